@@ -6,6 +6,7 @@ import (
 	"github.com/golang/protobuf/proto"
 
 	"github.com/hyperledger-labs/cckit/router"
+	"github.com/hyperledger-labs/cckit/serialize"
 	"github.com/hyperledger-labs/cckit/state"
 )
 
@@ -16,6 +17,11 @@ type (
 
 	// StateFn function can add mappings to state, for correct convertation in StateGet
 	StateFn func(router.Context) state.State
+
+	// valueEntry state entry with key and raw value, used in bulk import
+	valueEntry struct {
+		value *Value
+	}
 )
 
 var _ DebugStateServiceChaincode = &StateService{}
@@ -117,3 +123,28 @@ func (s *StateService) DeleteStates(ctx router.Context, prefixes *Prefixes) (*Pr
 
 	return &PrefixesMatchCount{Matches: matches}, nil
 }
+
+// ImportStates writes raw values to state, values are imported as export records without schema
+func (s *StateService) ImportStates(ctx router.Context, values *Values) (*CompositeKeys, error) {
+	records := make([]*ExportRecord, len(values.GetItems()))
+	for i, val := range values.GetItems() {
+		records[i] = &ExportRecord{Key: val.GetKey(), Value: val.GetValue()}
+	}
+	return ImportState(s.State(ctx), records)
+}
+
+func (s *StateService) ExportStates(ctx router.Context, req *ExportRequest) (*ExportPage, error) {
+	return ExportState(ctx.Stub(), s.State(ctx), req)
+}
+
+func (s *StateService) ImportRecords(ctx router.Context, page *ExportPage) (*CompositeKeys, error) {
+	return ImportState(s.State(ctx), page.GetRecords())
+}
+
+func (v *valueEntry) Key() (state.Key, error) {
+	return v.value.Key, nil
+}
+
+func (v *valueEntry) ToBytes(serialize.ToBytesConverter) ([]byte, error) {
+	return v.value.Value, nil
+}
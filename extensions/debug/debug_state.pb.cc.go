@@ -35,6 +35,12 @@ const (
 	DebugStateServiceChaincode_DeleteState = DebugStateServiceChaincodeMethodPrefix + "DeleteState"
 
 	DebugStateServiceChaincode_DeleteStates = DebugStateServiceChaincodeMethodPrefix + "DeleteStates"
+
+	DebugStateServiceChaincode_ImportStates = DebugStateServiceChaincodeMethodPrefix + "ImportStates"
+
+	DebugStateServiceChaincode_ExportStates = DebugStateServiceChaincodeMethodPrefix + "ExportStates"
+
+	DebugStateServiceChaincode_ImportRecords = DebugStateServiceChaincodeMethodPrefix + "ImportRecords"
 )
 
 // DebugStateServiceChaincode chaincode methods interface
@@ -48,6 +54,12 @@ type DebugStateServiceChaincode interface {
 	DeleteState(cckit_router.Context, *CompositeKey) (*Value, error)
 
 	DeleteStates(cckit_router.Context, *Prefixes) (*PrefixesMatchCount, error)
+
+	ImportStates(cckit_router.Context, *Values) (*CompositeKeys, error)
+
+	ExportStates(cckit_router.Context, *ExportRequest) (*ExportPage, error)
+
+	ImportRecords(cckit_router.Context, *ExportPage) (*CompositeKeys, error)
 }
 
 // RegisterDebugStateServiceChaincode registers service methods as chaincode router handlers
@@ -83,6 +95,24 @@ func RegisterDebugStateServiceChaincode(r *cckit_router.Group, cc DebugStateServ
 		},
 		cckit_defparam.Proto(&Prefixes{}))
 
+	r.Invoke(DebugStateServiceChaincode_ImportStates,
+		func(ctx cckit_router.Context) (interface{}, error) {
+			return cc.ImportStates(ctx, ctx.Param().(*Values))
+		},
+		cckit_defparam.Proto(&Values{}))
+
+	r.Query(DebugStateServiceChaincode_ExportStates,
+		func(ctx cckit_router.Context) (interface{}, error) {
+			return cc.ExportStates(ctx, ctx.Param().(*ExportRequest))
+		},
+		cckit_defparam.Proto(&ExportRequest{}))
+
+	r.Invoke(DebugStateServiceChaincode_ImportRecords,
+		func(ctx cckit_router.Context) (interface{}, error) {
+			return cc.ImportRecords(ctx, ctx.Param().(*ExportPage))
+		},
+		cckit_defparam.Proto(&ExportPage{}))
+
 	return nil
 }
 
@@ -200,3 +230,48 @@ func (c *DebugStateServiceGateway) DeleteStates(ctx context.Context, in *Prefixe
 		return res.(*PrefixesMatchCount), nil
 	}
 }
+
+func (c *DebugStateServiceGateway) ImportStates(ctx context.Context, in *Values) (*CompositeKeys, error) {
+	var inMsg interface{} = in
+	if v, ok := inMsg.(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if res, err := c.Invoker().Invoke(ctx, DebugStateServiceChaincode_ImportStates, []interface{}{in}, &CompositeKeys{}); err != nil {
+		return nil, err
+	} else {
+		return res.(*CompositeKeys), nil
+	}
+}
+
+func (c *DebugStateServiceGateway) ExportStates(ctx context.Context, in *ExportRequest) (*ExportPage, error) {
+	var inMsg interface{} = in
+	if v, ok := inMsg.(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if res, err := c.Invoker().Query(ctx, DebugStateServiceChaincode_ExportStates, []interface{}{in}, &ExportPage{}); err != nil {
+		return nil, err
+	} else {
+		return res.(*ExportPage), nil
+	}
+}
+
+func (c *DebugStateServiceGateway) ImportRecords(ctx context.Context, in *ExportPage) (*CompositeKeys, error) {
+	var inMsg interface{} = in
+	if v, ok := inMsg.(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if res, err := c.Invoker().Invoke(ctx, DebugStateServiceChaincode_ImportRecords, []interface{}{in}, &CompositeKeys{}); err != nil {
+		return nil, err
+	} else {
+		return res.(*CompositeKeys), nil
+	}
+}
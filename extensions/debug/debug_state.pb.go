@@ -329,6 +329,263 @@ func (x *Value) GetJson() string {
 	return ""
 }
 
+// State values
+type Values struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items []*Value `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *Values) Reset() {
+	*x = Values{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_debug_debug_state_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Values) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Values) ProtoMessage() {}
+
+func (x *Values) ProtoReflect() protoreflect.Message {
+	mi := &file_debug_debug_state_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Values.ProtoReflect.Descriptor instead.
+func (*Values) Descriptor() ([]byte, []int) {
+	return file_debug_debug_state_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Values) GetItems() []*Value {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// State export request, exports entries with key prefix or, if schemas are defined, only matched
+type ExportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// parts of key prefix. If empty, entries of mapped state are exported by namespaces of schemas mappings or,
+	// if schemas are not defined, by namespaces of all mappings followed by simple keys
+	Prefix []string `protobuf:"bytes,1,rep,name=prefix,proto3" json:"prefix,omitempty"`
+	// full names of proto schemas of mapped entries, entries with other values are skipped
+	Schemas []string `protobuf:"bytes,2,rep,name=schemas,proto3" json:"schemas,omitempty"`
+	// max number of entries fetched from state, default 100
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// bookmark of export page to continue from, returned with previous page
+	Bookmark string `protobuf:"bytes,4,opt,name=bookmark,proto3" json:"bookmark,omitempty"`
+}
+
+func (x *ExportRequest) Reset() {
+	*x = ExportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_debug_debug_state_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportRequest) ProtoMessage() {}
+
+func (x *ExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_debug_debug_state_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportRequest.ProtoReflect.Descriptor instead.
+func (*ExportRequest) Descriptor() ([]byte, []int) {
+	return file_debug_debug_state_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ExportRequest) GetPrefix() []string {
+	if x != nil {
+		return x.Prefix
+	}
+	return nil
+}
+
+func (x *ExportRequest) GetSchemas() []string {
+	if x != nil {
+		return x.Schemas
+	}
+	return nil
+}
+
+func (x *ExportRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ExportRequest) GetBookmark() string {
+	if x != nil {
+		return x.Bookmark
+	}
+	return ""
+}
+
+// Exported state entry
+type ExportRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key []string `protobuf:"bytes,1,rep,name=key,proto3" json:"key,omitempty"`
+	// raw state value
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// full name of proto schema of value, if entry is mapped
+	Schema string `protobuf:"bytes,3,opt,name=schema,proto3" json:"schema,omitempty"`
+	// value decoded with mapping as JSON, if entry is mapped
+	Json string `protobuf:"bytes,4,opt,name=json,proto3" json:"json,omitempty"`
+}
+
+func (x *ExportRecord) Reset() {
+	*x = ExportRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_debug_debug_state_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportRecord) ProtoMessage() {}
+
+func (x *ExportRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_debug_debug_state_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportRecord.ProtoReflect.Descriptor instead.
+func (*ExportRecord) Descriptor() ([]byte, []int) {
+	return file_debug_debug_state_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ExportRecord) GetKey() []string {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *ExportRecord) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *ExportRecord) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+func (x *ExportRecord) GetJson() string {
+	if x != nil {
+		return x.Json
+	}
+	return ""
+}
+
+// Page of exported state entries
+type ExportPage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Records []*ExportRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	// bookmark for next page, empty if all entries are exported
+	Bookmark string `protobuf:"bytes,2,opt,name=bookmark,proto3" json:"bookmark,omitempty"`
+}
+
+func (x *ExportPage) Reset() {
+	*x = ExportPage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_debug_debug_state_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportPage) ProtoMessage() {}
+
+func (x *ExportPage) ProtoReflect() protoreflect.Message {
+	mi := &file_debug_debug_state_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportPage.ProtoReflect.Descriptor instead.
+func (*ExportPage) Descriptor() ([]byte, []int) {
+	return file_debug_debug_state_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ExportPage) GetRecords() []*ExportRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *ExportPage) GetBookmark() string {
+	if x != nil {
+		return x.Bookmark
+	}
+	return ""
+}
+
 var File_debug_debug_state_proto protoreflect.FileDescriptor
 
 var file_debug_debug_state_proto_rawDesc = []byte{
@@ -363,43 +620,88 @@ var file_debug_debug_state_proto_rawDesc = []byte{
 	0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
 	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12,
 	0x12, 0x0a, 0x04, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6a,
-	0x73, 0x6f, 0x6e, 0x32, 0x88, 0x04, 0x0a, 0x11, 0x44, 0x65, 0x62, 0x75, 0x67, 0x53, 0x74, 0x61,
-	0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x66, 0x0a, 0x08, 0x4c, 0x69, 0x73,
-	0x74, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x18, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f,
-	0x6e, 0x73, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x1a,
-	0x1f, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x64, 0x65, 0x62,
-	0x75, 0x67, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x73,
-	0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19, 0x12, 0x17, 0x2f, 0x64, 0x65, 0x62, 0x75, 0x67,
-	0x2f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2f, 0x6b, 0x65, 0x79, 0x73, 0x2f, 0x7b, 0x6b, 0x65, 0x79,
-	0x7d, 0x12, 0x5f, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x2e,
-	0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67,
-	0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x1a, 0x17, 0x2e,
-	0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67,
-	0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x1a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x12, 0x12,
-	0x2f, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2f, 0x7b, 0x6b, 0x65,
-	0x79, 0x7d, 0x12, 0x55, 0x0a, 0x08, 0x50, 0x75, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x17,
-	0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x64, 0x65, 0x62, 0x75,
-	0x67, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x17, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73,
-	0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65,
-	0x22, 0x17, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x11, 0x1a, 0x0c, 0x2f, 0x64, 0x65, 0x62, 0x75, 0x67,
-	0x2f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x62, 0x0a, 0x0b, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e,
-	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x43, 0x6f, 0x6d, 0x70,
-	0x6f, 0x73, 0x69, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x1a, 0x17, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e,
-	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x22, 0x1a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x2a, 0x12, 0x2f, 0x64, 0x65, 0x62, 0x75,
-	0x67, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2f, 0x7b, 0x6b, 0x65, 0x79, 0x7d, 0x12, 0x6f, 0x0a,
-	0x0c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x1a, 0x2e,
-	0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67,
-	0x2e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x65, 0x73, 0x1a, 0x24, 0x2e, 0x65, 0x78, 0x74, 0x65,
-	0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x50, 0x72, 0x65,
-	0x66, 0x69, 0x78, 0x65, 0x73, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22,
-	0x1d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x22, 0x12, 0x2f, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2f,
-	0x73, 0x74, 0x61, 0x74, 0x65, 0x2f, 0x63, 0x6c, 0x65, 0x61, 0x6e, 0x3a, 0x01, 0x2a, 0x42, 0x34,
-	0x5a, 0x32, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x79, 0x70,
-	0x65, 0x72, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x2d, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x63, 0x63,
-	0x6b, 0x69, 0x74, 0x2f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x64,
-	0x65, 0x62, 0x75, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x6f, 0x6e, 0x22, 0x37, 0x0a, 0x06, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x2d, 0x0a,
+	0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x65,
+	0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x7a, 0x0a, 0x0d,
+	0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x70,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x73, 0x12,
+	0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x62, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x62, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x22, 0x62, 0x0a, 0x0c, 0x45, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x6a, 0x73, 0x6f, 0x6e,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6a, 0x73, 0x6f, 0x6e, 0x22, 0x62, 0x0a, 0x0a,
+	0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x67, 0x65, 0x12, 0x38, 0x0a, 0x07, 0x72, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x65, 0x78,
+	0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x45,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x07, 0x72, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b,
+	0x32, 0xd7, 0x06, 0x0a, 0x11, 0x44, 0x65, 0x62, 0x75, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x66, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x4b, 0x65,
+	0x79, 0x73, 0x12, 0x18, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
+	0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x1a, 0x1f, 0x2e, 0x65,
+	0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e,
+	0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x22, 0x1f, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x19, 0x12, 0x17, 0x2f, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2f, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x2f, 0x6b, 0x65, 0x79, 0x73, 0x2f, 0x7b, 0x6b, 0x65, 0x79, 0x7d, 0x12, 0x5f,
+	0x0a, 0x08, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x2e, 0x65, 0x78, 0x74,
+	0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x43, 0x6f,
+	0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x1a, 0x17, 0x2e, 0x65, 0x78, 0x74,
+	0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x22, 0x1a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x12, 0x12, 0x2f, 0x64, 0x65,
+	0x62, 0x75, 0x67, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2f, 0x7b, 0x6b, 0x65, 0x79, 0x7d, 0x12,
+	0x55, 0x0a, 0x08, 0x50, 0x75, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x17, 0x2e, 0x65, 0x78,
+	0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x1a, 0x17, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x17, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x11, 0x1a, 0x0c, 0x2f, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2f, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x62, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x65, 0x4b, 0x65, 0x79, 0x1a, 0x17, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x1a,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x2a, 0x12, 0x2f, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2f, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x2f, 0x7b, 0x6b, 0x65, 0x79, 0x7d, 0x12, 0x6f, 0x0a, 0x0c, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x1a, 0x2e, 0x65, 0x78, 0x74,
+	0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x50, 0x72,
+	0x65, 0x66, 0x69, 0x78, 0x65, 0x73, 0x1a, 0x24, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78,
+	0x65, 0x73, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x1d, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x17, 0x22, 0x12, 0x2f, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2f, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x2f, 0x63, 0x6c, 0x65, 0x61, 0x6e, 0x3a, 0x01, 0x2a, 0x12, 0x69, 0x0a, 0x0c, 0x49,
+	0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x18, 0x2e, 0x65, 0x78,
+	0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x1a, 0x1f, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x22, 0x1e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x18, 0x22, 0x13,
+	0x2f, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2f, 0x69, 0x6d, 0x70,
+	0x6f, 0x72, 0x74, 0x3a, 0x01, 0x2a, 0x12, 0x6a, 0x0a, 0x0c, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x1f, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72,
+	0x74, 0x50, 0x61, 0x67, 0x65, 0x22, 0x1b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15, 0x12, 0x13, 0x2f,
+	0x64, 0x65, 0x62, 0x75, 0x67, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2f, 0x65, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x12, 0x76, 0x0a, 0x0d, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x73, 0x12, 0x1c, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x2e, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x67,
+	0x65, 0x1a, 0x1f, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x64,
+	0x65, 0x62, 0x75, 0x67, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x65, 0x4b, 0x65,
+	0x79, 0x73, 0x22, 0x26, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x20, 0x22, 0x1b, 0x2f, 0x64, 0x65, 0x62,
+	0x75, 0x67, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2f, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x2f,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x3a, 0x01, 0x2a, 0x42, 0x34, 0x5a, 0x32, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x79, 0x70, 0x65, 0x72, 0x6c, 0x65,
+	0x64, 0x67, 0x65, 0x72, 0x2d, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x63, 0x63, 0x6b, 0x69, 0x74, 0x2f,
+	0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x64, 0x65, 0x62, 0x75, 0x67,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -414,7 +716,7 @@ func file_debug_debug_state_proto_rawDescGZIP() []byte {
 	return file_debug_debug_state_proto_rawDescData
 }
 
-var file_debug_debug_state_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_debug_debug_state_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_debug_debug_state_proto_goTypes = []interface{}{
 	(*Prefix)(nil),             // 0: extensions.debug.Prefix
 	(*Prefixes)(nil),           // 1: extensions.debug.Prefixes
@@ -422,27 +724,39 @@ var file_debug_debug_state_proto_goTypes = []interface{}{
 	(*CompositeKeys)(nil),      // 3: extensions.debug.CompositeKeys
 	(*CompositeKey)(nil),       // 4: extensions.debug.CompositeKey
 	(*Value)(nil),              // 5: extensions.debug.Value
-	nil,                        // 6: extensions.debug.PrefixesMatchCount.MatchesEntry
+	(*Values)(nil),             // 6: extensions.debug.Values
+	(*ExportRequest)(nil),      // 7: extensions.debug.ExportRequest
+	(*ExportRecord)(nil),       // 8: extensions.debug.ExportRecord
+	(*ExportPage)(nil),         // 9: extensions.debug.ExportPage
+	nil,                        // 10: extensions.debug.PrefixesMatchCount.MatchesEntry
 }
 var file_debug_debug_state_proto_depIdxs = []int32{
-	0, // 0: extensions.debug.Prefixes.prefixes:type_name -> extensions.debug.Prefix
-	6, // 1: extensions.debug.PrefixesMatchCount.matches:type_name -> extensions.debug.PrefixesMatchCount.MatchesEntry
-	4, // 2: extensions.debug.CompositeKeys.keys:type_name -> extensions.debug.CompositeKey
-	0, // 3: extensions.debug.DebugStateService.ListKeys:input_type -> extensions.debug.Prefix
-	4, // 4: extensions.debug.DebugStateService.GetState:input_type -> extensions.debug.CompositeKey
-	5, // 5: extensions.debug.DebugStateService.PutState:input_type -> extensions.debug.Value
-	4, // 6: extensions.debug.DebugStateService.DeleteState:input_type -> extensions.debug.CompositeKey
-	1, // 7: extensions.debug.DebugStateService.DeleteStates:input_type -> extensions.debug.Prefixes
-	3, // 8: extensions.debug.DebugStateService.ListKeys:output_type -> extensions.debug.CompositeKeys
-	5, // 9: extensions.debug.DebugStateService.GetState:output_type -> extensions.debug.Value
-	5, // 10: extensions.debug.DebugStateService.PutState:output_type -> extensions.debug.Value
-	5, // 11: extensions.debug.DebugStateService.DeleteState:output_type -> extensions.debug.Value
-	2, // 12: extensions.debug.DebugStateService.DeleteStates:output_type -> extensions.debug.PrefixesMatchCount
-	8, // [8:13] is the sub-list for method output_type
-	3, // [3:8] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	0,  // 0: extensions.debug.Prefixes.prefixes:type_name -> extensions.debug.Prefix
+	10, // 1: extensions.debug.PrefixesMatchCount.matches:type_name -> extensions.debug.PrefixesMatchCount.MatchesEntry
+	4,  // 2: extensions.debug.CompositeKeys.keys:type_name -> extensions.debug.CompositeKey
+	5,  // 3: extensions.debug.Values.items:type_name -> extensions.debug.Value
+	8,  // 4: extensions.debug.ExportPage.records:type_name -> extensions.debug.ExportRecord
+	0,  // 5: extensions.debug.DebugStateService.ListKeys:input_type -> extensions.debug.Prefix
+	4,  // 6: extensions.debug.DebugStateService.GetState:input_type -> extensions.debug.CompositeKey
+	5,  // 7: extensions.debug.DebugStateService.PutState:input_type -> extensions.debug.Value
+	4,  // 8: extensions.debug.DebugStateService.DeleteState:input_type -> extensions.debug.CompositeKey
+	1,  // 9: extensions.debug.DebugStateService.DeleteStates:input_type -> extensions.debug.Prefixes
+	6,  // 10: extensions.debug.DebugStateService.ImportStates:input_type -> extensions.debug.Values
+	7,  // 11: extensions.debug.DebugStateService.ExportStates:input_type -> extensions.debug.ExportRequest
+	9,  // 12: extensions.debug.DebugStateService.ImportRecords:input_type -> extensions.debug.ExportPage
+	3,  // 13: extensions.debug.DebugStateService.ListKeys:output_type -> extensions.debug.CompositeKeys
+	5,  // 14: extensions.debug.DebugStateService.GetState:output_type -> extensions.debug.Value
+	5,  // 15: extensions.debug.DebugStateService.PutState:output_type -> extensions.debug.Value
+	5,  // 16: extensions.debug.DebugStateService.DeleteState:output_type -> extensions.debug.Value
+	2,  // 17: extensions.debug.DebugStateService.DeleteStates:output_type -> extensions.debug.PrefixesMatchCount
+	3,  // 18: extensions.debug.DebugStateService.ImportStates:output_type -> extensions.debug.CompositeKeys
+	9,  // 19: extensions.debug.DebugStateService.ExportStates:output_type -> extensions.debug.ExportPage
+	3,  // 20: extensions.debug.DebugStateService.ImportRecords:output_type -> extensions.debug.CompositeKeys
+	13, // [13:21] is the sub-list for method output_type
+	5,  // [5:13] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_debug_debug_state_proto_init() }
@@ -523,6 +837,54 @@ func file_debug_debug_state_proto_init() {
 				return nil
 			}
 		}
+		file_debug_debug_state_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Values); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_debug_debug_state_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_debug_debug_state_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_debug_debug_state_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportPage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -530,7 +892,7 @@ func file_debug_debug_state_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_debug_debug_state_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -566,6 +928,13 @@ type DebugStateServiceClient interface {
 	DeleteState(ctx context.Context, in *CompositeKey, opts ...grpc.CallOption) (*Value, error)
 	// Delete all states or, if prefixes are defined, only prefix matched
 	DeleteStates(ctx context.Context, in *Prefixes, opts ...grpc.CallOption) (*PrefixesMatchCount, error)
+	// Import state values, all values are validated first, then written in key order
+	ImportStates(ctx context.Context, in *Values, opts ...grpc.CallOption) (*CompositeKeys, error)
+	// Export page of state entries with values decoded by mappings, ordered by key
+	ExportStates(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*ExportPage, error)
+	// Import exported state entries, all records are validated first, then written in key order.
+	// Mapped entries are put with mappings, so key refs are created with entries
+	ImportRecords(ctx context.Context, in *ExportPage, opts ...grpc.CallOption) (*CompositeKeys, error)
 }
 
 type debugStateServiceClient struct {
@@ -621,6 +990,33 @@ func (c *debugStateServiceClient) DeleteStates(ctx context.Context, in *Prefixes
 	return out, nil
 }
 
+func (c *debugStateServiceClient) ImportStates(ctx context.Context, in *Values, opts ...grpc.CallOption) (*CompositeKeys, error) {
+	out := new(CompositeKeys)
+	err := c.cc.Invoke(ctx, "/extensions.debug.DebugStateService/ImportStates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugStateServiceClient) ExportStates(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*ExportPage, error) {
+	out := new(ExportPage)
+	err := c.cc.Invoke(ctx, "/extensions.debug.DebugStateService/ExportStates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugStateServiceClient) ImportRecords(ctx context.Context, in *ExportPage, opts ...grpc.CallOption) (*CompositeKeys, error) {
+	out := new(CompositeKeys)
+	err := c.cc.Invoke(ctx, "/extensions.debug.DebugStateService/ImportRecords", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DebugStateServiceServer is the server API for DebugStateService service.
 type DebugStateServiceServer interface {
 	// Get keys list, returns all keys or, if prefixes are defined, only prefix matched
@@ -633,6 +1029,13 @@ type DebugStateServiceServer interface {
 	DeleteState(context.Context, *CompositeKey) (*Value, error)
 	// Delete all states or, if prefixes are defined, only prefix matched
 	DeleteStates(context.Context, *Prefixes) (*PrefixesMatchCount, error)
+	// Import state values, all values are validated first, then written in key order
+	ImportStates(context.Context, *Values) (*CompositeKeys, error)
+	// Export page of state entries with values decoded by mappings, ordered by key
+	ExportStates(context.Context, *ExportRequest) (*ExportPage, error)
+	// Import exported state entries, all records are validated first, then written in key order.
+	// Mapped entries are put with mappings, so key refs are created with entries
+	ImportRecords(context.Context, *ExportPage) (*CompositeKeys, error)
 }
 
 // UnimplementedDebugStateServiceServer can be embedded to have forward compatible implementations.
@@ -654,6 +1057,15 @@ func (*UnimplementedDebugStateServiceServer) DeleteState(context.Context, *Compo
 func (*UnimplementedDebugStateServiceServer) DeleteStates(context.Context, *Prefixes) (*PrefixesMatchCount, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteStates not implemented")
 }
+func (*UnimplementedDebugStateServiceServer) ImportStates(context.Context, *Values) (*CompositeKeys, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportStates not implemented")
+}
+func (*UnimplementedDebugStateServiceServer) ExportStates(context.Context, *ExportRequest) (*ExportPage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportStates not implemented")
+}
+func (*UnimplementedDebugStateServiceServer) ImportRecords(context.Context, *ExportPage) (*CompositeKeys, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportRecords not implemented")
+}
 
 func RegisterDebugStateServiceServer(s *grpc.Server, srv DebugStateServiceServer) {
 	s.RegisterService(&_DebugStateService_serviceDesc, srv)
@@ -749,6 +1161,60 @@ func _DebugStateService_DeleteStates_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DebugStateService_ImportStates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Values)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugStateServiceServer).ImportStates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extensions.debug.DebugStateService/ImportStates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugStateServiceServer).ImportStates(ctx, req.(*Values))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugStateService_ExportStates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugStateServiceServer).ExportStates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extensions.debug.DebugStateService/ExportStates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugStateServiceServer).ExportStates(ctx, req.(*ExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DebugStateService_ImportRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportPage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugStateServiceServer).ImportRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extensions.debug.DebugStateService/ImportRecords",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugStateServiceServer).ImportRecords(ctx, req.(*ExportPage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _DebugStateService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "extensions.debug.DebugStateService",
 	HandlerType: (*DebugStateServiceServer)(nil),
@@ -773,6 +1239,18 @@ var _DebugStateService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteStates",
 			Handler:    _DebugStateService_DeleteStates_Handler,
 		},
+		{
+			MethodName: "ImportStates",
+			Handler:    _DebugStateService_ImportStates_Handler,
+		},
+		{
+			MethodName: "ExportStates",
+			Handler:    _DebugStateService_ExportStates_Handler,
+		},
+		{
+			MethodName: "ImportRecords",
+			Handler:    _DebugStateService_ImportRecords_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "debug/debug_state.proto",
@@ -49,3 +49,29 @@ func (this *CompositeKey) Validate() error {
 func (this *Value) Validate() error {
 	return nil
 }
+func (this *Values) Validate() error {
+	for _, item := range this.Items {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Items", err)
+			}
+		}
+	}
+	return nil
+}
+func (this *ExportRequest) Validate() error {
+	return nil
+}
+func (this *ExportRecord) Validate() error {
+	return nil
+}
+func (this *ExportPage) Validate() error {
+	for _, item := range this.Records {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Records", err)
+			}
+		}
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"strconv"
 
 	"github.com/hyperledger-labs/cckit/extensions/debug"
+	"github.com/hyperledger-labs/cckit/state"
 	testcc "github.com/hyperledger-labs/cckit/testing"
 )
 
@@ -215,6 +216,39 @@ var _ = Describe(`State`, func() {
 				Expect(keys.Keys).To(HaveLen(0)) //total state after last clean and delete in previous test
 			})
 		})
+
+		It("Disallow to import values with duplicated keys", func() {
+			cc.From(Owner).Tx(func() {
+				_, err := dbg.ImportStates(ctx, &debug.Values{Items: []*debug.Value{
+					{Key: []string{`prefixC`, `key1`}, Value: []byte(`value1`)},
+					{Key: []string{`prefixC`, `key1`}, Value: []byte(`value2`)},
+				}})
+				Expect(err).To(MatchError(ContainSubstring(state.ErrBulkKeyDuplicated.Error())))
+			})
+
+			cc.From(Owner).Tx(func() {
+				keys, _ := dbg.ListKeys(ctx, nil)
+				Expect(keys.Keys).To(HaveLen(0))
+			})
+		})
+
+		It("Allow to import values", func() {
+			cc.From(Owner).Tx(func() {
+				keys, err := dbg.ImportStates(ctx, &debug.Values{Items: []*debug.Value{
+					{Key: []string{`prefixC`, `key2`}, Value: []byte(`value2`)},
+					{Key: []string{`prefixC`, `key1`}, Value: []byte(`value1`)},
+				}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keys.Keys).To(HaveLen(2))
+				Expect(keys.Keys[0].Key).To(Equal([]string{`prefixC`, `key2`}))
+			})
+
+			cc.From(Owner).Tx(func() {
+				val, err := dbg.GetState(ctx, &debug.CompositeKey{Key: []string{`prefixC`, `key1`}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(val.Value).To(Equal([]byte(`value1`)))
+			})
+		})
 	})
 
 })
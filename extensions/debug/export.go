@@ -0,0 +1,361 @@
+package debug
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/hyperledger-labs/cckit/serialize"
+	"github.com/hyperledger-labs/cckit/state"
+	"github.com/hyperledger-labs/cckit/state/mapping"
+)
+
+const (
+	// DefaultExportPageSize number of state entries fetched for export page, if page size is not set
+	DefaultExportPageSize = 100
+
+	// exportBookmarkSep separates index of exported key prefix and range query bookmark in export bookmark
+	exportBookmarkSep = `:`
+
+	// compositeKeyNamespace first char of composite key
+	compositeKeyNamespace = "\x00"
+)
+
+var (
+	// ErrInvalidExportRecord occurs when imported record cannot be validated
+	ErrInvalidExportRecord = errors.New(`invalid export record`)
+
+	// ErrExportPrefixRequired occurs when export request has no key prefix and prefixes can't be derived from mappings
+	ErrExportPrefixRequired = errors.New(`export prefix required`)
+
+	// ErrInvalidExportBookmark occurs when bookmark of export request is not returned with export page
+	ErrInvalidExportBookmark = errors.New(`invalid export bookmark`)
+)
+
+// ExportState returns page of state entries, matched by prefix and schemas of request, ordered by key.
+// Values of mapped state are decoded with mappings. Stub can be testing.MockStub.
+// On peer range query doesn't return composite keys, so without request prefix entries are exported
+// by namespaces of request schemas mappings or, if schemas are not set, by namespaces of all mappings
+// followed by simple (not composite) keys. Key refs are not exported then, they are created with mapped entries on import
+func ExportState(stub shim.ChaincodeStubInterface, s state.State, req *ExportRequest) (*ExportPage, error) {
+	prefixes, err := exportPrefixes(s, req)
+	if err != nil {
+		return nil, err
+	}
+
+	pos, bookmark, err := parseExportBookmark(req.GetBookmark(), len(prefixes))
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := req.GetPageSize()
+	if pageSize <= 0 {
+		pageSize = DefaultExportPageSize
+	}
+
+	var (
+		iter   shim.StateQueryIteratorInterface
+		md     *peer.QueryResponseMetadata
+		prefix = prefixes[pos]
+	)
+	if len(prefix) == 0 {
+		iter, md, err = stub.GetStateByRangeWithPagination(``, ``, pageSize, bookmark)
+	} else {
+		iter, md, err = stub.GetStateByPartialCompositeKeyWithPagination(prefix[0], prefix[1:], pageSize, bookmark)
+	}
+	if err != nil {
+		return nil, fmt.Errorf(`export: %w`, err)
+	}
+	defer func() { _ = iter.Close() }()
+
+	schemas := make(map[string]bool)
+	for _, schema := range req.GetSchemas() {
+		schemas[schema] = true
+	}
+
+	page := &ExportPage{}
+	switch {
+	case md.GetBookmark() != ``:
+		page.Bookmark = strconv.Itoa(pos) + exportBookmarkSep + md.GetBookmark()
+	case pos+1 < len(prefixes):
+		page.Bookmark = strconv.Itoa(pos+1) + exportBookmarkSep
+	}
+
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		// testing.MockStub returns composite keys with simple keys
+		if len(prefix) == 0 && strings.HasPrefix(kv.Key, compositeKeyNamespace) {
+			continue
+		}
+
+		key, err := state.KeyFromComposite(stub, kv.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		record := &ExportRecord{Key: key, Value: kv.Value}
+		if err = decodeRecord(s, record); err != nil {
+			return nil, err
+		}
+
+		if len(schemas) > 0 && !schemas[record.Schema] {
+			continue
+		}
+		page.Records = append(page.Records, record)
+	}
+
+	return page, nil
+}
+
+// exportPrefixes returns key prefixes of export request: request prefix or namespaces of mappings.
+// Empty prefix is range of simple keys
+func exportPrefixes(s state.State, req *ExportRequest) ([]state.Key, error) {
+	if len(req.GetPrefix()) > 0 {
+		return []state.Key{req.GetPrefix()}, nil
+	}
+
+	mapped, ok := s.(mapping.MappedState)
+	if !ok {
+		return nil, ErrExportPrefixRequired
+	}
+
+	// requested schemas, true if mapping of schema is found
+	schemas := make(map[string]bool)
+	for _, schema := range req.GetSchemas() {
+		schemas[schema] = false
+	}
+	all := len(schemas) == 0
+
+	var namespaces []state.Key
+	for _, m := range mapped.Mappings() {
+		if m.KeyerFor() != nil {
+			continue
+		}
+		msg, ok := m.Schema().(proto.Message)
+		if !ok {
+			continue
+		}
+		name := string(proto.MessageName(msg))
+		if _, ok = schemas[name]; !all && !ok {
+			continue
+		}
+		schemas[name] = true
+		namespaces = append(namespaces, m.Namespace())
+	}
+
+	for _, schema := range req.GetSchemas() {
+		if !schemas[schema] {
+			return nil, fmt.Errorf(`export schema=%s: %w`, schema, mapping.ErrStateMappingNotFound)
+		}
+	}
+
+	sort.Slice(namespaces, func(i, j int) bool {
+		return namespaces[i].String() < namespaces[j].String()
+	})
+
+	var prefixes []state.Key
+	for _, namespace := range namespaces {
+		if !hasKeyPrefix(namespace, prefixes...) {
+			prefixes = append(prefixes, namespace)
+		}
+	}
+
+	if all {
+		prefixes = append(prefixes, state.Key{})
+	}
+	return prefixes, nil
+}
+
+// hasKeyPrefix reports whether key begins with parts of one of prefixes,
+// entries of such key are already exported with prefix
+func hasKeyPrefix(key state.Key, prefixes ...state.Key) bool {
+	for _, prefix := range prefixes {
+		if len(key) < len(prefix) {
+			continue
+		}
+		matched := true
+		for i := range prefix {
+			matched = matched && key[i] == prefix[i]
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// parseExportBookmark returns index of exported prefix and range query bookmark
+func parseExportBookmark(bookmark string, prefixesCount int) (int, string, error) {
+	if bookmark == `` {
+		return 0, ``, nil
+	}
+
+	parts := strings.SplitN(bookmark, exportBookmarkSep, 2)
+	if len(parts) != 2 {
+		return 0, ``, ErrInvalidExportBookmark
+	}
+	pos, err := strconv.Atoi(parts[0])
+	if err != nil || pos < 0 || pos >= prefixesCount {
+		return 0, ``, ErrInvalidExportBookmark
+	}
+	return pos, parts[1], nil
+}
+
+// ExportAllState returns all state entries, matched by prefix and schemas of request, fetched page by page
+func ExportAllState(stub shim.ChaincodeStubInterface, s state.State, req *ExportRequest) ([]*ExportRecord, error) {
+	pageReq := &ExportRequest{
+		Prefix:   req.GetPrefix(),
+		Schemas:  req.GetSchemas(),
+		PageSize: req.GetPageSize(),
+		Bookmark: req.GetBookmark(),
+	}
+
+	var records []*ExportRecord
+	for {
+		page, err := ExportState(stub, s, pageReq)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, page.Records...)
+
+		if page.Bookmark == `` {
+			return records, nil
+		}
+		pageReq.Bookmark = page.Bookmark
+	}
+}
+
+// decodeRecord sets schema and JSON of record, if state is mapped and record value is mapped entry
+func decodeRecord(s state.State, record *ExportRecord) error {
+	mapped, ok := s.(mapping.MappedState)
+	if !ok {
+		return nil
+	}
+
+	val, err := mapped.Mappings().Resolve(record.Key[0], record.Value, s.Serializer())
+	if err != nil {
+		// entries without mapping, for example key refs, are exported as is
+		if errors.Is(err, mapping.ErrStateMappingNotFound) {
+			return nil
+		}
+		return fmt.Errorf(`decode key=%s: %w`, record.Key, err)
+	}
+
+	msg, ok := val.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	// canonical JSON, protojson output is not stable
+	jsonVal, err := serialize.CanonicalJSONProtoMarshal(msg, &protojson.MarshalOptions{UseProtoNames: true})
+	if err != nil {
+		return err
+	}
+	record.Schema = string(proto.MessageName(msg))
+	record.Json = string(jsonVal)
+	return nil
+}
+
+// ImportState validates all records, then writes them to state in key order.
+// Record without value is created from JSON with schema. Records of mapped entries are put to mapped state,
+// so key refs and other mapped data are created with entries, other records are written as is
+func ImportState(s state.State, records []*ExportRecord) (*CompositeKeys, error) {
+	entries := make([]interface{}, len(records))
+	for i, record := range records {
+		entry, err := recordEntry(s, record)
+		if err != nil {
+			return nil, fmt.Errorf(`%w: position=%d, key=%s: %s`, ErrInvalidExportRecord, i, record.Key, err)
+		}
+		entries[i] = entry
+	}
+
+	results, err := s.PutMany(entries...)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := &CompositeKeys{}
+	for _, key := range results.Keys() {
+		keys.Keys = append(keys.Keys, &CompositeKey{Key: key})
+	}
+	return keys, nil
+}
+
+// recordEntry returns validated mapped entry of record or raw state entry, if schema is not mapped
+func recordEntry(s state.State, record *ExportRecord) (interface{}, error) {
+	if len(record.Key) == 0 {
+		return nil, errors.New(`key is empty`)
+	}
+
+	if record.Schema == `` {
+		if len(record.Value) == 0 {
+			return nil, errors.New(`value is empty`)
+		}
+		return &valueEntry{value: &Value{Key: record.Key, Value: record.Value}}, nil
+	}
+
+	msg, value, err := recordValue(s, record)
+	if err != nil {
+		return nil, err
+	}
+
+	mapped, ok := s.(mapping.MappedState)
+	if !ok || !mapped.Mappings().Exists(msg) {
+		return &valueEntry{value: &Value{Key: record.Key, Value: value}}, nil
+	}
+
+	key, err := mapped.Mappings().PrimaryKey(msg)
+	if err != nil {
+		return nil, err
+	}
+	if key.String() != state.Key(record.Key).String() {
+		return nil, fmt.Errorf(`primary key of value %s doesn't match record key`, key)
+	}
+	return msg, nil
+}
+
+// recordValue returns validated proto value of record with schema and its serialized form
+func recordValue(s state.State, record *ExportRecord) (proto.Message, []byte, error) {
+	msgType, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(record.Schema))
+	if err != nil {
+		return nil, nil, fmt.Errorf(`schema=%s: %w`, record.Schema, err)
+	}
+
+	var msg proto.Message
+	value := record.Value
+	if len(value) > 0 {
+		decoded, err := s.Serializer().FromBytesTo(value, msgType.New().Interface())
+		if err != nil {
+			return nil, nil, fmt.Errorf(`decode value: %w`, err)
+		}
+		msg = decoded.(proto.Message)
+	} else {
+		msg = msgType.New().Interface()
+		if err = protojson.Unmarshal([]byte(record.Json), msg); err != nil {
+			return nil, nil, fmt.Errorf(`decode json: %w`, err)
+		}
+		if value, err = s.Serializer().ToBytesFrom(msg); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if v, ok := msg.(interface{ Validate() error }); ok {
+		if err = v.Validate(); err != nil {
+			return nil, nil, err
+		}
+	}
+	return msg, value, nil
+}
@@ -0,0 +1,253 @@
+package debug_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/hyperledger-labs/cckit/extensions/debug"
+	"github.com/hyperledger-labs/cckit/router"
+	"github.com/hyperledger-labs/cckit/state"
+	"github.com/hyperledger-labs/cckit/state/mapping"
+	"github.com/hyperledger-labs/cckit/state/mapping/testdata/schema"
+	testcc "github.com/hyperledger-labs/cckit/testing"
+)
+
+var _ = Describe(`State export and import`, func() {
+
+	var (
+		mappings = mapping.StateMappings{}.Add(&schema.EntityWithIndexes{},
+			mapping.PKeyId(),
+			mapping.List(&schema.EntityWithIndexesList{}),
+			mapping.UniqKey(`ExternalId`))
+
+		dbg = &debug.StateService{
+			State: func(ctx router.Context) state.State {
+				return mapping.WrapState(ctx.State(), mappings)
+			},
+		}
+
+		cc, ctx = testcc.NewTxHandler(`export`)
+
+		exportAll = func(cc *testcc.TxHandler, ctx router.Context, req *debug.ExportRequest) []*debug.ExportRecord {
+			var records []*debug.ExportRecord
+			cc.Tx(func() {
+				var err error
+				records, err = debug.ExportAllState(ctx.Stub(), dbg.State(ctx), req)
+				Expect(err).NotTo(HaveOccurred())
+			})
+			return records
+		}
+
+		// exportPrefixes exports entries, key refs and raw entries
+		exportPrefixes = func(cc *testcc.TxHandler, ctx router.Context) []*debug.ExportRecord {
+			var records []*debug.ExportRecord
+			for _, prefix := range []string{`EntityWithIndexes`, mapping.KeyRefNamespace, `raw`} {
+				records = append(records, exportAll(cc, ctx, &debug.ExportRequest{Prefix: []string{prefix}})...)
+			}
+			return records
+		}
+
+		snapshot []*debug.ExportRecord
+	)
+
+	It("Allow to export state with decoded mapped values", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			for _, id := range []string{`aaa`, `bbb`, `ccc`} {
+				Expect(s.Insert(&schema.EntityWithIndexes{Id: id, ExternalId: id + `_ext`, Value: 1})).To(Succeed())
+			}
+			Expect(ctx.State().Put(state.Key{`raw`, `1`}, []byte(`raw value`))).To(Succeed())
+		})
+
+		snapshot = exportPrefixes(cc, ctx)
+		// 3 entities, 3 uniq key index entries and raw entry
+		Expect(snapshot).To(HaveLen(7))
+
+		var mapped []*debug.ExportRecord
+		for _, r := range snapshot {
+			if r.Schema != `` {
+				mapped = append(mapped, r)
+			}
+		}
+		Expect(mapped).To(HaveLen(3))
+		Expect(mapped[0].Key).To(Equal([]string{`EntityWithIndexes`, `aaa`}))
+		Expect(mapped[0].Schema).To(Equal(`schema.EntityWithIndexes`))
+		Expect(mapped[0].Json).To(Equal(`{"external_id":"aaa_ext","id":"aaa","value":1}`))
+	})
+
+	It("Allow to export state by pages with bookmark", func() {
+		cc.Tx(func() {
+			req := &debug.ExportRequest{Prefix: []string{`EntityWithIndexes`}, PageSize: 2}
+			page, err := dbg.ExportStates(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(page.Records).To(HaveLen(2))
+			Expect(page.Bookmark).NotTo(BeEmpty())
+
+			req.Bookmark = page.Bookmark
+			next, err := dbg.ExportStates(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(next.Records).To(HaveLen(1))
+			Expect(next.Bookmark).To(BeEmpty())
+
+			Expect(append(page.Records, next.Records...)).To(Equal(snapshot[:3]))
+		})
+	})
+
+	It("Disallow to export state without prefix, if state is not mapped", func() {
+		_, err := debug.ExportState(cc.MockStub, state.NewState(cc.MockStub, zap.NewNop()), &debug.ExportRequest{})
+		Expect(err).To(MatchError(debug.ErrExportPrefixRequired))
+	})
+
+	It("Disallow to export state with invalid bookmark", func() {
+		cc.Tx(func() {
+			_, err := dbg.ExportStates(ctx, &debug.ExportRequest{Bookmark: `5:`})
+			Expect(err).To(MatchError(debug.ErrInvalidExportBookmark))
+		})
+	})
+
+	It("Disallow to export mapped entries, which can't be decoded", func() {
+		invalidCC, invalidCtx := testcc.NewTxHandler(`export invalid`)
+		invalidCC.Tx(func() {
+			Expect(invalidCtx.State().Put(state.Key{`EntityWithIndexes`, `bad`}, []byte{0xff})).To(Succeed())
+		})
+
+		invalidCC.Tx(func() {
+			_, err := dbg.ExportStates(invalidCtx, &debug.ExportRequest{Prefix: []string{`EntityWithIndexes`}})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	It("Allow to export state filtered by prefix and schema", func() {
+		Expect(exportAll(cc, ctx, &debug.ExportRequest{Prefix: []string{`EntityWithIndexes`, `bbb`}})).To(HaveLen(1))
+		Expect(exportAll(cc, ctx, &debug.ExportRequest{Prefix: []string{`EntityWithIndexes`},
+			Schemas: []string{`schema.EntityWithIndexes`}, PageSize: 2})).To(HaveLen(3))
+		Expect(exportAll(cc, ctx, &debug.ExportRequest{Prefix: []string{mapping.KeyRefNamespace},
+			Schemas: []string{`schema.EntityWithIndexes`}})).To(BeEmpty())
+	})
+
+	It("Allow to export state without prefix by namespaces of schemas mappings", func() {
+		records := exportAll(cc, ctx, &debug.ExportRequest{Schemas: []string{`schema.EntityWithIndexes`}, PageSize: 2})
+		Expect(records).To(Equal(snapshot[:3]))
+
+		cc.Tx(func() {
+			_, err := dbg.ExportStates(ctx, &debug.ExportRequest{Schemas: []string{`schema.Unknown`}})
+			Expect(errors.Is(err, mapping.ErrStateMappingNotFound)).To(BeTrue())
+		})
+	})
+
+	It("Allow to export all state by namespaces of mappings and simple keys", func() {
+		cc.Tx(func() {
+			Expect(ctx.State().Put(state.Key{`version`}, []byte(`1`))).To(Succeed())
+		})
+
+		// mapped entries and simple key, key refs are created with mapped entries on import
+		records := exportAll(cc, ctx, &debug.ExportRequest{PageSize: 2})
+		Expect(records).To(HaveLen(4))
+		Expect(records[:3]).To(Equal(snapshot[:3]))
+		Expect(records[3].Key).To(Equal([]string{`version`}))
+		Expect(records[3].Value).To(Equal([]byte(`1`)))
+
+		importCC, importCtx := testcc.NewTxHandler(`import all`)
+		importCC.Tx(func() {
+			_, err := dbg.ImportRecords(importCtx, &debug.ExportPage{Records: records})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Expect(exportAll(importCC, importCtx, &debug.ExportRequest{})).To(Equal(records))
+		Expect(exportPrefixes(importCC, importCtx)).To(Equal(snapshot[:6]))
+
+		cc.Tx(func() {
+			Expect(ctx.State().Delete(state.Key{`version`})).To(Succeed())
+		})
+	})
+
+	It("Allow to import exported state to other chaincode", func() {
+		importCC, importCtx := testcc.NewTxHandler(`import`)
+		importCC.Tx(func() {
+			keys, err := dbg.ImportRecords(importCtx, &debug.ExportPage{Records: snapshot})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys.Keys).To(HaveLen(7))
+		})
+
+		Expect(exportPrefixes(importCC, importCtx)).To(Equal(snapshot))
+
+		importCC.Tx(func() {
+			entity, err := mapping.WrapState(importCtx.State(), mappings).Get(&schema.EntityWithIndexes{Id: `bbb`})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entity.(*schema.EntityWithIndexes).ExternalId).To(Equal(`bbb_ext`))
+		})
+	})
+
+	It("Allow to import mapped entries with key refs, created by mappings", func() {
+		importCC, importCtx := testcc.NewTxHandler(`import mapped`)
+		importCC.Tx(func() {
+			// only entries, without key refs
+			_, err := dbg.ImportRecords(importCtx, &debug.ExportPage{Records: snapshot[:3]})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Expect(exportPrefixes(importCC, importCtx)).To(Equal(snapshot[:6]))
+
+		importCC.Tx(func() {
+			entity, err := mapping.WrapState(importCtx.State(), mappings).GetByKey(
+				&schema.EntityWithIndexes{}, `ExternalId`, []string{`ccc_ext`}, &schema.EntityWithIndexes{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entity.(*schema.EntityWithIndexes).Id).To(Equal(`ccc`))
+		})
+	})
+
+	It("Allow to import records with JSON values to seed state", func() {
+		cc.Tx(func() {
+			_, err := dbg.ImportRecords(ctx, &debug.ExportPage{Records: []*debug.ExportRecord{{
+				Key:    []string{`EntityWithIndexes`, `ddd`},
+				Schema: `schema.EntityWithIndexes`,
+				Json:   `{"id":"ddd","external_id":"ddd_ext","value":4}`,
+			}}})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		cc.Tx(func() {
+			entity, err := mapping.WrapState(ctx.State(), mappings).Get(&schema.EntityWithIndexes{Id: `ddd`})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entity.(*schema.EntityWithIndexes).Value).To(Equal(int32(4)))
+		})
+	})
+
+	It("Disallow to import invalid records", func() {
+		cc.Tx(func() {
+			_, err := dbg.ImportRecords(ctx, &debug.ExportPage{Records: []*debug.ExportRecord{
+				{Key: []string{`raw`, `eee`}, Value: []byte(`eee`)},
+				{Key: []string{`EntityWithIndexes`, `eee`}, Schema: `schema.Unknown`, Json: `{}`},
+			}})
+			Expect(errors.Is(err, debug.ErrInvalidExportRecord)).To(BeTrue())
+
+			_, err = dbg.ImportRecords(ctx, &debug.ExportPage{Records: []*debug.ExportRecord{
+				{Key: []string{`EntityWithIndexes`, `eee`}, Schema: `schema.EntityWithIndexes`, Value: []byte(`not proto`)},
+			}})
+			Expect(errors.Is(err, debug.ErrInvalidExportRecord)).To(BeTrue())
+
+			// primary key of mapped entry doesn't match record key
+			_, err = dbg.ImportRecords(ctx, &debug.ExportPage{Records: []*debug.ExportRecord{
+				{Key: []string{`EntityWithIndexes`, `eee`}, Schema: `schema.EntityWithIndexes`, Json: `{"id":"fff"}`},
+			}})
+			Expect(errors.Is(err, debug.ErrInvalidExportRecord)).To(BeTrue())
+		})
+
+		cc.Tx(func() {
+			exists, err := ctx.State().Exists([]string{`raw`, `eee`})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+		})
+	})
+
+	It("Allow to export state of mock stub directly", func() {
+		records, err := debug.ExportAllState(cc.MockStub, state.NewState(cc.MockStub, zap.NewNop()),
+			&debug.ExportRequest{Prefix: []string{`EntityWithIndexes`}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(HaveLen(4))
+		Expect(records[0].Schema).To(BeEmpty()) // no mappings, raw values
+	})
+})
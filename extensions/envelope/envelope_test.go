@@ -140,6 +140,48 @@ var _ = Describe(`Envelop`, func() {
 			Expect(resp.Status).To(BeNumerically("==", 200))
 		})
 
+		It("Allow to verify canonical signature of payload with other json serialization", func() {
+			// test vector: ed25519 key from seed of 32 0x01 bytes, payload hashed in canonical form
+			jsonEnvelope := `{"hash_func":"SHA256_CANONICAL","hash_to_sign":"DZMdjp2TVUtA4NWpnruAdUmCoRWoiCwpx9DvJujHJ7n9","nonce":"1675065805271","channel":"envelope-channel","method":"invokeWithEnvelope","chaincode":"envelope-chaincode","deadline":"2033-01-31T07:58:39.677Z","public_key":"AKnL4NNf3DGWZJS6cPknBuEGnVsV4A4m5tgebLHaRSZ9","signature":"2hRwuCwRvnn28ueyzibBXkJTbEsLP4McsjT92chYnJngsrSrSK9HxoSYXosyGLAzT8RzBrs6nLX3c6W4TDW8JQ7s"}`
+			reorderedPayload := []byte(`{"issuer_id": "GLDINC", "underlying_asset": "gold", "type": "DM",
+				"name": "Gold digital asset", "decimals": "8", "symbol": "GLD"}`)
+
+			envelopCC = testcc.NewMockStub(chaincode, testdata.NewEnvelopCC(chaincode)).WithChannel(channel)
+			resp := envelopCC.Invoke(methodInvoke, reorderedPayload, []byte(jsonEnvelope))
+			Expect(resp.Status).To(BeNumerically("==", 200))
+		})
+
+		It("Disallow to verify canonical signature with invalid payload", func() {
+			publicKey, privateKey, _ := e.CreateKeys()
+			nonce := e.CreateNonce()
+			_, sig, err := e.CreateCanonicalSig(payload, nonce, channel, chaincode, methodInvoke, ``, privateKey)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(e.CheckCanonicalSig(payload, nonce, channel, chaincode, methodInvoke, ``, publicKey, sig)).To(Succeed())
+			Expect(e.CheckCanonicalSig([]byte(`{"symbol":"SLV"}`), nonce, channel, chaincode, methodInvoke, ``, publicKey, sig)).
+				To(MatchError(e.ErrCheckSignatureFailed))
+		})
+
+		It("Disallow to verify canonical signature with other integer above 2^53 in payload", func() {
+			publicKey, privateKey, _ := e.CreateKeys()
+			nonce := e.CreateNonce()
+			signedPayload := []byte(`{"amount":9007199254740992}`)
+			otherPayload := []byte(`{"amount":9007199254740993}`)
+
+			_, sig, err := e.CreateCanonicalSig(signedPayload, nonce, channel, chaincode, methodInvoke, ``, privateKey)
+			Expect(err).NotTo(HaveOccurred())
+
+			hash, err := e.CanonicalHash(signedPayload, nonce, channel, chaincode, methodInvoke, ``, publicKey)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hash).NotTo(BeZero())
+
+			// 9007199254740993 as double equals 9007199254740992, so payloads would have same canonical hash
+			_, err = e.CanonicalHash(otherPayload, nonce, channel, chaincode, methodInvoke, ``, publicKey)
+			Expect(err).To(MatchError(ContainSubstring(serialize.ErrInexactNumber.Error())))
+			Expect(e.CheckCanonicalSig(otherPayload, nonce, channel, chaincode, methodInvoke, ``, publicKey, sig)).
+				NotTo(Succeed())
+		})
+
 		It("Disallow to verify signature with invalid payload", func() {
 			serializedEnvelope, _ := createEnvelope(payload, channel, chaincode, methodInvoke, deadline)
 
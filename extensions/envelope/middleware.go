@@ -94,7 +94,11 @@ func verifyEnvelope(c router.Context, method, payload, envlp []byte) (*Envelope,
 		if envelope.Deadline != nil {
 			deadline = envelope.Deadline.AsTime().Format(TimeLayout)
 		}
-		if err := CheckSig(payload, envelope.Nonce, envelope.Channel, envelope.Chaincode, envelope.Method, deadline, pubkey, sig); err != nil {
+		checkSig := CheckSig
+		if envelope.HashFunc == HashFuncSHA256Canonical {
+			checkSig = CheckCanonicalSig
+		}
+		if err := checkSig(payload, envelope.Nonce, envelope.Channel, envelope.Chaincode, envelope.Method, deadline, pubkey, sig); err != nil {
 			c.Logger().Sugar().Error(ErrCheckSignatureFailed)
 			return nil, ErrCheckSignatureFailed
 		}
@@ -4,11 +4,22 @@ import (
 	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/sha256"
+	"encoding/json"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/btcsuite/btcutil/base58"
+
+	"github.com/hyperledger-labs/cckit/serialize"
+)
+
+const (
+	// HashFuncSHA256 sha-256 hash of message with payload as is
+	HashFuncSHA256 = `SHA256`
+	// HashFuncSHA256Canonical sha-256 hash of message with JSON payload in canonical form (sorted keys, no whitespace),
+	// so signature does not depend on JSON serialization of client
+	HashFuncSHA256Canonical = `SHA256_CANONICAL`
 )
 
 func CreateKeys() (ed25519.PublicKey, ed25519.PrivateKey, error) {
@@ -24,7 +35,23 @@ func CreateNonce() string {
 }
 
 func Hash(payload []byte, nonce, channel, chaincode, method, deadline string, pubkey []byte) [32]byte {
-	bb := append(removeSpacesBetweenCommaAndQuotes(payload), nonce...) // resolve the unclear json serialization behavior in protojson package
+	// resolve the unclear json serialization behavior in protojson package
+	return hashMessage(removeSpacesBetweenCommaAndQuotes(payload), nonce, channel, chaincode, method, deadline, pubkey)
+}
+
+// CanonicalHash hashes message with JSON payload converted to canonical form, non JSON payload is used as is
+func CanonicalHash(payload []byte, nonce, channel, chaincode, method, deadline string, pubkey []byte) ([32]byte, error) {
+	if json.Valid(payload) {
+		var err error
+		if payload, err = serialize.CanonicalJSON(payload); err != nil {
+			return [32]byte{}, err
+		}
+	}
+	return hashMessage(payload, nonce, channel, chaincode, method, deadline, pubkey), nil
+}
+
+func hashMessage(payload []byte, nonce, channel, chaincode, method, deadline string, pubkey []byte) [32]byte {
+	bb := append(append([]byte{}, payload...), nonce...)
 	bb = append(bb, channel...)
 	bb = append(bb, chaincode...)
 	bb = append(bb, method...)
@@ -49,6 +76,29 @@ func CheckSig(payload []byte, nonce, channel, chaincode, method, deadline string
 	return nil
 }
 
+// CreateCanonicalSig signs message with JSON payload in canonical form
+func CreateCanonicalSig(payload []byte, nonce, channel, chaincode, method, deadline string, privateKey []byte) (
+	[]byte, []byte, error) {
+	pubKey := ed25519.PrivateKey(privateKey).Public().(ed25519.PublicKey)
+	hashed, err := CanonicalHash(payload, nonce, channel, chaincode, method, deadline, pubKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pubKey, ed25519.Sign(ed25519.PrivateKey(privateKey), hashed[:]), nil
+}
+
+// CheckCanonicalSig checks signature of message with JSON payload in canonical form
+func CheckCanonicalSig(payload []byte, nonce, channel, chaincode, method, deadline string, pubKey []byte, sig []byte) error {
+	hashed, err := CanonicalHash(payload, nonce, channel, chaincode, method, deadline, pubKey)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(ed25519.PublicKey(pubKey), hashed[:], sig) {
+		return ErrCheckSignatureFailed
+	}
+	return nil
+}
+
 func removeSpacesBetweenCommaAndQuotes(s []byte) []byte {
 	return []byte(strings.ReplaceAll(string(s), `", "`, `","`))
 }
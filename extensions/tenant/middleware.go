@@ -0,0 +1,27 @@
+package tenant
+
+import (
+	"github.com/hyperledger-labs/cckit/router"
+)
+
+// Isolate replaces context state with tenant state, tenant identifier resolved from invocation
+func Isolate(resolver Resolver) router.MiddlewareFunc {
+	return func(next router.HandlerFunc, pos ...int) router.HandlerFunc {
+		return func(c router.Context) (interface{}, error) {
+			tenant, err := resolver(c)
+			if err != nil {
+				return nil, err
+			}
+
+			s, err := State(c.State(), tenant)
+			if err != nil {
+				return nil, err
+			}
+
+			c.SetParam(ContextParam, tenant)
+			c.UseState(s)
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package tenant_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/cckit/extensions/tenant"
+	"github.com/hyperledger-labs/cckit/extensions/tenant/testdata"
+	"github.com/hyperledger-labs/cckit/state"
+	testcc "github.com/hyperledger-labs/cckit/testing"
+	expectcc "github.com/hyperledger-labs/cckit/testing/expect"
+)
+
+// certWithAttrs creates self-signed certificate with attributes extension, as issued by Fabric CA
+func certWithAttrs(attrs map[string]string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	attrsJSON, err := json.Marshal(map[string]interface{}{`attrs`: attrs})
+	Expect(err).NotTo(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: `tenant-user`},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtraExtensions: []pkix.Extension{{
+			Id:    asn1.ObjectIdentifier{1, 2, 3, 4, 5, 6, 7, 8, 1},
+			Value: attrsJSON,
+		}},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+
+	return pem.EncodeToMemory(&pem.Block{Type: `CERTIFICATE`, Bytes: der})
+}
+
+var _ = Describe(`Tenant resolvers`, func() {
+
+	cc := testcc.NewMockStub(`tenant_resolvers`, testdata.NewResolversCC())
+
+	It("Allow to resolve tenant from cert attribute", func() {
+		cert := certWithAttrs(map[string]string{testdata.CertAttr: `TENANT_C`})
+		Expect(expectcc.PayloadIs(cc.From(`SOME_MSP`, cert).Query(`certAttrTenant`), ``)).To(Equal(`TENANT_C`))
+	})
+
+	It("Disallow to resolve tenant from cert without attribute", func() {
+		expectcc.ResponseError(cc.From(TenantA).Query(`certAttrTenant`), tenant.ErrTenantNotDefined)
+
+		cert := certWithAttrs(map[string]string{`other`: `value`})
+		expectcc.ResponseError(cc.From(`SOME_MSP`, cert).Query(`certAttrTenant`), tenant.ErrTenantNotDefined)
+	})
+
+	It("Allow to resolve tenant from param with access check", func() {
+		Expect(expectcc.PayloadIs(cc.From(TenantA).Query(`paramTenant`, `TENANT_A_MSP`), ``)).
+			To(Equal(`TENANT_A_MSP`))
+
+		admin := testdata.AdminMSP
+		cert := certWithAttrs(nil)
+		Expect(expectcc.PayloadIs(cc.From(admin, cert).Query(`paramTenant`, `TENANT_B_MSP`), ``)).
+			To(Equal(`TENANT_B_MSP`))
+	})
+
+	It("Disallow to resolve tenant from param without access", func() {
+		expectcc.ResponseError(cc.From(TenantA).Query(`paramTenant`, `TENANT_B_MSP`), tenant.ErrTenantAccessDenied)
+	})
+})
+
+var _ = Describe(`Tenant state`, func() {
+
+	cc, ctx := testcc.NewTxHandler(`tenant_state`)
+
+	It("Allow to compose tenant prefix with existing key transformer", func() {
+		cc.Tx(func() {
+			outer := state.Key{`outer`}
+			s := ctx.State()
+			s.UseKeyTransformer(state.KeyWithPrefix(outer))
+			s.UseKeyReverseTransformer(state.KeyWithoutPrefix(outer))
+
+			tenantState, err := tenant.State(s, `TENANT_A`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tenantState.Put(state.Key{testdata.Prefix, `a`}, `value`)).To(Succeed())
+
+			// state, passed to tenant state, is not changed
+			Expect(s.Put(state.Key{testdata.Prefix, `b`}, `value`)).To(Succeed())
+		})
+
+		cc.Tx(func() {
+			outer := state.Key{`outer`}
+			s := ctx.State()
+			s.UseKeyTransformer(state.KeyWithPrefix(outer))
+			s.UseKeyReverseTransformer(state.KeyWithoutPrefix(outer))
+
+			tenantState, err := tenant.State(s, `TENANT_A`)
+			Expect(err).NotTo(HaveOccurred())
+			keys, err := tenantState.Keys(testdata.Prefix)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(Equal([]string{"\x00prefix\x00a\x00"}))
+		})
+
+		Expect(cc.MockStub.State).To(HaveKey("\x00outer\x00_tenant\x00TENANT_A\x00prefix\x00a\x00"))
+		Expect(cc.MockStub.State).To(HaveKey("\x00outer\x00prefix\x00b\x00"))
+	})
+
+	It("Disallow to create tenant state without tenant", func() {
+		_, err := tenant.State(ctx.State(), ``)
+		Expect(err).To(MatchError(tenant.ErrTenantNotDefined))
+	})
+})
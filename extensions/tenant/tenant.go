@@ -0,0 +1,125 @@
+// Package tenant provides isolation of logical tenants, hosted in one chaincode,
+// by transparent prefixing of all state keys with tenant namespace
+package tenant
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger-labs/cckit/router"
+	"github.com/hyperledger-labs/cckit/state"
+)
+
+// Namespace prefix for all tenant state keys
+// key will be <`_tenant`,{TenantId}, {Key[0]},... {Key[n}}>
+const Namespace = `_tenant`
+
+// ContextParam name of context param, containing current tenant identifier
+const ContextParam = `_tenant`
+
+var (
+	// ErrTenantNotDefined occurs when tenant identifier cannot be resolved for invocation
+	ErrTenantNotDefined = errors.New(`tenant not defined`)
+
+	// ErrTenantAccessDenied occurs when invoker has no access to tenant
+	ErrTenantAccessDenied = errors.New(`tenant access denied`)
+)
+
+type (
+	// Resolver returns tenant identifier for chaincode invocation
+	Resolver func(c router.Context) (string, error)
+
+	// AccessChecker checks invoker access to tenant
+	AccessChecker func(c router.Context, tenant string) error
+)
+
+// FromMSPID uses invoker MSP ID as tenant identifier
+func FromMSPID() Resolver {
+	return func(c router.Context) (string, error) {
+		client, err := c.Client()
+		if err != nil {
+			return ``, err
+		}
+
+		return client.GetMSPID()
+	}
+}
+
+// FromCertAttr uses attribute value from invoker certificate as tenant identifier
+func FromCertAttr(attr string) Resolver {
+	return func(c router.Context) (string, error) {
+		client, err := c.Client()
+		if err != nil {
+			return ``, err
+		}
+
+		value, found, err := client.GetAttributeValue(attr)
+		if err != nil {
+			return ``, err
+		}
+
+		if !found {
+			return ``, fmt.Errorf(`cert attribute=%s: %w`, attr, ErrTenantNotDefined)
+		}
+
+		return value, nil
+	}
+}
+
+// FromParam uses chaincode method param value as tenant identifier.
+// Method params are set by method middleware, so Isolate with this resolver should be used
+// as method middleware after param definition. Should be combined with access check, see WithAccessCheck
+func FromParam(name string) Resolver {
+	return func(c router.Context) (string, error) {
+		return c.ParamString(name), nil
+	}
+}
+
+// WithAccessCheck returns resolver, checking invoker access to resolved tenant.
+// Access check error is returned wrapped with ErrTenantAccessDenied
+func WithAccessCheck(resolver Resolver, check AccessChecker) Resolver {
+	return func(c router.Context) (string, error) {
+		tenant, err := resolver(c)
+		if err != nil {
+			return ``, err
+		}
+
+		if err = check(c, tenant); err != nil {
+			return ``, fmt.Errorf(`%w: tenant=%s: %s`, ErrTenantAccessDenied, tenant, err)
+		}
+
+		return tenant, nil
+	}
+}
+
+// KeyPrefix returns state key prefix for tenant
+func KeyPrefix(tenant string) state.Key {
+	return state.Key{Namespace, tenant}
+}
+
+// State returns clone of state, all keys of which will be prefixed with tenant namespace.
+// Keys outside tenant namespace are not accessible. Tenant prefix is added before existing
+// key transformation (for example, key encryption) and removed after reverse transformation
+func State(s state.State, tenant string) (state.State, error) {
+	if tenant == `` {
+		return nil, ErrTenantNotDefined
+	}
+
+	tenantState := s.Clone()
+	tenantState.UseKeyTransformer(state.ComposeKeyTransformers(
+		state.KeyWithPrefix(KeyPrefix(tenant)), s.KeyTransformer()))
+	tenantState.UseKeyReverseTransformer(state.ComposeKeyTransformers(
+		s.KeyReverseTransformer(), state.KeyWithoutPrefix(KeyPrefix(tenant))))
+
+	return tenantState, nil
+}
+
+// FromContext returns tenant identifier, set by Isolate middleware
+func FromContext(c router.Context) (string, error) {
+	tenant := c.ParamString(ContextParam)
+	if tenant == `` {
+		return ``, ErrTenantNotDefined
+	}
+
+	return tenant, nil
+}
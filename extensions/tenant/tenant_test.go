@@ -0,0 +1,88 @@
+package tenant_test
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/cckit/extensions/tenant"
+	"github.com/hyperledger-labs/cckit/extensions/tenant/testdata"
+	identitytestdata "github.com/hyperledger-labs/cckit/identity/testdata"
+	"github.com/hyperledger-labs/cckit/state"
+	testcc "github.com/hyperledger-labs/cckit/testing"
+	expectcc "github.com/hyperledger-labs/cckit/testing/expect"
+)
+
+func TestTenant(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Tenant suite")
+}
+
+var (
+	TenantA = identitytestdata.Certificates[0].MustIdentity(`TENANT_A_MSP`)
+	TenantB = identitytestdata.Certificates[1].MustIdentity(`TENANT_B_MSP`)
+
+	ValuesA = []testdata.Value{{Id: `a`, Value: `tenant a value a`}, {Id: `b`, Value: `tenant a value b`}}
+	ValuesB = []testdata.Value{{Id: `a`, Value: `tenant b value a`}}
+)
+
+var _ = Describe(`Tenant`, func() {
+
+	cc := testcc.NewMockStub(`tenant`, testdata.NewTenantCC())
+
+	BeforeSuite(func() {
+		expectcc.ResponseOk(cc.From(TenantA).Init())
+	})
+
+	It("Allow to get tenant from context", func() {
+		Expect(expectcc.PayloadIs(cc.From(TenantA).Query(`tenant`), ``)).To(Equal(`TENANT_A_MSP`))
+		Expect(expectcc.PayloadIs(cc.From(TenantB).Query(`tenant`), ``)).To(Equal(`TENANT_B_MSP`))
+	})
+
+	It("Allow to put entries with same keys for different tenants", func() {
+		for _, v := range ValuesA {
+			expectcc.ResponseOk(cc.From(TenantA).Invoke(`put`, v))
+		}
+		for _, v := range ValuesB {
+			expectcc.ResponseOk(cc.From(TenantB).Invoke(`put`, v))
+		}
+	})
+
+	It("Allow to get entry of current tenant", func() {
+		Expect(expectcc.PayloadIs(cc.From(TenantA).Query(`get`, `a`), &testdata.Value{})).To(Equal(ValuesA[0]))
+		Expect(expectcc.PayloadIs(cc.From(TenantB).Query(`get`, `a`), &testdata.Value{})).To(Equal(ValuesB[0]))
+
+		expectcc.ResponseError(cc.From(TenantB).Query(`get`, `b`), state.ErrKeyNotFound)
+	})
+
+	It("Allow to list entries of current tenant", func() {
+		Expect(expectcc.PayloadIs(cc.From(TenantA).Query(`list`), &[]testdata.Value{})).To(Equal(ValuesA))
+		Expect(expectcc.PayloadIs(cc.From(TenantB).Query(`list`), &[]testdata.Value{})).To(Equal(ValuesB))
+	})
+
+	It("Allow to get keys without tenant prefix", func() {
+		keys := expectcc.PayloadIs(cc.From(TenantB).Query(`keys`), &[]string{}).([]string)
+		key, _ := shim.CreateCompositeKey(testdata.Prefix, []string{`a`})
+		Expect(keys).To(Equal([]string{key}))
+	})
+
+	It("Disallow to access entries of another tenant", func() {
+		expectcc.ResponseError(cc.From(TenantB).Query(`getRaw`,
+			tenant.KeyPrefix(`TENANT_A_MSP`).Append(state.Key{testdata.Prefix, `b`})), state.ErrKeyNotFound)
+	})
+
+	It("Allow to list private entries of current tenant", func() {
+		for _, v := range ValuesA {
+			expectcc.ResponseOk(cc.From(TenantA).Invoke(`privatePut`, v))
+		}
+		for _, v := range ValuesB {
+			expectcc.ResponseOk(cc.From(TenantB).Invoke(`privatePut`, v))
+		}
+
+		Expect(expectcc.PayloadIs(cc.From(TenantA).Query(`privateList`), &[]testdata.Value{})).To(Equal(ValuesA))
+		Expect(expectcc.PayloadIs(cc.From(TenantB).Query(`privateList`), &[]testdata.Value{})).To(Equal(ValuesB))
+	})
+})
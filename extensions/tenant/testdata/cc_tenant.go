@@ -0,0 +1,107 @@
+package testdata
+
+import (
+	"fmt"
+
+	"github.com/hyperledger-labs/cckit/extensions/tenant"
+	"github.com/hyperledger-labs/cckit/router"
+	p "github.com/hyperledger-labs/cckit/router/param"
+)
+
+const (
+	Collection = `SampleCollection`
+	Prefix     = `prefix`
+	// CertAttr invoker certificate attribute with tenant identifier
+	CertAttr = `tenant`
+	// AdminMSP members of MSP have access to all tenants, others only to tenant, equal to MSP ID
+	AdminMSP = `ADMIN_MSP`
+)
+
+type Value struct {
+	Id    string
+	Value string
+}
+
+func (v Value) Key() ([]string, error) {
+	return []string{Prefix, v.Id}, nil
+}
+
+func NewTenantCC() *router.Chaincode {
+	r := router.New(`tenant`)
+	r.Use(tenant.Isolate(tenant.FromMSPID()))
+
+	r.Init(router.EmptyContextHandler).
+		Query(`tenant`, queryTenant).
+		Invoke(`put`, put, p.Struct(`value`, &Value{})).
+		Query(`get`, get, p.String(`key`)).
+		Query(`getRaw`, getRaw, p.Strings(`key`)).
+		Query(`list`, list).
+		Query(`keys`, keys).
+		Invoke(`privatePut`, privatePut, p.Struct(`value`, &Value{})).
+		Query(`privateList`, privateList)
+
+	return router.NewChaincode(r)
+}
+
+// NewResolversCC chaincode with tenant resolved from cert attribute or from method param with access check
+func NewResolversCC() *router.Chaincode {
+	r := router.New(`tenant_resolvers`)
+
+	r.Init(router.EmptyContextHandler).
+		Query(`certAttrTenant`, queryTenant, tenant.Isolate(tenant.FromCertAttr(CertAttr))).
+		Query(`paramTenant`, queryTenant,
+			p.String(`tenant`), tenant.Isolate(tenant.WithAccessCheck(tenant.FromParam(`tenant`), checkAccess)))
+
+	return router.NewChaincode(r)
+}
+
+func checkAccess(c router.Context, tenantID string) error {
+	client, err := c.Client()
+	if err != nil {
+		return err
+	}
+	invokerMSP, err := client.GetMSPID()
+	if err != nil {
+		return err
+	}
+	if invokerMSP != AdminMSP && invokerMSP != tenantID {
+		return fmt.Errorf(`msp=%s`, invokerMSP)
+	}
+	return nil
+}
+
+func queryTenant(c router.Context) (interface{}, error) {
+	return tenant.FromContext(c)
+}
+
+func put(c router.Context) (interface{}, error) {
+	return nil, c.State().Put(c.Param(`value`))
+}
+
+func get(c router.Context) (interface{}, error) {
+	return c.State().Get(Value{Id: c.ParamString(`key`)}, &Value{})
+}
+
+func getRaw(c router.Context) (interface{}, error) {
+	return c.State().Get(c.Param(`key`), &Value{})
+}
+
+func list(c router.Context) (interface{}, error) {
+	return c.State().List(Prefix, &Value{})
+}
+
+func keys(c router.Context) (interface{}, error) {
+	return c.State().Keys(Prefix)
+}
+
+func privatePut(c router.Context) (interface{}, error) {
+	value := c.Param(`value`)
+	if err := c.State().Put(value, `{}`); err != nil {
+		return nil, err
+	}
+	return nil, c.State().PutPrivate(Collection, value)
+}
+
+func privateList(c router.Context) (interface{}, error) {
+	return c.State().ListPrivate(Collection, false, Prefix, &Value{})
+}
@@ -2,7 +2,11 @@ package token
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
+	"strings"
+
+	"github.com/hyperledger-labs/cckit/serialize"
 )
 
 var ErrConvertStringInt = errors.New("failed to convert string to big int")
@@ -42,3 +46,232 @@ func BigIntSub(a, b *big.Int) *big.Int {
 func BigIntSubAsDecimal(a, b *big.Int, scale ...int32) *Decimal {
 	return NewDecimal(BigIntSub(a, b), scale...)
 }
+
+// RoundingMode defines how decimal values are rounded to scale
+type RoundingMode int
+
+const (
+	// RoundDown rounds towards zero (truncates)
+	RoundDown RoundingMode = iota
+	// RoundUp rounds away from zero
+	RoundUp
+	// RoundFloor rounds towards negative infinity
+	RoundFloor
+	// RoundCeiling rounds towards positive infinity
+	RoundCeiling
+	// RoundHalfUp rounds to nearest, ties away from zero
+	RoundHalfUp
+	// RoundHalfEven rounds to nearest, ties to even (banker's rounding)
+	RoundHalfEven
+)
+
+var (
+	ErrInvalidDecimal = errors.New(`invalid decimal`)
+	ErrDivisionByZero = errors.New(`division by zero`)
+)
+
+// ParseDecimal converts decimal string, for example "123.45", to Decimal with scale equal to number of fraction digits
+func ParseDecimal(s string) (*Decimal, error) {
+	intPart, fracPart := s, ``
+	if pos := strings.IndexByte(s, '.'); pos >= 0 {
+		intPart, fracPart = s[:pos], s[pos+1:]
+	}
+	if strings.ContainsAny(fracPart, `+-`) || (intPart == `` || intPart == `-` || intPart == `+`) && fracPart == `` {
+		return nil, fmt.Errorf(`%w: %s`, ErrInvalidDecimal, s)
+	}
+
+	value, ok := new(big.Int).SetString(intPart+fracPart, 10)
+	if !ok {
+		return nil, fmt.Errorf(`%w: %s`, ErrInvalidDecimal, s)
+	}
+	return NewDecimal(value, int32(len(fracPart))), nil
+}
+
+// NewDecimalFromRat creates Decimal with scale from rational number, rounded with rounding mode
+func NewDecimalFromRat(r *big.Rat, scale int32, mode RoundingMode) *Decimal {
+	return NewDecimal(roundRat(r, scale, mode), scale)
+}
+
+// Text returns decimal string with scale fraction digits, for example "123.45"
+func (x *Decimal) Text() (string, error) {
+	value, err := x.BigInt()
+	if err != nil {
+		return ``, err
+	}
+	if x.Scale <= 0 {
+		// negative scale means trailing zeros of integer value
+		return new(big.Int).Mul(value, pow10(-x.Scale).Num()).String(), nil
+	}
+
+	digits := new(big.Int).Abs(value).String()
+	if pad := int(x.Scale) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat(`0`, pad) + digits
+	}
+
+	sign := ``
+	if value.Sign() < 0 {
+		sign = `-`
+	}
+	pos := len(digits) - int(x.Scale)
+	return sign + digits[:pos] + `.` + digits[pos:], nil
+}
+
+// SetText sets decimal from decimal string, for example "123.45", scale is equal to number of fraction digits
+func (x *Decimal) SetText(s string) error {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	x.Value, x.Scale = d.Value, d.Scale
+	return nil
+}
+
+// Rat returns decimal as rational number value * 10^-scale
+func (x *Decimal) Rat() (*big.Rat, error) {
+	value, err := x.BigInt()
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Rat).Mul(new(big.Rat).SetInt(value), pow10(-x.Scale)), nil
+}
+
+// Rescale returns decimal with scale, rounded with rounding mode if scale is decreased
+func (x *Decimal) Rescale(scale int32, mode RoundingMode) (*Decimal, error) {
+	r, err := x.Rat()
+	if err != nil {
+		return nil, err
+	}
+	return NewDecimalFromRat(r, scale, mode), nil
+}
+
+// KeyPart returns state key part of decimal, keys of decimals with different scales are ordered as numbers
+func (x *Decimal) KeyPart() (string, error) {
+	value, err := x.BigInt()
+	if err != nil {
+		return ``, err
+	}
+	return serialize.EncodeDecimalKey(value, x.Scale)
+}
+
+// SetKeyPart sets decimal from state key part, scale is minimal scale of decimal
+func (x *Decimal) SetKeyPart(part string) error {
+	d, err := DecimalFromKey(part)
+	if err != nil {
+		return err
+	}
+	x.Value, x.Scale = d.Value, d.Scale
+	return nil
+}
+
+// DecimalFromKey decodes decimal from state key part, scale is minimal scale of decimal
+func DecimalFromKey(key string) (*Decimal, error) {
+	value, scale, err := serialize.DecodeDecimalKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return NewDecimal(value, scale), nil
+}
+
+// DecimalCmp compares decimals with any scales, returns -1, 0 or +1
+func DecimalCmp(a, b *Decimal) (int, error) {
+	ra, rb, err := decimalRats(a, b)
+	if err != nil {
+		return 0, err
+	}
+	return ra.Cmp(rb), nil
+}
+
+// DecimalAdd returns exact sum of decimals with max scale of decimals
+func DecimalAdd(a, b *Decimal) (*Decimal, error) {
+	ra, rb, err := decimalRats(a, b)
+	if err != nil {
+		return nil, err
+	}
+	return NewDecimalFromRat(new(big.Rat).Add(ra, rb), maxScale(a, b), RoundDown), nil
+}
+
+// DecimalSub returns exact difference of decimals with max scale of decimals
+func DecimalSub(a, b *Decimal) (*Decimal, error) {
+	ra, rb, err := decimalRats(a, b)
+	if err != nil {
+		return nil, err
+	}
+	return NewDecimalFromRat(new(big.Rat).Sub(ra, rb), maxScale(a, b), RoundDown), nil
+}
+
+// DecimalMul returns product of decimals with scale, rounded with rounding mode
+func DecimalMul(a, b *Decimal, scale int32, mode RoundingMode) (*Decimal, error) {
+	ra, rb, err := decimalRats(a, b)
+	if err != nil {
+		return nil, err
+	}
+	return NewDecimalFromRat(new(big.Rat).Mul(ra, rb), scale, mode), nil
+}
+
+// DecimalQuo returns quotient of decimals with scale, rounded with rounding mode
+func DecimalQuo(a, b *Decimal, scale int32, mode RoundingMode) (*Decimal, error) {
+	ra, rb, err := decimalRats(a, b)
+	if err != nil {
+		return nil, err
+	}
+	if rb.Sign() == 0 {
+		return nil, ErrDivisionByZero
+	}
+	return NewDecimalFromRat(new(big.Rat).Quo(ra, rb), scale, mode), nil
+}
+
+func decimalRats(a, b *Decimal) (*big.Rat, *big.Rat, error) {
+	ra, err := a.Rat()
+	if err != nil {
+		return nil, nil, err
+	}
+	rb, err := b.Rat()
+	if err != nil {
+		return nil, nil, err
+	}
+	return ra, rb, nil
+}
+
+func maxScale(a, b *Decimal) int32 {
+	if a.Scale > b.Scale {
+		return a.Scale
+	}
+	return b.Scale
+}
+
+// pow10 returns 10^exp as rational number, exp can be negative
+func pow10(exp int32) *big.Rat {
+	if exp < 0 {
+		return new(big.Rat).Inv(pow10(-exp))
+	}
+	return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil))
+}
+
+// roundRat returns r * 10^scale, rounded to integer with rounding mode
+func roundRat(r *big.Rat, scale int32, mode RoundingMode) *big.Int {
+	scaled := new(big.Rat).Mul(r, pow10(scale))
+	quo, rem := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	if rem.Sign() == 0 {
+		return quo
+	}
+
+	sign := int64(scaled.Sign())
+	awayFromZero := false
+	switch mode {
+	case RoundUp:
+		awayFromZero = true
+	case RoundFloor:
+		awayFromZero = sign < 0
+	case RoundCeiling:
+		awayFromZero = sign > 0
+	case RoundHalfUp, RoundHalfEven:
+		// compare remainder with half of denominator
+		half := new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2)).Cmp(scaled.Denom())
+		awayFromZero = half > 0 || half == 0 && (mode == RoundHalfUp || quo.Bit(0) == 1)
+	}
+
+	if awayFromZero {
+		quo.Add(quo, big.NewInt(sign))
+	}
+	return quo
+}
@@ -1,6 +1,9 @@
 package token_test
 
 import (
+	"errors"
+	"sort"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -44,4 +47,130 @@ var _ = Describe(`Bigint`, func() {
 
 		Expect(obj.(*token.UTXO).String()).To(Equal(utxo1.String()))
 	})
+
+	It(`allow to serialize decimal in string form`, func() {
+		for _, serializer := range []serialize.Serializer{
+			serialize.DefaultSerializer, serialize.PreferJSONSerializer} {
+			bb, err := serializer.ToBytesFrom(&token.Decimal{Value: `-12345`, Scale: 2})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(bb)).To(Equal(`-123.45`))
+
+			obj, err := serializer.FromBytesTo(bb, &token.Decimal{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(obj.(*token.Decimal).Value).To(Equal(`-12345`))
+			Expect(obj.(*token.Decimal).Scale).To(Equal(int32(2)))
+
+			_, err = serializer.FromBytesTo([]byte(`1.2.3`), &token.Decimal{})
+			Expect(errors.Is(err, serialize.ErrInvalidBigNumber)).To(BeTrue())
+		}
+	})
+})
+
+var _ = Describe(`Decimal`, func() {
+
+	mustParse := func(s string) *token.Decimal {
+		d, err := token.ParseDecimal(s)
+		Expect(err).NotTo(HaveOccurred())
+		return d
+	}
+
+	text := func(d *token.Decimal, err error) string {
+		Expect(err).NotTo(HaveOccurred())
+		s, err := d.Text()
+		Expect(err).NotTo(HaveOccurred())
+		return s
+	}
+
+	It(`allow to convert from and to string`, func() {
+		d := mustParse(`-123.045`)
+		Expect(d.Value).To(Equal(`-123045`))
+		Expect(d.Scale).To(Equal(int32(3)))
+		Expect(text(d, nil)).To(Equal(`-123.045`))
+
+		Expect(text(&token.Decimal{Value: `5`, Scale: 3}, nil)).To(Equal(`0.005`))
+		Expect(text(&token.Decimal{Value: `12345`}, nil)).To(Equal(`12345`))
+
+		for _, invalid := range []string{``, `.`, `-`, `1.2.3`, `1e5`, `1.-5`, `abc`} {
+			_, err := token.ParseDecimal(invalid)
+			Expect(errors.Is(err, token.ErrInvalidDecimal)).To(BeTrue(), invalid)
+		}
+	})
+
+	It(`allow to use decimals with negative scale`, func() {
+		d := &token.Decimal{Value: `-123`, Scale: -2}
+		Expect(text(d, nil)).To(Equal(`-12300`))
+
+		r, err := d.Rat()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.RatString()).To(Equal(`-12300`))
+
+		Expect(text(token.DecimalAdd(d, mustParse(`0.5`)))).To(Equal(`-12299.5`))
+		Expect(text(mustParse(`12345.6`).Rescale(-2, token.RoundHalfUp))).To(Equal(`12300`))
+
+		key, err := d.KeyPart()
+		Expect(err).NotTo(HaveOccurred())
+		decoded, err := token.DecimalFromKey(key)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(text(decoded, nil)).To(Equal(`-12300`))
+	})
+
+	It(`allow to add and subtract decimals with different scales`, func() {
+		Expect(text(token.DecimalAdd(mustParse(`1.5`), mustParse(`2.25`)))).To(Equal(`3.75`))
+		Expect(text(token.DecimalSub(mustParse(`1.5`), mustParse(`2.25`)))).To(Equal(`-0.75`))
+
+		cmp, err := token.DecimalCmp(mustParse(`1.50`), mustParse(`1.5`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cmp).To(Equal(0))
+	})
+
+	It(`allow to multiply and divide with rounding modes`, func() {
+		Expect(text(token.DecimalMul(mustParse(`1.25`), mustParse(`1.1`), 2, token.RoundHalfUp))).To(Equal(`1.38`))
+		Expect(text(token.DecimalMul(mustParse(`1.25`), mustParse(`1.1`), 2, token.RoundDown))).To(Equal(`1.37`))
+		Expect(text(token.DecimalQuo(mustParse(`1`), mustParse(`3`), 4, token.RoundUp))).To(Equal(`0.3334`))
+
+		_, err := token.DecimalQuo(mustParse(`1`), mustParse(`0.00`), 2, token.RoundDown)
+		Expect(err).To(MatchError(token.ErrDivisionByZero))
+	})
+
+	It(`allow to round with rounding modes`, func() {
+		for _, c := range []struct {
+			value    string
+			mode     token.RoundingMode
+			expected string
+		}{
+			{`2.5`, token.RoundDown, `2`},
+			{`-2.5`, token.RoundDown, `-2`},
+			{`2.1`, token.RoundUp, `3`},
+			{`-2.1`, token.RoundUp, `-3`},
+			{`-2.1`, token.RoundFloor, `-3`},
+			{`2.9`, token.RoundFloor, `2`},
+			{`2.1`, token.RoundCeiling, `3`},
+			{`-2.9`, token.RoundCeiling, `-2`},
+			{`2.5`, token.RoundHalfUp, `3`},
+			{`-2.5`, token.RoundHalfUp, `-3`},
+			{`2.5`, token.RoundHalfEven, `2`},
+			{`3.5`, token.RoundHalfEven, `4`},
+			{`-2.5`, token.RoundHalfEven, `-2`},
+			{`2.51`, token.RoundHalfEven, `3`},
+		} {
+			Expect(text(mustParse(c.value).Rescale(0, c.mode))).To(Equal(c.expected), c.value)
+		}
+	})
+
+	It(`allow to use decimal as ordered key part`, func() {
+		values := []string{`-10.5`, `-2`, `0`, `0.05`, `1.5`, `1.55`, `10`}
+		var keys []string
+		for _, v := range values {
+			key, err := mustParse(v).KeyPart()
+			Expect(err).NotTo(HaveOccurred())
+			keys = append(keys, key)
+
+			decoded, err := token.DecimalFromKey(key)
+			Expect(err).NotTo(HaveOccurred())
+			cmp, err := token.DecimalCmp(decoded, mustParse(v))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cmp).To(Equal(0))
+		}
+		Expect(sort.StringsAreSorted(keys)).To(BeTrue())
+	})
 })
@@ -2,6 +2,7 @@ package gateway
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/golang/protobuf/ptypes/timestamp"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/hyperledger-labs/cckit/router"
 	"github.com/hyperledger-labs/cckit/sdk"
+	"github.com/hyperledger-labs/cckit/state"
 )
 
 type (
@@ -90,12 +92,12 @@ func (ces *ChaincodeInstanceEventService) EventsStream(
 				return nil
 			}
 
-			processedEvent, err := ProcessEvent(e, ces.Opts.Event, req.EventName)
+			processedEvents, err := ProcessEvents(e, ces.Opts.Event, req.EventName)
 			if err != nil {
 				ces.Logger.Warn(`event processing`, zap.Error(err))
 			}
 
-			if processedEvent != nil {
+			for _, processedEvent := range processedEvents {
 				if err = stream.Send(processedEvent); err != nil {
 					return err
 				}
@@ -159,14 +161,12 @@ func (ces *ChaincodeInstanceEventService) Events(ctx context.Context, req *Chain
 				return events, nil
 			}
 
-			processedEvent, err := ProcessEvent(e, ces.Opts.Event, req.EventName)
+			processedEvents, err := ProcessEvents(e, ces.Opts.Event, req.EventName)
 			if err != nil {
 				ces.Logger.Warn(`event processing`, zap.Error(err))
 			}
 
-			if processedEvent != nil {
-				events.Items = append(events.Items, processedEvent)
-			}
+			events.Items = append(events.Items, processedEvents...)
 
 			ticker.Reset(EventListStreamTimeout)
 		}
@@ -207,12 +207,12 @@ func (ces *ChaincodeInstanceEventService) EventsChan(ctx context.Context, rr ...
 
 	go func() {
 		for e := range events {
-			eventProcessed, err := ProcessEvent(e, ces.Opts.Event, req.EventName)
+			processed, err := ProcessEvents(e, ces.Opts.Event, req.EventName)
 
 			if err != nil {
 				ces.Logger.Warn(`event processing`, zap.Error(err))
 			}
-			if eventProcessed != nil {
+			for _, eventProcessed := range processed {
 				eventsProcessed <- eventProcessed
 			}
 		}
@@ -228,18 +228,79 @@ func ProcessEvent(event interface {
 	TxTimestamp() *timestamp.Timestamp
 }, opts []EventOpt, matchName []string) (*ChaincodeEvent, error) {
 
-	processedEvent := &ChaincodeEvent{
+	return processEvent(&ChaincodeEvent{
+		Event:       event.Event(),
+		Block:       event.Block(),
+		TxTimestamp: event.TxTimestamp(),
+	}, opts, matchName)
+}
+
+// ProcessEvents unpacks batch event to events, set in transaction, and processes each event as ProcessEvent.
+// Events, failed on processing, are returned with error of first failed event
+func ProcessEvents(event interface {
+	Event() *peer.ChaincodeEvent
+	Block() uint64
+	TxTimestamp() *timestamp.Timestamp
+}, opts []EventOpt, matchName []string) (processedEvents []*ChaincodeEvent, err error) {
+
+	events, err := UnpackEventBatch(&ChaincodeEvent{
 		Event:       event.Event(),
 		Block:       event.Block(),
 		TxTimestamp: event.TxTimestamp(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range events {
+		processedEvent, processErr := processEvent(e, opts, matchName)
+		if processErr != nil && err == nil {
+			err = processErr
+		}
+		if processedEvent != nil {
+			processedEvents = append(processedEvents, processedEvent)
+		}
+	}
+
+	return processedEvents, err
+}
+
+// UnpackEventBatch returns events from batch event, emitted with state.EventBatch,
+// other events are returned as is
+func UnpackEventBatch(event *ChaincodeEvent) ([]*ChaincodeEvent, error) {
+	if event.Event == nil || event.Event.EventName != state.EventBatchName {
+		return []*ChaincodeEvent{event}, nil
+	}
+
+	batched, err := state.UnpackEventBatch(event.Event.Payload)
+	if err != nil {
+		return nil, fmt.Errorf(`unpack event batch: %w`, err)
 	}
+
+	events := make([]*ChaincodeEvent, len(batched))
+	for i, b := range batched {
+		events[i] = &ChaincodeEvent{
+			Event: &peer.ChaincodeEvent{
+				ChaincodeId: event.Event.ChaincodeId,
+				TxId:        event.Event.TxId,
+				EventName:   b.Name,
+				Payload:     b.Payload,
+			},
+			Block:       event.Block,
+			TxTimestamp: event.TxTimestamp,
+		}
+	}
+	return events, nil
+}
+
+func processEvent(processedEvent *ChaincodeEvent, opts []EventOpt, matchName []string) (*ChaincodeEvent, error) {
 	for _, o := range opts {
 		if err := o(processedEvent); err != nil {
 			return processedEvent, err
 		}
 	}
 
-	if !MatchEventName(event.Event().EventName, matchName) {
+	if !MatchEventName(processedEvent.Event.EventName, matchName) {
 		return nil, nil
 	}
 
@@ -0,0 +1,93 @@
+package gateway_test
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/cckit/gateway"
+	"github.com/hyperledger-labs/cckit/state"
+	"github.com/hyperledger-labs/cckit/state/schema"
+)
+
+type deliveredEvent struct {
+	event *peer.ChaincodeEvent
+}
+
+func (e *deliveredEvent) Event() *peer.ChaincodeEvent {
+	return e.event
+}
+
+func (e *deliveredEvent) Block() uint64 {
+	return 10
+}
+
+func (e *deliveredEvent) TxTimestamp() *timestamp.Timestamp {
+	return &timestamp.Timestamp{Seconds: 1}
+}
+
+var _ = Describe(`Event batch`, func() {
+
+	var (
+		batchEvent = func() *deliveredEvent {
+			payload, err := proto.Marshal(&schema.EventBatch{Events: []*schema.BatchedEvent{
+				{Name: `Created`, Payload: []byte(`a`)},
+				{Name: `Updated`, Payload: []byte(`b`)},
+			}})
+			Expect(err).NotTo(HaveOccurred())
+
+			return &deliveredEvent{event: &peer.ChaincodeEvent{
+				ChaincodeId: `cc`,
+				TxId:        `tx`,
+				EventName:   state.EventBatchName,
+				Payload:     payload,
+			}}
+		}
+	)
+
+	It("Allow to unpack batch event to separate events", func() {
+		events, err := gateway.ProcessEvents(batchEvent(), nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(events).To(HaveLen(2))
+
+		Expect(events[0].Event.EventName).To(Equal(`Created`))
+		Expect(events[0].Event.Payload).To(Equal([]byte(`a`)))
+		Expect(events[0].Event.TxId).To(Equal(`tx`))
+		Expect(events[0].Block).To(Equal(uint64(10)))
+		Expect(events[1].Event.EventName).To(Equal(`Updated`))
+	})
+
+	It("Allow to filter unpacked events by name", func() {
+		events, err := gateway.ProcessEvents(batchEvent(), nil, []string{`Updated`})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].Event.EventName).To(Equal(`Updated`))
+	})
+
+	It("Allow to process not batched event as is", func() {
+		events, err := gateway.ProcessEvents(&deliveredEvent{event: &peer.ChaincodeEvent{
+			EventName: `Created`, Payload: []byte(`a`)}}, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].Event.EventName).To(Equal(`Created`))
+	})
+
+	It("Allow to send batch event to stream as separate events", func(done Done) {
+		ctxWithCancel, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		stream := gateway.NewChaincodeEventServerStream(ctxWithCancel)
+
+		go func() {
+			defer GinkgoRecover()
+			Expect(stream.SendMsg(&gateway.ChaincodeEvent{Event: batchEvent().event})).To(Succeed())
+		}()
+
+		Expect((<-stream.Events()).Event.EventName).To(Equal(`Created`))
+		Expect((<-stream.Events()).Event.EventName).To(Equal(`Updated`))
+		close(done)
+	}, 1)
+})
@@ -68,8 +68,19 @@ func (s *ChaincodeEventServerStream) send(e *ChaincodeEvent) error {
 	return nil
 }
 
+// SendMsg sends event to stream, batch event is sent as separate events
 func (s *ChaincodeEventServerStream) SendMsg(m interface{}) error {
-	return s.send(proto.Clone(m.(*ChaincodeEvent)).(*ChaincodeEvent))
+	events, err := UnpackEventBatch(proto.Clone(m.(*ChaincodeEvent)).(*ChaincodeEvent))
+	if err != nil {
+		return err
+	}
+
+	for _, e := range events {
+		if err = s.send(e); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (s *ChaincodeEventServerStream) Recv(e *ChaincodeEvent) error {
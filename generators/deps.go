@@ -6,6 +6,8 @@ package generators
 import (
 	// chaincode gateway
 	_ "github.com/hyperledger-labs/cckit/gateway/protoc-gen-cc-gateway"
+	// state and event mappings
+	_ "github.com/hyperledger-labs/cckit/state/mapping/protoc-gen-cc-mapping"
 	// proto/grpc
 	_ "github.com/golang/protobuf/protoc-gen-go"
 	// json gateway
@@ -251,7 +251,11 @@ func (c *context) SetEvent(name string, payload interface{}) error {
 	return c.Event().Set(name, payload)
 }
 
-func ContextWithStateCache(ctx Context) Context {
-	clone := ctx.Clone()
-	return clone.UseState(state.WithCache(clone.State()))
+// ContextWithStateCache returns clone of context with tx level state cache
+func ContextWithStateCache(ctx Context) (Context, error) {
+	cached, err := state.WithCache(ctx.State())
+	if err != nil {
+		return nil, err
+	}
+	return ctx.Clone().UseState(cached), nil
 }
@@ -33,6 +33,17 @@ func Bool(name string, argPoss ...int) router.MiddlewareFunc {
 	return Param(name, serialize.TypeBool, argPoss...)
 }
 
+// BigInt creates middleware for converting to *big.Int chaincode method parameter, passed as base 10 string
+func BigInt(name string, argPoss ...int) router.MiddlewareFunc {
+	return Param(name, serialize.TypeBigInt, argPoss...)
+}
+
+// BigRat creates middleware for converting to *big.Rat chaincode method parameter,
+// passed as fraction "a/b" or decimal string
+func BigRat(name string, argPoss ...int) router.MiddlewareFunc {
+	return Param(name, serialize.TypeBigRat, argPoss...)
+}
+
 // Struct creates middleware for converting to struct chaincode method parameter
 func Struct(name string, target interface{}, argPoss ...int) router.MiddlewareFunc {
 	return Param(name, target, argPoss...)
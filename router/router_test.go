@@ -1,6 +1,7 @@
 package router_test
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/hyperledger/fabric-chaincode-go/shim"
@@ -8,6 +9,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/hyperledger-labs/cckit/router"
+	"github.com/hyperledger-labs/cckit/router/param"
 	"github.com/hyperledger-labs/cckit/serialize"
 	testcc "github.com/hyperledger-labs/cckit/testing"
 )
@@ -22,7 +24,13 @@ func New() *router.Chaincode {
 		Init(router.EmptyContextHandler).
 		Invoke(`empty`, func(c router.Context) (interface{}, error) {
 			return nil, nil
-		})
+		}).
+		Invoke(`bigIntSum`, func(c router.Context) (interface{}, error) {
+			return new(big.Int).Add(c.Param(`a`).(*big.Int), c.Param(`b`).(*big.Int)), nil
+		}, param.BigInt(`a`), param.BigInt(`b`)).
+		Invoke(`bigRatMul`, func(c router.Context) (interface{}, error) {
+			return new(big.Rat).Mul(c.Param(`a`).(*big.Rat), c.Param(`b`).(*big.Rat)), nil
+		}, param.BigRat(`a`), param.BigRat(`b`))
 
 	return router.NewChaincode(r)
 }
@@ -44,4 +52,17 @@ var _ = Describe(`Router`, func() {
 
 	})
 
+	It(`Allow big number params`, func() {
+		a, _ := new(big.Int).SetString(`123456789012345678901234567890`, 10)
+		response := cc.Invoke(`bigIntSum`, a, big.NewInt(10))
+		Expect(response.Status).To(Equal(int32(shim.OK)))
+		Expect(string(response.Payload)).To(Equal(`123456789012345678901234567900`))
+
+		response = cc.Invoke(`bigRatMul`, `1/3`, `1.5`)
+		Expect(response.Status).To(Equal(int32(shim.OK)))
+		Expect(string(response.Payload)).To(Equal(`1/2`))
+
+		Expect(cc.Invoke(`bigIntSum`, `1.5`, `1`).Status).To(Equal(int32(shim.ERROR)))
+	})
+
 })
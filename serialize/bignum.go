@@ -0,0 +1,234 @@
+package serialize
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type (
+	// BigNumberBinarySerializer serializes *big.Int and *big.Rat values in binary (gob) form,
+	// other values are serialized with wrapped serializer
+	BigNumberBinarySerializer struct {
+		Serializer Serializer
+	}
+)
+
+var (
+	// TypeBigInt target for converting to *big.Int
+	TypeBigInt = new(big.Int)
+	// TypeBigRat target for converting to *big.Rat
+	TypeBigRat = new(big.Rat)
+)
+
+const (
+	// decimal key exponent offset and width, exponent is number of digits before decimal point
+	decimalKeyExpOffset = 50000
+	decimalKeyExpWidth  = 5
+
+	decimalKeyNegative = '0'
+	decimalKeyZero     = '1'
+	decimalKeyPositive = '2'
+	// decimalKeyNegativeEnd terminates complemented digits of negative number,
+	// so shorter digits (greater negative number) sort after longer
+	decimalKeyNegativeEnd = '~'
+)
+
+// NewBigNumberBinarySerializer creates serializer with binary form of big numbers
+func NewBigNumberBinarySerializer(serializer Serializer) *BigNumberBinarySerializer {
+	return &BigNumberBinarySerializer{Serializer: serializer}
+}
+
+func (bs *BigNumberBinarySerializer) ToBytesFrom(entry interface{}) ([]byte, error) {
+	switch v := entry.(type) {
+	case *big.Int:
+		return v.GobEncode()
+	case *big.Rat:
+		return v.GobEncode()
+	default:
+		return bs.Serializer.ToBytesFrom(entry)
+	}
+}
+
+func (bs *BigNumberBinarySerializer) FromBytesTo(serialized []byte, target interface{}) (interface{}, error) {
+	switch target.(type) {
+	case *big.Int:
+		v := new(big.Int)
+		if err := v.GobDecode(serialized); err != nil {
+			return nil, fmt.Errorf(`%w: %s`, ErrInvalidBigNumber, err)
+		}
+		return v, nil
+	case *big.Rat:
+		v := new(big.Rat)
+		if err := v.GobDecode(serialized); err != nil {
+			return nil, fmt.Errorf(`%w: %s`, ErrInvalidBigNumber, err)
+		}
+		return v, nil
+	default:
+		return bs.Serializer.FromBytesTo(serialized, target)
+	}
+}
+
+// BigIntFromString converts base 10 string to *big.Int
+func BigIntFromString(s string) (*big.Int, error) {
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf(`%w: %s`, ErrInvalidBigNumber, s)
+	}
+	return v, nil
+}
+
+// BigRatFromString converts string as fraction "a/b" or decimal "1.25" to *big.Rat
+func BigRatFromString(s string) (*big.Rat, error) {
+	v, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf(`%w: %s`, ErrInvalidBigNumber, s)
+	}
+	return v, nil
+}
+
+// DecimalFromString creates decimal of target type from decimal string, for example "123.45"
+func DecimalFromString(s string, target Decimal) (Decimal, error) {
+	v, ok := reflect.New(reflect.TypeOf(target).Elem()).Interface().(Decimal)
+	if !ok {
+		return nil, fmt.Errorf(`%w: decimal target %T`, ErrInvalidBigNumber, target)
+	}
+	if err := v.SetText(s); err != nil {
+		return nil, fmt.Errorf(`%w: %s`, ErrInvalidBigNumber, err)
+	}
+	return v, nil
+}
+
+// EncodeBigIntKey encodes integer to key part, preserving order of numbers
+func EncodeBigIntKey(v *big.Int) (string, error) {
+	return EncodeDecimalKey(v, 0)
+}
+
+// DecodeBigIntKey decodes integer from key part
+func DecodeBigIntKey(key string) (*big.Int, error) {
+	unscaled, scale, err := DecodeDecimalKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if scale != 0 {
+		return nil, fmt.Errorf(`%w: not integer key %s`, ErrInvalidBigNumberKey, key)
+	}
+	return unscaled, nil
+}
+
+// EncodeBigRatKey encodes rational number with finite decimal representation to key part, preserving order of numbers
+func EncodeBigRatKey(v *big.Rat) (string, error) {
+	unscaled, scale, err := finiteDecimal(v)
+	if err != nil {
+		return ``, err
+	}
+	return EncodeDecimalKey(unscaled, scale)
+}
+
+// DecodeBigRatKey decodes rational number from key part
+func DecodeBigRatKey(key string) (*big.Rat, error) {
+	unscaled, scale, err := DecodeDecimalKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Rat).SetFrac(unscaled, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)), nil
+}
+
+// EncodeDecimalKey encodes decimal number unscaled * 10^-scale to key part. Keys of numbers are ordered
+// as numbers, regardless of scale: sign, exponent (number of digits before decimal point)
+// and significant digits without trailing zeros, digits of negative numbers are complemented
+func EncodeDecimalKey(unscaled *big.Int, scale int32) (string, error) {
+	if unscaled.Sign() == 0 {
+		return string(decimalKeyZero), nil
+	}
+
+	digits := new(big.Int).Abs(unscaled).String()
+	exp := len(digits) - int(scale)
+	digits = strings.TrimRight(digits, `0`)
+
+	if exp+decimalKeyExpOffset < 0 || exp+decimalKeyExpOffset >= 2*decimalKeyExpOffset {
+		return ``, fmt.Errorf(`%w: exponent out of range %d`, ErrInvalidBigNumberKey, exp)
+	}
+
+	encodedExp := fmt.Sprintf(`%0*d`, decimalKeyExpWidth, exp+decimalKeyExpOffset)
+	if unscaled.Sign() > 0 {
+		return string(decimalKeyPositive) + encodedExp + digits, nil
+	}
+	return string(decimalKeyNegative) + complementDigits(encodedExp) + complementDigits(digits) +
+		string(decimalKeyNegativeEnd), nil
+}
+
+// DecodeDecimalKey decodes decimal number from key part, returns unscaled value and scale (not negative)
+func DecodeDecimalKey(key string) (*big.Int, int32, error) {
+	if key == string(decimalKeyZero) {
+		return new(big.Int), 0, nil
+	}
+	if len(key) < 2+decimalKeyExpWidth {
+		return nil, 0, fmt.Errorf(`%w: %s`, ErrInvalidBigNumberKey, key)
+	}
+
+	negative := key[0] == decimalKeyNegative
+	encodedExp, digits := key[1:1+decimalKeyExpWidth], key[1+decimalKeyExpWidth:]
+	switch {
+	case negative && strings.HasSuffix(digits, string(decimalKeyNegativeEnd)):
+		encodedExp = complementDigits(encodedExp)
+		digits = complementDigits(strings.TrimSuffix(digits, string(decimalKeyNegativeEnd)))
+	case key[0] != decimalKeyPositive:
+		return nil, 0, fmt.Errorf(`%w: %s`, ErrInvalidBigNumberKey, key)
+	}
+
+	exp, err := strconv.Atoi(encodedExp)
+	if err != nil {
+		return nil, 0, fmt.Errorf(`%w: %s`, ErrInvalidBigNumberKey, key)
+	}
+	exp -= decimalKeyExpOffset
+
+	unscaled, ok := new(big.Int).SetString(digits, 10)
+	if !ok || digits == `` || digits[0] == '-' || digits[0] == '+' {
+		return nil, 0, fmt.Errorf(`%w: %s`, ErrInvalidBigNumberKey, key)
+	}
+
+	scale := len(digits) - exp
+	if scale < 0 {
+		unscaled.Mul(unscaled, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-scale)), nil))
+		scale = 0
+	}
+	if negative {
+		unscaled.Neg(unscaled)
+	}
+	return unscaled, int32(scale), nil
+}
+
+func complementDigits(digits string) string {
+	complemented := []byte(digits)
+	for i, d := range complemented {
+		complemented[i] = '9' - d + '0'
+	}
+	return string(complemented)
+}
+
+// finiteDecimal returns unscaled value and scale of rational number, if it has finite decimal representation
+// (denominator has only 2 and 5 prime factors)
+func finiteDecimal(v *big.Rat) (*big.Int, int32, error) {
+	denom := new(big.Int).Set(v.Denom())
+	var twos, fives int32
+	for two := big.NewInt(2); new(big.Int).Mod(denom, two).Sign() == 0; twos++ {
+		denom.Quo(denom, two)
+	}
+	for five := big.NewInt(5); new(big.Int).Mod(denom, five).Sign() == 0; fives++ {
+		denom.Quo(denom, five)
+	}
+	if denom.Cmp(big.NewInt(1)) != 0 {
+		return nil, 0, fmt.Errorf(`%w: %s has no finite decimal representation`,
+			ErrInvalidBigNumberKey, v.RatString())
+	}
+
+	scale := twos
+	if fives > scale {
+		scale = fives
+	}
+	unscaled := new(big.Int).Mul(v.Num(), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil))
+	return unscaled.Quo(unscaled, v.Denom()), scale, nil
+}
@@ -0,0 +1,260 @@
+package serialize
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+type (
+	// CanonicalSerializer serializes proto values to stable bytes, suitable for signing and hashing:
+	// deterministic proto binary or canonical JSON (RFC 8785 like: sorted keys, no whitespace,
+	// ECMAScript number formatting). Other values are serialized as with generic serializer
+	CanonicalSerializer struct {
+		Target        TargetType
+		UseProtoNames bool // to use proto field name instead of lowerCamelCase name in JSON field names
+	}
+)
+
+var (
+	// CanonicalBinarySerializer serializes proto values to deterministic proto binary
+	CanonicalBinarySerializer = &CanonicalSerializer{
+		Target:        DefaultTarget,
+		UseProtoNames: true,
+	}
+
+	// CanonicalJSONSerializer serializes proto values to canonical JSON
+	CanonicalJSONSerializer = &CanonicalSerializer{
+		Target:        PreferJSON,
+		UseProtoNames: true,
+	}
+)
+
+func (cs *CanonicalSerializer) ToBytesFrom(entry interface{}) ([]byte, error) {
+	switch entryType := entry.(type) {
+	case Serializable:
+		return entryType.ToBytes(cs)
+
+	case proto.Message:
+		if cs.Target == PreferJSON {
+			return CanonicalJSONProtoMarshal(entryType, &protojson.MarshalOptions{UseProtoNames: cs.UseProtoNames})
+		}
+		return DeterministicProtoMarshal(entryType)
+	default:
+		return toBytes(entry)
+	}
+}
+
+func (cs *CanonicalSerializer) FromBytesTo(serialized []byte, target interface{}) (interface{}, error) {
+	switch targetType := target.(type) {
+	case proto.Message:
+		if cs.Target == PreferJSON {
+			return JSONProtoUnmarshal(serialized, targetType)
+		}
+		return BinaryProtoUnmarshal(serialized, targetType)
+	default:
+		return fromBytes(serialized, target)
+	}
+}
+
+// DeterministicProtoMarshal marshals proto with deterministic map entries order
+func DeterministicProtoMarshal(entry proto.Message) ([]byte, error) {
+	return proto.MarshalOptions{Deterministic: true}.Marshal(entry)
+}
+
+// CanonicalJSONProtoMarshal marshals proto to JSON with marshal options and converts result to canonical JSON
+func CanonicalJSONProtoMarshal(entry proto.Message, mo *protojson.MarshalOptions) ([]byte, error) {
+	bb, err := mo.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+	return CanonicalJSON(bb)
+}
+
+// CanonicalJSON converts JSON to canonical form: object keys are sorted by UTF-16 code units,
+// whitespace is removed, numbers are formatted as in ECMAScript (JSON.stringify), strings are
+// escaped minimally. Numbers are IEEE 754 doubles, so int64 values must be passed as strings
+// (protojson does it), integers not exactly representable in canonical form are rejected
+func CanonicalJSON(bb []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(bb))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf(`%w: %s`, ErrInvalidJSON, err)
+	}
+	if dec.More() {
+		return nil, fmt.Errorf(`%w: trailing data`, ErrInvalidJSON)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := writeCanonicalJSON(buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeCanonicalJSON(buf *bytes.Buffer, v interface{}) error {
+	switch val := v.(type) {
+	case nil:
+		buf.WriteString(`null`)
+	case bool:
+		buf.WriteString(strconv.FormatBool(val))
+	case json.Number:
+		f, err := strconv.ParseFloat(string(val), 64)
+		if err != nil {
+			return fmt.Errorf(`%w: number %s: %s`, ErrInvalidJSON, val, err)
+		}
+		n, err := canonicalNumber(f)
+		if err != nil {
+			return err
+		}
+		// different integers must not have same canonical form, i.e. integers above 2^53
+		if !exactInteger(string(val), n) {
+			return fmt.Errorf(`%w: %s`, ErrInexactNumber, val)
+		}
+		buf.WriteString(n)
+	case string:
+		writeCanonicalString(buf, val)
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, item := range val {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonicalJSON(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return lessUTF16(keys[i], keys[j])
+		})
+
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeCanonicalString(buf, k)
+			buf.WriteByte(':')
+			if err := writeCanonicalJSON(buf, val[k]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf(`%w: unexpected type %T`, ErrInvalidJSON, v)
+	}
+	return nil
+}
+
+// exactInteger checks that integer number literal and its canonical form have exactly the same value,
+// fractional numbers are rounded to nearest double as in ECMAScript
+func exactInteger(literal, canonical string) bool {
+	l, ok := new(big.Rat).SetString(literal)
+	if !ok {
+		return false
+	}
+	if !l.IsInt() {
+		return true
+	}
+	c, ok := new(big.Rat).SetString(canonical)
+	if !ok {
+		return false
+	}
+	return l.Cmp(c) == 0
+}
+
+// canonicalNumber formats number as ECMAScript Number.prototype.toString
+func canonicalNumber(f float64) (string, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return ``, fmt.Errorf(`%w: number %v`, ErrInvalidJSON, f)
+	}
+	if f == 0 {
+		return `0`, nil
+	}
+
+	abs := math.Abs(f)
+	if abs >= 1e-6 && abs < 1e21 {
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	}
+
+	// exponent without leading zeros: 1e-7, 1e+21
+	s := strconv.FormatFloat(f, 'e', -1, 64)
+	mantissa, exp := s[:strings.IndexByte(s, 'e')], s[strings.IndexByte(s, 'e')+1:]
+	sign, digits := exp[:1], strings.TrimLeft(exp[1:], `0`)
+	return mantissa + `e` + sign + digits, nil
+}
+
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	const hex = `0123456789abcdef`
+
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hex[r>>4])
+				buf.WriteByte(hex[r&0xf])
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+}
+
+// lessUTF16 compares strings by UTF-16 code units, as required for canonical JSON keys order
+func lessUTF16(a, b string) bool {
+	for a != `` && b != `` {
+		ra, na := utf8.DecodeRuneInString(a)
+		rb, nb := utf8.DecodeRuneInString(b)
+		if ka, kb := utf16Key(ra), utf16Key(rb); ka != kb {
+			return ka < kb
+		}
+		if ra != rb {
+			// same high surrogate, low surrogates are ordered as runes
+			return ra < rb
+		}
+		a, b = a[na:], b[nb:]
+	}
+	return a == `` && b != ``
+}
+
+// utf16Key returns first UTF-16 code unit of rune, runes outside BMP are encoded with surrogates 0xD800-0xDBFF
+func utf16Key(r rune) rune {
+	if r >= 0x10000 {
+		return 0xD800 + ((r - 0x10000) >> 10)
+	}
+	return r
+}
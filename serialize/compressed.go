@@ -0,0 +1,148 @@
+package serialize
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+type (
+	// Compression algorithm of compressed serializer, value is used as header byte of compressed value
+	Compression byte
+
+	// CompressedSerializer compresses values, serialized with wrapped serializer. Compressed value starts
+	// with header byte, values without header (for example, stored before compression was enabled)
+	// are decoded as is
+	CompressedSerializer struct {
+		Serializer  Serializer
+		Compression Compression
+		// MinSize values less than min size are stored uncompressed
+		MinSize int
+	}
+
+	CompressedSerializerOpt func(*CompressedSerializer)
+)
+
+const (
+	// CompressionNone header of value stored uncompressed, used if value starts with header byte
+	CompressionNone Compression = iota
+	CompressionGzip
+	CompressionZlib
+)
+
+// NewCompressedSerializer creates serializer, compressing values of serializer with gzip
+func NewCompressedSerializer(serializer Serializer, opts ...CompressedSerializerOpt) *CompressedSerializer {
+	cs := &CompressedSerializer{
+		Serializer:  serializer,
+		Compression: CompressionGzip,
+	}
+
+	for _, o := range opts {
+		o(cs)
+	}
+	return cs
+}
+
+// WithCompression sets compression algorithm
+func WithCompression(compression Compression) CompressedSerializerOpt {
+	return func(cs *CompressedSerializer) {
+		cs.Compression = compression
+	}
+}
+
+// WithCompressionMinSize sets min size of value to compress
+func WithCompressionMinSize(minSize int) CompressedSerializerOpt {
+	return func(cs *CompressedSerializer) {
+		cs.MinSize = minSize
+	}
+}
+
+func (cs *CompressedSerializer) ToBytesFrom(entry interface{}) ([]byte, error) {
+	bb, err := cs.Serializer.ToBytesFrom(entry)
+	if err != nil {
+		return nil, err
+	}
+	return Compress(bb, cs.Compression, cs.MinSize)
+}
+
+func (cs *CompressedSerializer) FromBytesTo(serialized []byte, target interface{}) (interface{}, error) {
+	bb, err := Decompress(serialized)
+	if err != nil {
+		return nil, err
+	}
+	return cs.Serializer.FromBytesTo(bb, target)
+}
+
+// Compress compresses value with header byte. Empty value is returned as is,
+// value less than min size is stored uncompressed
+func Compress(bb []byte, compression Compression, minSize int) ([]byte, error) {
+	if len(bb) == 0 {
+		return bb, nil
+	}
+
+	if len(bb) < minSize || compression == CompressionNone {
+		// value without header is decoded as is, header is needed only if value starts with header byte
+		if !isCompressionHeader(bb[0]) {
+			return bb, nil
+		}
+		return append([]byte{byte(CompressionNone)}, bb...), nil
+	}
+
+	buf := bytes.NewBuffer([]byte{byte(compression)})
+	var w io.WriteCloser
+	switch compression {
+	case CompressionGzip:
+		w = gzip.NewWriter(buf)
+	case CompressionZlib:
+		w = zlib.NewWriter(buf)
+	default:
+		return nil, fmt.Errorf(`%w: %d`, ErrCompressionNotSupported, compression)
+	}
+
+	if _, err := w.Write(bb); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decompress decompresses value by header byte, value without header is returned as is
+func Decompress(bb []byte) ([]byte, error) {
+	if len(bb) == 0 || !isCompressionHeader(bb[0]) {
+		return bb, nil
+	}
+
+	var (
+		r   io.ReadCloser
+		err error
+	)
+	switch Compression(bb[0]) {
+	case CompressionNone:
+		return bb[1:], nil
+	case CompressionGzip:
+		r, err = gzip.NewReader(bytes.NewReader(bb[1:]))
+	case CompressionZlib:
+		r, err = zlib.NewReader(bytes.NewReader(bb[1:]))
+	}
+	if err != nil {
+		return nil, fmt.Errorf(`decompress: %w`, err)
+	}
+	defer func() { _ = r.Close() }()
+
+	decompressed, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf(`decompress: %w`, err)
+	}
+	return decompressed, nil
+}
+
+// isCompressionHeader checks byte is header of compressed value. Header bytes are not valid
+// as first byte of binary proto (field number 0) and JSON values
+func isCompressionHeader(b byte) bool {
+	return Compression(b) <= CompressionZlib
+}
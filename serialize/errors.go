@@ -11,4 +11,23 @@ var (
 	ErrUnableToConvertNilToStruct = errors.New(`unable to convert nil to [struct,array,slice,ptr]`)
 	// ErrUnableToConvertValueToStruct - value  cannot be converted to struct
 	ErrUnableToConvertValueToStruct = errors.New(`unable to convert value to struct`)
+
+	// ErrCompressionNotSupported - compression algorithm is not supported
+	ErrCompressionNotSupported = errors.New(`compression not supported`)
+	// ErrNotSelfDescribing - value is not proto wrapped in Any with type URL
+	ErrNotSelfDescribing = errors.New(`value is not self describing`)
+	// ErrSelfDescribingTypeMismatch - value is proto wrapped in Any with type URL of other type than target
+	ErrSelfDescribingTypeMismatch = errors.New(`self describing value type mismatch`)
+	// ErrInvalidJSON - value cannot be converted to canonical JSON
+	ErrInvalidJSON = errors.New(`invalid json`)
+	// ErrInexactNumber - JSON number cannot be converted to canonical form without loss of precision
+	ErrInexactNumber = errors.New(`inexact number`)
+	// ErrVersionNotSupported - stored value version is newer than current version of type
+	ErrVersionNotSupported = errors.New(`version not supported`)
+	// ErrInvalidVersionHeader - version header of stored value cannot be decoded
+	ErrInvalidVersionHeader = errors.New(`invalid version header`)
+	// ErrInvalidBigNumber - value cannot be converted to big number
+	ErrInvalidBigNumber = errors.New(`invalid big number`)
+	// ErrInvalidBigNumberKey - big number cannot be encoded to or decoded from key part
+	ErrInvalidBigNumberKey = errors.New(`invalid big number key`)
 )
@@ -3,6 +3,7 @@ package serialize
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"reflect"
 	"strconv"
 
@@ -21,6 +22,10 @@ func fromBytes(bb []byte, target interface{}) (result interface{}, err error) {
 		return strconv.Atoi(string(bb))
 	case bool:
 		return strconv.ParseBool(string(bb))
+	case *big.Int:
+		return BigIntFromString(string(bb))
+	case *big.Rat:
+		return BigRatFromString(string(bb))
 	case []string:
 		arrInterface, err := JSONUnmarshalPtr(bb, &target)
 
@@ -36,6 +41,9 @@ func fromBytes(bb []byte, target interface{}) (result interface{}, err error) {
 	case FromByter:
 		return t.FromBytes(bb)
 
+	case Decimal:
+		return DecimalFromString(string(bb), t)
+
 	case proto.Message:
 		return BinaryProtoUnmarshal(bb, t)
 
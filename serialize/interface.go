@@ -31,6 +31,19 @@ type (
 		FromBytesTo(from []byte, target interface{}) (interface{}, error)
 	}
 
+	// Rewriter is implemented by serializers, which can convert value, stored in previous format, to current format.
+	// Returns false if value is already in current format
+	Rewriter interface {
+		Rewrite(serialized []byte, target interface{}) ([]byte, bool, error)
+	}
+
+	// Decimal interface is implemented by decimal number types (for example token.Decimal),
+	// which are serialized in string form, for example "123.45"
+	Decimal interface {
+		Text() (string, error)
+		SetText(string) error
+	}
+
 	// ToBytesConverter supports ToBytesConverter func converting from some interface to bytes
 	ToBytesConverter interface {
 		ToBytesFrom(from interface{}) ([]byte, error)
@@ -58,8 +58,13 @@ func BinaryProtoUnmarshal(bb []byte, messageType proto.Message) (message proto.M
 }
 
 func JSONProtoUnmarshal(json []byte, messageType proto.Message) (message proto.Message, err error) {
+	return JSONProtoUnmarshalWithOptions(json, messageType, &protojson.UnmarshalOptions{})
+}
+
+func JSONProtoUnmarshalWithOptions(json []byte, messageType proto.Message, uo *protojson.UnmarshalOptions) (
+	message proto.Message, err error) {
 	msg := proto.Clone(messageType)
-	err = protojson.Unmarshal(json, msg)
+	err = uo.Unmarshal(json, msg)
 
 	if err != nil {
 		return nil, fmt.Errorf(`json proto unmarshal: %w`, err)
@@ -0,0 +1,92 @@
+package serialize
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+type (
+	// SelfDescribingSerializer stores proto values wrapped in Any with type URL, so values can be decoded
+	// without knowing Go type, with types from global proto registry. Other values are serialized with
+	// wrapped serializer
+	SelfDescribingSerializer struct {
+		Serializer Serializer
+	}
+)
+
+// NewSelfDescribingSerializer creates self describing serializer, non proto values are serialized with serializer
+func NewSelfDescribingSerializer(serializer Serializer) *SelfDescribingSerializer {
+	return &SelfDescribingSerializer{Serializer: serializer}
+}
+
+func (ss *SelfDescribingSerializer) ToBytesFrom(entry interface{}) ([]byte, error) {
+	msg, ok := entry.(proto.Message)
+	if !ok {
+		return ss.Serializer.ToBytesFrom(entry)
+	}
+
+	a, err := anypb.New(msg)
+	if err != nil {
+		return nil, err
+	}
+	return proto.MarshalOptions{Deterministic: true}.Marshal(a)
+}
+
+// FromBytesTo decodes value to target. If target is proto, value must be Any with target type URL,
+// Any with other type URL is not decoded. Value, that is not Any, is decoded as proto without Any
+// (legacy value, for example stored with other serializer).
+// If target is nil, proto value is decoded with type from global proto registry
+func (ss *SelfDescribingSerializer) FromBytesTo(serialized []byte, target interface{}) (interface{}, error) {
+	switch t := target.(type) {
+	case proto.Message:
+		a := &anypb.Any{}
+		if err := proto.Unmarshal(serialized, a); err != nil || !isTypeURL(a.TypeUrl) {
+			return BinaryProtoUnmarshal(serialized, t)
+		}
+
+		if !a.MessageIs(t) {
+			return nil, fmt.Errorf(`%w: type=%s, target=%s`,
+				ErrSelfDescribingTypeMismatch, a.MessageName(), proto.MessageName(t))
+		}
+		msg := proto.Clone(t)
+		if err := a.UnmarshalTo(msg); err != nil {
+			return nil, fmt.Errorf(`unmarshal any to proto=%s: %w`, a.MessageName(), err)
+		}
+		return msg, nil
+
+	case nil:
+		if msg, err := Describe(serialized); err == nil {
+			return msg, nil
+		}
+	}
+
+	return ss.Serializer.FromBytesTo(serialized, target)
+}
+
+// isTypeURL checks that string is Any type URL, i.e. `type.googleapis.com/full.Name`
+func isTypeURL(typeURL string) bool {
+	i := strings.LastIndexByte(typeURL, '/')
+	return i >= 0 && protoreflect.FullName(typeURL[i+1:]).IsValid()
+}
+
+// Describe decodes proto value, stored with self describing serializer,
+// with type from global proto registry
+func Describe(serialized []byte) (proto.Message, error) {
+	a := &anypb.Any{}
+	if err := proto.Unmarshal(serialized, a); err != nil {
+		return nil, fmt.Errorf(`%w: %s`, ErrNotSelfDescribing, err)
+	}
+	if a.TypeUrl == `` || !protoreflect.FullName(a.MessageName()).IsValid() {
+		return nil, ErrNotSelfDescribing
+	}
+
+	msg, err := a.UnmarshalNew()
+	if err != nil {
+		return nil, fmt.Errorf(`%w: %s`, ErrNotSelfDescribing, err)
+	}
+	return msg, nil
+}
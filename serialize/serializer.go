@@ -16,6 +16,8 @@ type (
 		Supports      []SuppportedType
 		Target        TargetType
 		UseProtoNames bool // to use proto field name instead of lowerCamelCase name in JSON field names
+		// DiscardUnknown to ignore unknown fields in JSON, for example stored by newer version of chaincode
+		DiscardUnknown bool
 	}
 
 	StringSerializer struct {
@@ -60,6 +62,9 @@ func (g *GenericSerializer) ToBytesFrom(entry interface{}) ([]byte, error) {
 	case Serializable:
 		return entryType.ToBytes(g)
 
+	case Decimal:
+		return toBytes(entryType)
+
 	case proto.Message:
 		if g.Target == PreferJSON {
 			mo := &protojson.MarshalOptions{UseProtoNames: g.UseProtoNames}
@@ -76,9 +81,13 @@ func (g *GenericSerializer) ToBytesFrom(entry interface{}) ([]byte, error) {
 func (g *GenericSerializer) FromBytesTo(serialized []byte, target interface{}) (interface{}, error) {
 	switch targetType := target.(type) {
 
+	case Decimal:
+		return fromBytes(serialized, targetType)
+
 	case proto.Message:
 		if g.Target == PreferJSON {
-			return JSONProtoUnmarshal(serialized, targetType)
+			return JSONProtoUnmarshalWithOptions(serialized, targetType,
+				&protojson.UnmarshalOptions{DiscardUnknown: g.DiscardUnknown})
 		} else {
 			return BinaryProtoUnmarshal(serialized, targetType)
 		}
@@ -1,11 +1,20 @@
 package serialize_test
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	. "github.com/onsi/ginkgo"
@@ -163,3 +172,391 @@ var _ = Describe(`Prefer JSON serializer`, func() {
 		Expect(deserializedProto).To(gomega.StringerEqual(ProtoToSerialize))
 	})
 })
+
+var _ = Describe(`Compressed serializer`, func() {
+
+	serializer := serialize.NewCompressedSerializer(serialize.DefaultSerializer)
+
+	It(`serialize and deserialize proto`, func() {
+		bb, err := serializer.ToBytesFrom(ProtoToSerialize)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bb[0]).To(Equal(byte(serialize.CompressionGzip)))
+
+		deserializedProto, err := serializer.FromBytesTo(bb, &testdata.Payment{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deserializedProto).To(gomega.StringerEqual(ProtoToSerialize))
+	})
+
+	It(`compress large value`, func() {
+		large := make([]byte, 10000)
+		bb, err := serialize.NewCompressedSerializer(serialize.DefaultSerializer,
+			serialize.WithCompression(serialize.CompressionZlib)).ToBytesFrom(large)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bb[0]).To(Equal(byte(serialize.CompressionZlib)))
+		Expect(len(bb)).To(BeNumerically(`<`, len(large)))
+
+		decompressed, err := serializer.FromBytesTo(bb, []byte{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decompressed).To(Equal(large))
+	})
+
+	It(`store value less than min size uncompressed`, func() {
+		minSizeSerializer := serialize.NewCompressedSerializer(serialize.DefaultSerializer,
+			serialize.WithCompressionMinSize(1000))
+
+		bb, err := minSizeSerializer.ToBytesFrom(StringToSerialize)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bb).To(Equal([]byte(StringToSerialize)))
+
+		// value starting with header byte is stored with header
+		bb, err = minSizeSerializer.ToBytesFrom([]byte{byte(serialize.CompressionGzip), 1})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bb).To(Equal([]byte{byte(serialize.CompressionNone), byte(serialize.CompressionGzip), 1}))
+
+		deserialized, err := minSizeSerializer.FromBytesTo(bb, []byte{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deserialized).To(Equal([]byte{byte(serialize.CompressionGzip), 1}))
+	})
+
+	It(`deserialize legacy uncompressed value`, func() {
+		bb, err := serialize.DefaultSerializer.ToBytesFrom(ProtoToSerialize)
+		Expect(err).NotTo(HaveOccurred())
+
+		deserializedProto, err := serializer.FromBytesTo(bb, &testdata.Payment{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deserializedProto).To(gomega.StringerEqual(ProtoToSerialize))
+	})
+})
+
+var _ = Describe(`Self describing serializer`, func() {
+
+	serializer := serialize.NewSelfDescribingSerializer(serialize.DefaultSerializer)
+
+	var serializedProto []byte
+
+	It(`serialize proto as any`, func() {
+		serializedProto, err = serializer.ToBytesFrom(ProtoToSerialize)
+		Expect(err).NotTo(HaveOccurred())
+
+		a := &anypb.Any{}
+		Expect(proto.Unmarshal(serializedProto, a)).To(Succeed())
+		Expect(a.TypeUrl).To(Equal(`type.googleapis.com/testdata.Payment`))
+	})
+
+	It(`deserialize proto to target`, func() {
+		deserializedProto, err := serializer.FromBytesTo(serializedProto, &testdata.Payment{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deserializedProto).To(gomega.StringerEqual(ProtoToSerialize))
+	})
+
+	It(`deserialize proto without target with proto registry`, func() {
+		deserializedProto, err := serializer.FromBytesTo(serializedProto, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deserializedProto).To(gomega.StringerEqual(ProtoToSerialize))
+
+		described, err := serialize.Describe(serializedProto)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(described).To(BeAssignableToTypeOf(&testdata.Payment{}))
+	})
+
+	It(`deserialize legacy proto value`, func() {
+		bb, err := serialize.DefaultSerializer.ToBytesFrom(ProtoToSerialize)
+		Expect(err).NotTo(HaveOccurred())
+
+		deserializedProto, err := serializer.FromBytesTo(bb, &testdata.Payment{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deserializedProto).To(gomega.StringerEqual(ProtoToSerialize))
+	})
+
+	It(`disallow to deserialize proto of other type`, func() {
+		bb, err := serializer.ToBytesFrom(timestamppb.Now())
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = serializer.FromBytesTo(bb, &testdata.Payment{})
+		Expect(err).To(MatchError(ContainSubstring(serialize.ErrSelfDescribingTypeMismatch.Error())))
+	})
+
+	It(`serialize non proto with wrapped serializer`, func() {
+		bb, err := serializer.ToBytesFrom(StringToSerialize)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bb).To(Equal([]byte(StringToSerialize)))
+
+		_, err = serialize.Describe(bb)
+		Expect(err).To(MatchError(serialize.ErrNotSelfDescribing))
+	})
+})
+
+var _ = Describe(`Canonical serializer`, func() {
+
+	canonicalProto := &testdata.Payment{
+		Type:         "some-type",
+		Id:           "some-id",
+		Amount:       100,
+		Key:          []byte("public-key"),
+		Deadline:     &timestamppb.Timestamp{Seconds: 1675065805, Nanos: 271000000},
+		SnakeOrCamel: "snake_case or camelCase",
+	}
+
+	It(`serialize proto to canonical json`, func() {
+		bb, err := serialize.CanonicalJSONSerializer.ToBytesFrom(canonicalProto)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(bb)).To(Equal(`{"amount":100,"deadline":"2023-01-30T08:03:25.271Z","id":"some-id",` +
+			`"key":"cHVibGljLWtleQ==","snake_or_camel":"snake_case or camelCase","type":"some-type"}`))
+
+		deserializedProto, err := serialize.CanonicalJSONSerializer.FromBytesTo(bb, &testdata.Payment{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deserializedProto).To(gomega.StringerEqual(canonicalProto))
+	})
+
+	It(`serialize proto to deterministic binary`, func() {
+		bb, err := serialize.CanonicalBinarySerializer.ToBytesFrom(canonicalProto)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hex.EncodeToString(bb)).To(Equal(`0a09736f6d652d747970651207736f6d652d69641864220a7075626c69632d6b65792a0c` +
+			`08cdf3dd9e0610c0c39c81013217736e616b655f63617365206f722063616d656c43617365`))
+
+		deserializedProto, err := serialize.CanonicalBinarySerializer.FromBytesTo(bb, &testdata.Payment{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deserializedProto).To(gomega.StringerEqual(canonicalProto))
+	})
+
+	It(`convert json to canonical form with test vectors`, func() {
+		bb, err := ioutil.ReadFile(`testdata/canonical_json.json`)
+		Expect(err).NotTo(HaveOccurred())
+
+		var vectors []struct {
+			Name      string `json:"name"`
+			Input     string `json:"input"`
+			Canonical string `json:"canonical"`
+			SHA256    string `json:"sha256"`
+		}
+		Expect(json.Unmarshal(bb, &vectors)).To(Succeed())
+		Expect(vectors).NotTo(BeEmpty())
+
+		for _, v := range vectors {
+			canonical, err := serialize.CanonicalJSON([]byte(v.Input))
+			Expect(err).NotTo(HaveOccurred(), v.Name)
+			Expect(string(canonical)).To(Equal(v.Canonical), v.Name)
+
+			hash := sha256.Sum256(canonical)
+			Expect(hex.EncodeToString(hash[:])).To(Equal(v.SHA256), v.Name)
+		}
+	})
+
+	It(`disallow to convert invalid json to canonical form`, func() {
+		_, err := serialize.CanonicalJSON([]byte(`{"a":1} {}`))
+		Expect(err).To(MatchError(ContainSubstring(serialize.ErrInvalidJSON.Error())))
+
+		_, err = serialize.CanonicalJSON([]byte(`{"a":`))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It(`disallow to convert integers above 2^53 to same canonical form`, func() {
+		canonical, err := serialize.CanonicalJSON([]byte(`{"a":9007199254740992}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(canonical)).To(Equal(`{"a":9007199254740992}`))
+
+		_, err = serialize.CanonicalJSON([]byte(`{"a":9007199254740993}`))
+		Expect(err).To(MatchError(ContainSubstring(serialize.ErrInexactNumber.Error())))
+
+		_, err = serialize.CanonicalJSON([]byte(`{"a":9007199254740993e0}`))
+		Expect(err).To(MatchError(ContainSubstring(serialize.ErrInexactNumber.Error())))
+	})
+})
+
+var _ = Describe(`Versioned serializer`, func() {
+
+	// version 0 of Payment stored amount in `sum` field
+	renameSum := serialize.UpgradeJSON(func(fields map[string]interface{}) error {
+		fields[`amount`] = fields[`sum`]
+		delete(fields, `sum`)
+		return nil
+	})
+
+	legacyJSON := []byte(`{"type":"some-type","id":"some-id","sum":100}`)
+
+	It(`serialize value with version header`, func() {
+		serializer := serialize.NewVersionedSerializer(serialize.DefaultSerializer,
+			serialize.WithVersion(&testdata.Payment{}, 2))
+
+		bb, err := serializer.ToBytesFrom(ProtoToSerialize)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bb[0]).To(Equal(serialize.VersionHeader))
+
+		_, version, err := serialize.SplitVersionHeader(bb)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(version).To(Equal(uint32(2)))
+
+		deserializedProto, err := serializer.FromBytesTo(bb, &testdata.Payment{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deserializedProto).To(gomega.StringerEqual(ProtoToSerialize))
+
+		// types without version are serialized without header
+		bb, err = serializer.ToBytesFrom(StringToSerialize)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bb).To(Equal([]byte(StringToSerialize)))
+	})
+
+	It(`upgrade legacy value without header`, func() {
+		serializer := serialize.NewVersionedSerializer(serialize.PreferJSONSerializer,
+			serialize.WithVersion(&testdata.Payment{}, 1),
+			serialize.WithUpgrade(&testdata.Payment{}, 0, renameSum))
+
+		deserialized, err := serializer.FromBytesTo(legacyJSON, &testdata.Payment{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deserialized.(*testdata.Payment).Amount).To(Equal(int32(100)))
+
+		_, err = serialize.PreferJSONSerializer.FromBytesTo(legacyJSON, &testdata.Payment{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It(`decode value of type without version as is, even if it starts with version header byte`, func() {
+		serializer := serialize.NewVersionedSerializer(serialize.DefaultSerializer,
+			serialize.WithVersion(&testdata.Payment{}, 1))
+
+		raw := []byte{serialize.VersionHeader, 0x01, 0x02}
+		bb, err := serializer.ToBytesFrom(raw)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bb).To(Equal(raw))
+
+		deserialized, err := serializer.FromBytesTo(bb, []byte{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deserialized).To(Equal(raw))
+	})
+
+	It(`disallow to decode value of newer version`, func() {
+		serializer := serialize.NewVersionedSerializer(serialize.DefaultSerializer,
+			serialize.WithVersion(&testdata.Payment{}, 1))
+
+		_, err := serializer.FromBytesTo(serialize.WithVersionHeader([]byte{}, 2), &testdata.Payment{})
+		Expect(err).To(MatchError(ContainSubstring(serialize.ErrVersionNotSupported.Error())))
+	})
+
+	It(`allow to discard or preserve unknown json fields`, func() {
+		withUnknown := serialize.WithVersionHeader(
+			[]byte(`{"type":"some-type","amount":100,"added_in_next_version":"x"}`), 1)
+
+		serializer := serialize.NewVersionedSerializer(serialize.PreferJSONSerializer,
+			serialize.WithVersion(&testdata.Payment{}, 1))
+		_, err := serializer.FromBytesTo(withUnknown, &testdata.Payment{})
+		Expect(err).To(HaveOccurred())
+
+		for _, unknownFields := range []serialize.UnknownFields{
+			serialize.UnknownFieldsDiscard, serialize.UnknownFieldsPreserve} {
+			serializer = serialize.NewVersionedSerializer(serialize.PreferJSONSerializer,
+				serialize.WithVersion(&testdata.Payment{}, 1),
+				serialize.WithUnknownFields(unknownFields))
+
+			deserialized, err := serializer.FromBytesTo(withUnknown, &testdata.Payment{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deserialized.(*testdata.Payment).Amount).To(Equal(int32(100)))
+		}
+	})
+
+	It(`rewrite upgraded value`, func() {
+		for unknownFields, expectUnknown := range map[serialize.UnknownFields]bool{
+			serialize.UnknownFieldsDiscard:  false,
+			serialize.UnknownFieldsPreserve: true,
+		} {
+			serializer := serialize.NewVersionedSerializer(serialize.PreferJSONSerializer,
+				serialize.WithVersion(&testdata.Payment{}, 1),
+				serialize.WithUpgrade(&testdata.Payment{}, 0, renameSum),
+				serialize.WithUnknownFields(unknownFields),
+				serialize.WithRewriteUpgraded())
+
+			rewritten, upgraded, err := serializer.Rewrite(
+				[]byte(`{"type":"some-type","sum":100,"unknown":"x"}`), &testdata.Payment{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(upgraded).To(BeTrue())
+
+			bb, version, err := serialize.SplitVersionHeader(rewritten)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(Equal(uint32(1)))
+			Expect(string(bb)).To(ContainSubstring(`"amount":100`))
+			Expect(strings.Contains(string(bb), `"unknown"`)).To(Equal(expectUnknown))
+
+			// value of current version is not rewritten
+			_, upgraded, err = serializer.Rewrite(rewritten, &testdata.Payment{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(upgraded).To(BeFalse())
+		}
+	})
+})
+
+var _ = Describe(`Big numbers`, func() {
+
+	bigInt, _ := new(big.Int).SetString(`-123456789012345678901234567890`, 10)
+	bigRat := big.NewRat(-7, 3)
+
+	It(`serialize big numbers in string form`, func() {
+		bb, err := serialize.DefaultSerializer.ToBytesFrom(bigInt)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(bb)).To(Equal(`-123456789012345678901234567890`))
+
+		deserialized, err := serialize.DefaultSerializer.FromBytesTo(bb, serialize.TypeBigInt)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deserialized.(*big.Int).Cmp(bigInt)).To(Equal(0))
+
+		bb, err = serialize.DefaultSerializer.ToBytesFrom(bigRat)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(bb)).To(Equal(`-7/3`))
+
+		deserialized, err = serialize.DefaultSerializer.FromBytesTo(bb, serialize.TypeBigRat)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deserialized.(*big.Rat).Cmp(bigRat)).To(Equal(0))
+
+		_, err = serialize.DefaultSerializer.FromBytesTo([]byte(`1.5`), serialize.TypeBigInt)
+		Expect(errors.Is(err, serialize.ErrInvalidBigNumber)).To(BeTrue())
+	})
+
+	It(`serialize big numbers in binary form`, func() {
+		serializer := serialize.NewBigNumberBinarySerializer(serialize.DefaultSerializer)
+
+		bb, err := serializer.ToBytesFrom(bigInt)
+		Expect(err).NotTo(HaveOccurred())
+		deserialized, err := serializer.FromBytesTo(bb, serialize.TypeBigInt)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deserialized.(*big.Int).Cmp(bigInt)).To(Equal(0))
+
+		bb, err = serializer.ToBytesFrom(bigRat)
+		Expect(err).NotTo(HaveOccurred())
+		deserialized, err = serializer.FromBytesTo(bb, serialize.TypeBigRat)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deserialized.(*big.Rat).Cmp(bigRat)).To(Equal(0))
+
+		bb, err = serializer.ToBytesFrom(StringToSerialize)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(bb)).To(Equal(StringToSerialize))
+	})
+
+	It(`encode big numbers to key parts preserving order`, func() {
+		numbers := []string{`-1000.5`, `-1000`, `-12.5`, `-12`, `-1.25`, `-1.2`, `-0.001`,
+			`0`, `0.001`, `0.01`, `1`, `1.2`, `1.25`, `12`, `100`, `1000.5`, `123456789012345678901234567890`}
+
+		var keys []string
+		for _, n := range numbers {
+			r, _ := new(big.Rat).SetString(n)
+			key, err := serialize.EncodeBigRatKey(r)
+			Expect(err).NotTo(HaveOccurred())
+			keys = append(keys, key)
+
+			decoded, err := serialize.DecodeBigRatKey(key)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(decoded.Cmp(r)).To(Equal(0), n)
+		}
+		Expect(sort.StringsAreSorted(keys)).To(BeTrue())
+
+		// same number with different scales has same key
+		key1, _ := serialize.EncodeDecimalKey(big.NewInt(12), 1)
+		key2, _ := serialize.EncodeDecimalKey(big.NewInt(1200), 3)
+		Expect(key1).To(Equal(key2))
+
+		intKey, err := serialize.EncodeBigIntKey(bigInt)
+		Expect(err).NotTo(HaveOccurred())
+		decodedInt, err := serialize.DecodeBigIntKey(intKey)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decodedInt.Cmp(bigInt)).To(Equal(0))
+
+		_, err = serialize.EncodeBigRatKey(big.NewRat(1, 3))
+		Expect(errors.Is(err, serialize.ErrInvalidBigNumberKey)).To(BeTrue())
+	})
+})
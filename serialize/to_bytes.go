@@ -3,6 +3,7 @@ package serialize
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"reflect"
 	"strconv"
 
@@ -20,8 +21,19 @@ func toBytes(value interface{}) ([]byte, error) {
 	// first priority if value implements ToByter interface
 	case ToByter:
 		return v.ToBytes()
+	// decimal can be proto message, it is stored in string form
+	case Decimal:
+		text, err := v.Text()
+		if err != nil {
+			return nil, err
+		}
+		return []byte(text), nil
 	case proto.Message:
 		return BinaryProtoMarshal(v)
+	case *big.Int:
+		return []byte(v.String()), nil
+	case *big.Rat:
+		return []byte(v.RatString()), nil
 	case bool:
 		return []byte(strconv.FormatBool(v)), nil
 	case string:
@@ -0,0 +1,231 @@
+package serialize
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"google.golang.org/protobuf/proto"
+)
+
+type (
+	// UpgradeFunc converts value, serialized with wrapped serializer, from one version to next version
+	UpgradeFunc func(serialized []byte) ([]byte, error)
+
+	// UnknownFields defines how versioned serializer handles unknown JSON fields of stored values
+	UnknownFields int
+
+	// VersionedSerializer stores values of registered types with header, containing schema version of type.
+	// On decoding values of previous versions are upgraded with registered upgrade functions,
+	// values without header (stored before version was registered) have version 0
+	VersionedSerializer struct {
+		Serializer Serializer
+		// UnknownFields unknown JSON fields handling
+		UnknownFields UnknownFields
+		// RewriteUpgraded allows state to rewrite upgraded values on FlushRewrites
+		RewriteUpgraded bool
+
+		decoder  Serializer
+		versions map[string]uint32
+		upgrades map[string]map[uint32]UpgradeFunc
+	}
+
+	VersionedSerializerOpt func(*VersionedSerializer)
+)
+
+const (
+	// VersionHeader first byte of versioned value, followed by uvarint version. Not valid as first byte
+	// of binary proto (field number 0), JSON or compressed value
+	VersionHeader byte = 0x03
+)
+
+const (
+	// UnknownFieldsReject unknown JSON fields cause decoding error
+	UnknownFieldsReject UnknownFields = iota
+	// UnknownFieldsDiscard unknown JSON fields are ignored on decoding and discarded on rewrite
+	UnknownFieldsDiscard
+	// UnknownFieldsPreserve unknown JSON fields are ignored on decoding and preserved on rewrite
+	UnknownFieldsPreserve
+)
+
+// NewVersionedSerializer creates versioned serializer, values are serialized with serializer
+func NewVersionedSerializer(serializer Serializer, opts ...VersionedSerializerOpt) *VersionedSerializer {
+	vs := &VersionedSerializer{
+		Serializer: serializer,
+		versions:   make(map[string]uint32),
+		upgrades:   make(map[string]map[uint32]UpgradeFunc),
+	}
+
+	for _, o := range opts {
+		o(vs)
+	}
+
+	vs.decoder = vs.Serializer
+	if g, ok := vs.Serializer.(*GenericSerializer); ok && vs.UnknownFields != UnknownFieldsReject {
+		discarding := *g
+		discarding.DiscardUnknown = true
+		vs.decoder = &discarding
+	}
+	return vs
+}
+
+// WithVersion sets current schema version of type, values of type are stored with version header
+func WithVersion(target interface{}, version uint32) VersionedSerializerOpt {
+	return func(vs *VersionedSerializer) {
+		vs.versions[TypeName(target)] = version
+	}
+}
+
+// WithUpgrade registers upgrade function, converting value of type from version to next version.
+// Versions without upgrade function (for example, only new fields added) are upgraded as is
+func WithUpgrade(target interface{}, fromVersion uint32, upgrade UpgradeFunc) VersionedSerializerOpt {
+	return func(vs *VersionedSerializer) {
+		typeName := TypeName(target)
+		if _, ok := vs.upgrades[typeName]; !ok {
+			vs.upgrades[typeName] = make(map[uint32]UpgradeFunc)
+		}
+		vs.upgrades[typeName][fromVersion] = upgrade
+	}
+}
+
+// WithUnknownFields sets unknown JSON fields handling
+func WithUnknownFields(unknownFields UnknownFields) VersionedSerializerOpt {
+	return func(vs *VersionedSerializer) {
+		vs.UnknownFields = unknownFields
+	}
+}
+
+// WithRewriteUpgraded allows state to rewrite values, upgraded on Get, on FlushRewrites
+func WithRewriteUpgraded() VersionedSerializerOpt {
+	return func(vs *VersionedSerializer) {
+		vs.RewriteUpgraded = true
+	}
+}
+
+// TypeName returns name of type for version registry, proto full name for proto messages
+func TypeName(target interface{}) string {
+	if msg, ok := target.(proto.Message); ok {
+		return string(proto.MessageName(msg))
+	}
+	return reflect.TypeOf(target).String()
+}
+
+func (vs *VersionedSerializer) ToBytesFrom(entry interface{}) ([]byte, error) {
+	bb, err := vs.Serializer.ToBytesFrom(entry)
+	if err != nil || entry == nil {
+		return bb, err
+	}
+
+	version, ok := vs.versions[TypeName(entry)]
+	if !ok {
+		return bb, nil
+	}
+	return WithVersionHeader(bb, version), nil
+}
+
+func (vs *VersionedSerializer) FromBytesTo(serialized []byte, target interface{}) (interface{}, error) {
+	bb, _, err := vs.upgrade(serialized, target)
+	if err != nil {
+		return nil, err
+	}
+	return vs.decoder.FromBytesTo(bb, target)
+}
+
+// Rewrite returns value, converted to current version of target type. With UnknownFieldsPreserve
+// upgraded value is returned as is, otherwise value is decoded and serialized again
+func (vs *VersionedSerializer) Rewrite(serialized []byte, target interface{}) ([]byte, bool, error) {
+	if !vs.RewriteUpgraded {
+		return nil, false, nil
+	}
+
+	bb, upgraded, err := vs.upgrade(serialized, target)
+	if err != nil || !upgraded {
+		return nil, false, err
+	}
+
+	if vs.UnknownFields == UnknownFieldsPreserve {
+		return WithVersionHeader(bb, vs.versions[TypeName(target)]), true, nil
+	}
+
+	value, err := vs.decoder.FromBytesTo(bb, target)
+	if err != nil {
+		return nil, false, err
+	}
+	rewritten, err := vs.ToBytesFrom(value)
+	if err != nil {
+		return nil, false, err
+	}
+	return rewritten, true, nil
+}
+
+// upgrade returns value without version header, upgraded to current version of target type.
+// Values of types without version are serialized without header, so they are returned as is
+func (vs *VersionedSerializer) upgrade(serialized []byte, target interface{}) ([]byte, bool, error) {
+	if target == nil {
+		return serialized, false, nil
+	}
+
+	typeName := TypeName(target)
+	current, ok := vs.versions[typeName]
+	if !ok {
+		return serialized, false, nil
+	}
+
+	bb, version, err := SplitVersionHeader(serialized)
+	if err != nil || version == current {
+		return bb, false, err
+	}
+
+	if version > current {
+		return nil, false, fmt.Errorf(`%w: type=%s, version=%d, current=%d`,
+			ErrVersionNotSupported, typeName, version, current)
+	}
+
+	for ; version < current; version++ {
+		if upgrade, ok := vs.upgrades[typeName][version]; ok {
+			if bb, err = upgrade(bb); err != nil {
+				return nil, false, fmt.Errorf(`upgrade type=%s from version=%d: %w`, typeName, version, err)
+			}
+		}
+	}
+	return bb, true, nil
+}
+
+// WithVersionHeader returns value with version header
+func WithVersionHeader(bb []byte, version uint32) []byte {
+	header := make([]byte, 1+binary.MaxVarintLen32)
+	header[0] = VersionHeader
+	n := binary.PutUvarint(header[1:], uint64(version))
+	return append(header[:1+n], bb...)
+}
+
+// SplitVersionHeader returns value without version header and version, value without header has version 0
+func SplitVersionHeader(serialized []byte) ([]byte, uint32, error) {
+	if len(serialized) == 0 || serialized[0] != VersionHeader {
+		return serialized, 0, nil
+	}
+
+	version, n := binary.Uvarint(serialized[1:])
+	if n <= 0 || version > uint64(^uint32(0)) {
+		return nil, 0, ErrInvalidVersionHeader
+	}
+	return serialized[1+n:], uint32(version), nil
+}
+
+// UpgradeJSON creates upgrade function for JSON serialized values, for example for renaming fields
+func UpgradeJSON(upgrade func(fields map[string]interface{}) error) UpgradeFunc {
+	return func(serialized []byte) ([]byte, error) {
+		fields := make(map[string]interface{})
+		dec := json.NewDecoder(bytes.NewReader(serialized))
+		dec.UseNumber()
+		if err := dec.Decode(&fields); err != nil {
+			return nil, err
+		}
+		if err := upgrade(fields); err != nil {
+			return nil, err
+		}
+		return json.Marshal(fields)
+	}
+}
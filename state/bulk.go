@@ -0,0 +1,199 @@
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+type (
+	// BulkResult result of bulk operation with single entry
+	BulkResult struct {
+		// Pos position of entry in batch
+		Pos int
+		// Key state key of entry
+		Key Key
+		Err error
+	}
+
+	// BulkResults results of bulk operation, ordered as entries in batch
+	BulkResults []*BulkResult
+
+	// BulkOp bulk operation: all entries are validated first,
+	// if all entries are valid - operation is applied to entries in key order
+	BulkOp struct {
+		// Key returns state key for entry
+		Key func(entry interface{}) (Key, error)
+		// Check validates entry before applying operation, for example against current state
+		Check func(entry interface{}, key Key) error
+		// Prepare is called once after all entries are validated, before applying operation, can be nil
+		Prepare func() error
+		// Apply applies operation to entry
+		Apply func(entry interface{}) error
+	}
+)
+
+// Run validates entries and applies operation to all entries if all entries are valid
+func (op *BulkOp) Run(entries []interface{}) (BulkResults, error) {
+	results := make(BulkResults, len(entries))
+	keys := make(map[string]int)
+
+	for pos, entry := range entries {
+		results[pos] = &BulkResult{Pos: pos}
+		key, err := op.Key(entry)
+		if err != nil {
+			results[pos].Err = err
+			continue
+		}
+		results[pos].Key = key
+
+		if err = checkKeyParts(key); err != nil {
+			results[pos].Err = err
+			continue
+		}
+
+		keyStr := bulkKeyString(key)
+		if prev, ok := keys[keyStr]; ok {
+			results[pos].Err = fmt.Errorf(`%w: %s, position=%d`, ErrBulkKeyDuplicated, key, prev)
+			continue
+		}
+		keys[keyStr] = pos
+
+		if op.Check != nil {
+			results[pos].Err = op.Check(entry, key)
+		}
+	}
+
+	if err := results.Err(); err != nil {
+		return results, err
+	}
+
+	if op.Prepare != nil {
+		if err := op.Prepare(); err != nil {
+			return results, err
+		}
+	}
+
+	// deterministic write order
+	ordered := make(BulkResults, len(results))
+	copy(ordered, results)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return bulkKeyString(ordered[i].Key) < bulkKeyString(ordered[j].Key)
+	})
+
+	for _, res := range ordered {
+		if res.Err = op.Apply(entries[res.Pos]); res.Err != nil {
+			return results, fmt.Errorf(`bulk entry position=%d, key=%s: %w`, res.Pos, res.Key, res.Err)
+		}
+	}
+
+	return results, nil
+}
+
+// Err returns error if one or more entries in batch have error
+func (br BulkResults) Err() error {
+	var errs []string
+	for _, res := range br {
+		if res.Err != nil {
+			errs = append(errs, fmt.Sprintf(`position=%d: %s`, res.Pos, res.Err))
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf(`%w: %s`, ErrBulkValidationFailed, strings.Join(errs, `; `))
+}
+
+// Keys returns keys of entries in batch
+func (br BulkResults) Keys() []Key {
+	keys := make([]Key, len(br))
+	for i, res := range br {
+		keys[i] = res.Key
+	}
+	return keys
+}
+
+func checkKeyParts(key Key) error {
+	if len(key) == 0 {
+		return ErrKeyPartsLength
+	}
+	for _, part := range key {
+		if part == `` {
+			return fmt.Errorf(`%w: %s`, ErrKeyPartEmpty, key)
+		}
+	}
+	return nil
+}
+
+func bulkKeyString(key Key) string {
+	return strings.Join(key, "\000")
+}
+
+// PutMany validates all entries (keys, serialization, duplicates in batch),
+// then puts entries to state in key order
+func (s *Impl) PutMany(entries ...interface{}) (BulkResults, error) {
+	s.logger.Debug(`state PUT MANY`, zap.Int(`count`, len(entries)))
+	op := &BulkOp{
+		Key: s.bulkKey,
+		Check: func(entry interface{}, key Key) error {
+			_, err := s.serializer.ToBytesFrom(entry)
+			return err
+		},
+		Apply: func(entry interface{}) error {
+			return s.Put(entry)
+		},
+	}
+
+	return op.Run(entries)
+}
+
+// InsertMany validates all entries (keys, serialization, duplicates in batch and existence in state),
+// then inserts entries to state in key order
+func (s *Impl) InsertMany(entries ...interface{}) (BulkResults, error) {
+	s.logger.Debug(`state INSERT MANY`, zap.Int(`count`, len(entries)))
+	op := &BulkOp{
+		Key: s.bulkKey,
+		Check: func(entry interface{}, key Key) error {
+			if exists, err := s.Exists(entry); err != nil {
+				return err
+			} else if exists {
+				return fmt.Errorf(`%w: %s`, ErrKeyAlreadyExists, key)
+			}
+			_, err := s.serializer.ToBytesFrom(entry)
+			return err
+		},
+		Apply: func(entry interface{}) error {
+			return s.Put(entry)
+		},
+	}
+
+	return op.Run(entries)
+}
+
+// DeleteMany validates all entries (keys, duplicates in batch and existence in state),
+// then deletes entries from state in key order
+func (s *Impl) DeleteMany(entries ...interface{}) (BulkResults, error) {
+	s.logger.Debug(`state DELETE MANY`, zap.Int(`count`, len(entries)))
+	op := &BulkOp{
+		Key: s.bulkKey,
+		Check: func(entry interface{}, key Key) error {
+			if exists, err := s.Exists(entry); err != nil {
+				return err
+			} else if !exists {
+				return fmt.Errorf(`%w: %s`, ErrKeyNotFound, key)
+			}
+			return nil
+		},
+		Apply: s.Delete,
+	}
+
+	return op.Run(entries)
+}
+
+func (s *Impl) bulkKey(entry interface{}) (Key, error) {
+	return NormalizeKey(s.stub, entry)
+}
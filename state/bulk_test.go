@@ -0,0 +1,66 @@
+package state_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/cckit/state"
+	"github.com/hyperledger-labs/cckit/state/testdata"
+	"github.com/hyperledger-labs/cckit/state/testdata/schema"
+	testcc "github.com/hyperledger-labs/cckit/testing"
+	expectcc "github.com/hyperledger-labs/cckit/testing/expect"
+)
+
+var _ = Describe(`State bulk operations`, func() {
+
+	var (
+		bulkCC *testcc.MockStub
+
+		books = []*schema.Book{&testdata.Books[2], &testdata.Books[0], &testdata.Books[1]}
+	)
+
+	It("Allow to insert many entries", func() {
+		bulkCC = testcc.NewMockStub(`books_bulk`, testdata.NewBooksCC())
+
+		keys := expectcc.PayloadIs(bulkCC.Invoke(`bookInsertMany`, &schema.BookList{Items: books[:2]}),
+			&[]state.Key{}).([]state.Key)
+
+		// keys are returned in batch order
+		Expect(keys).To(Equal([]state.Key{
+			{schema.BookEntity, books[0].Id}, {schema.BookEntity, books[1].Id}}))
+
+		list := expectcc.PayloadIs(bulkCC.Invoke(`bookList`), &[]schema.Book{}).([]schema.Book)
+		Expect(list).To(Equal([]schema.Book{*books[1], *books[0]}))
+	})
+
+	It("Disallow to insert many entries if one entry already exists", func() {
+		expectcc.ResponseError(bulkCC.Invoke(`bookInsertMany`, &schema.BookList{Items: books}),
+			state.ErrKeyAlreadyExists)
+
+		// nothing is written
+		expectcc.ResponseError(bulkCC.Invoke(`bookGet`, books[2].Id), state.ErrKeyNotFound)
+	})
+
+	It("Disallow to insert many entries with duplicated or empty keys", func() {
+		expectcc.ResponseError(bulkCC.Invoke(`bookInsertMany`,
+			&schema.BookList{Items: []*schema.Book{books[2], books[2]}}), state.ErrBulkKeyDuplicated)
+
+		expectcc.ResponseError(bulkCC.Invoke(`bookInsertMany`,
+			&schema.BookList{Items: []*schema.Book{{Title: `without id`}}}), state.ErrKeyPartEmpty)
+	})
+
+	It("Disallow to delete many entries if one entry not exists", func() {
+		expectcc.ResponseError(bulkCC.Invoke(`bookDeleteMany`, &schema.BookList{Items: books}),
+			state.ErrKeyNotFound)
+
+		list := expectcc.PayloadIs(bulkCC.Invoke(`bookList`), &[]schema.Book{}).([]schema.Book)
+		Expect(list).To(HaveLen(2))
+	})
+
+	It("Allow to delete many entries", func() {
+		expectcc.ResponseOk(bulkCC.Invoke(`bookDeleteMany`, &schema.BookList{Items: books[:2]}))
+
+		list := expectcc.PayloadIs(bulkCC.Invoke(`bookList`), &[]schema.Book{}).([]schema.Book)
+		Expect(list).To(HaveLen(0))
+	})
+})
@@ -0,0 +1,298 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"go.uber.org/zap"
+)
+
+const (
+	// CounterNamespace namespace for counter keys
+	CounterNamespace = `_counter`
+	// CounterTotalKey key part for compacted counter value
+	CounterTotalKey = `_total`
+	// CounterDeltaKey key part for counter deltas namespace
+	CounterDeltaKey = `_delta`
+
+	// SequenceNamespace namespace for sequence keys
+	SequenceNamespace = `_seq`
+)
+
+type (
+	// Counter conflict-free counter. Each tx writes its own delta key under counter prefix,
+	// so concurrent increments don't produce MVCC read conflicts. Counter value is aggregated on read.
+	// Fabric doesn't return own writes within tx, so delta accumulated in tx is cached in state
+	// and shared by all counter instances with same key
+	Counter struct {
+		state State
+		key   Key
+	}
+
+	// Sequence generator of sequential numbers and human-readable ids.
+	// Sequence value is stored in one key, so concurrent txs using same sequence will conflict.
+	// Current value is cached in state and shared by all sequence instances with same key in tx
+	Sequence struct {
+		state  State
+		key    Key
+		format string
+	}
+
+	// sequenceValue current sequence value in tx
+	sequenceValue struct {
+		loaded bool
+		value  uint64
+	}
+
+	// SequenceOpt sequence option
+	SequenceOpt func(*Sequence)
+)
+
+// NewCounter creates counter with key
+func NewCounter(s State, key Key) *Counter {
+	return &Counter{
+		state: s,
+		key:   key,
+	}
+}
+
+// CounterKey returns prefix for all counter keys
+func CounterKey(key Key) Key {
+	return append(Key{CounterNamespace}, key...)
+}
+
+// Add adds delta to counter, delta is written to tx specific key
+func (c *Counter) Add(delta int64) error {
+	txDelta, err := c.txDelta()
+	if err != nil {
+		return err
+	}
+	*txDelta += delta
+	deltaKey := c.txDeltaKey()
+
+	c.state.Logger().Debug(`counter ADD`,
+		zap.String(`key`, c.key.String()), zap.Int64(`delta`, delta), zap.Int64(`txDelta`, *txDelta))
+
+	// zero tx delta is not stored
+	if *txDelta == 0 {
+		return c.state.Delete(deltaKey)
+	}
+	return c.state.Put(deltaKey, strconv.FormatInt(*txDelta, 10))
+}
+
+// Inc increments counter
+func (c *Counter) Inc() error {
+	return c.Add(1)
+}
+
+// Dec decrements counter
+func (c *Counter) Dec() error {
+	return c.Add(-1)
+}
+
+// Value returns counter value: compacted total, deltas from previous txs and delta from current tx
+func (c *Counter) Value() (int64, error) {
+	total, err := c.total()
+	if err != nil {
+		return 0, err
+	}
+
+	deltas, err := c.deltas()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, d := range deltas {
+		total += d
+	}
+
+	txDelta, err := c.txDelta()
+	if err != nil {
+		return 0, err
+	}
+
+	return total + *txDelta, nil
+}
+
+// Compact aggregates deltas from previous txs to counter total and deletes them.
+// Compaction reads all counter deltas, so it conflicts with concurrent increments
+func (c *Counter) Compact() error {
+	total, err := c.total()
+	if err != nil {
+		return err
+	}
+
+	deltas, err := c.deltas()
+	if err != nil {
+		return err
+	}
+
+	for key, d := range deltas {
+		total += d
+		if err = c.state.Delete(key); err != nil {
+			return err
+		}
+	}
+
+	c.state.Logger().Debug(`counter COMPACT`,
+		zap.String(`key`, c.key.String()), zap.Int(`deltas`, len(deltas)), zap.Int64(`total`, total))
+	return c.state.Put(CounterKey(c.key).Append(Key{CounterTotalKey}), strconv.FormatInt(total, 10))
+}
+
+// txDelta returns delta accumulated in current tx
+func (c *Counter) txDelta() (*int64, error) {
+	delta, err := c.state.TxCached(CounterKey(c.key).Append(Key{CounterDeltaKey}), func() interface{} {
+		return new(int64)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return delta.(*int64), nil
+}
+
+// txDeltaKey returns key of current tx delta
+func (c *Counter) txDeltaKey() Key {
+	return CounterKey(c.key).Append(Key{CounterDeltaKey, c.state.TxID()})
+}
+
+func (c *Counter) total() (int64, error) {
+	total, err := c.state.Get(CounterKey(c.key).Append(Key{CounterTotalKey}), ``)
+	if err != nil {
+		if errors.Is(err, ErrKeyNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return strconv.ParseInt(total.(string), 10, 64)
+}
+
+// deltas returns deltas from previous txs. Delta key of current tx is skipped: state with cache
+// returns own writes, but current tx delta is taken from tx cache
+func (c *Counter) deltas() (map[string]int64, error) {
+	keys, err := c.state.Keys(CounterKey(c.key).Append(Key{CounterDeltaKey}))
+	if err != nil {
+		return nil, err
+	}
+
+	txDeltaKey := c.txDeltaKey()
+	txDeltaKeyStr, err := shim.CreateCompositeKey(txDeltaKey[0], txDeltaKey[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	deltas := make(map[string]int64, len(keys))
+	for _, key := range keys {
+		if key == txDeltaKeyStr {
+			continue
+		}
+		d, err := c.state.Get(key, ``)
+		if err != nil {
+			return nil, err
+		}
+
+		if deltas[key], err = strconv.ParseInt(d.(string), 10, 64); err != nil {
+			return nil, fmt.Errorf(`counter delta key=%s: %w`, key, err)
+		}
+	}
+
+	return deltas, nil
+}
+
+// SequenceFormat sets format for human-readable id, for example `INV-%06d`
+func SequenceFormat(format string) SequenceOpt {
+	return func(s *Sequence) {
+		s.format = format
+	}
+}
+
+// NewSequence creates sequence with key
+func NewSequence(s State, key Key, opts ...SequenceOpt) *Sequence {
+	seq := &Sequence{
+		state:  s,
+		key:    key,
+		format: `%d`,
+	}
+
+	for _, opt := range opts {
+		opt(seq)
+	}
+
+	return seq
+}
+
+// SequenceKey returns state key of sequence
+func SequenceKey(key Key) Key {
+	return append(Key{SequenceNamespace}, key...)
+}
+
+// Current returns current sequence value
+func (s *Sequence) Current() (uint64, error) {
+	current, err := s.txValue()
+	if err != nil {
+		return 0, err
+	}
+	return current.value, nil
+}
+
+// Next increments sequence and returns next value
+func (s *Sequence) Next() (uint64, error) {
+	current, err := s.txValue()
+	if err != nil {
+		return 0, err
+	}
+
+	next := current.value + 1
+	if err = s.state.Put(SequenceKey(s.key), strconv.FormatUint(next, 10)); err != nil {
+		return 0, err
+	}
+
+	current.value = next
+	return next, nil
+}
+
+// txValue returns sequence value in current tx, value is read from state once per tx
+func (s *Sequence) txValue() (*sequenceValue, error) {
+	cached, err := s.state.TxCached(SequenceKey(s.key), func() interface{} {
+		return &sequenceValue{}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	current := cached.(*sequenceValue)
+	if current.loaded {
+		return current, nil
+	}
+
+	val, err := s.state.Get(SequenceKey(s.key), ``)
+	if err != nil && !errors.Is(err, ErrKeyNotFound) {
+		return nil, err
+	}
+
+	if err == nil {
+		if current.value, err = strconv.ParseUint(val.(string), 10, 64); err != nil {
+			return nil, fmt.Errorf(`sequence key=%s: %w`, s.key, err)
+		}
+	}
+
+	current.loaded = true
+	return current, nil
+}
+
+// NextID increments sequence and returns next value, formatted as human-readable id
+func (s *Sequence) NextID() (string, error) {
+	next, err := s.Next()
+	if err != nil {
+		return ``, err
+	}
+
+	return s.FormatID(next), nil
+}
+
+// FormatID formats sequence value as human-readable id
+func (s *Sequence) FormatID(value uint64) string {
+	return fmt.Sprintf(s.format, value)
+}
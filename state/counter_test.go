@@ -0,0 +1,98 @@
+package state_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/cckit/router"
+	"github.com/hyperledger-labs/cckit/state"
+	testcc "github.com/hyperledger-labs/cckit/testing"
+)
+
+var _ = Describe(`State counter and sequence`, func() {
+
+	var (
+		cc  *testcc.TxHandler
+		ctx router.Context
+
+		counterKey  = state.Key{`orders`, `total`}
+		sequenceKey = state.Key{`invoice`}
+	)
+
+	It("Allow to increment counter in different txs without reading same key", func() {
+		cc, ctx = testcc.NewTxHandler(`counter`)
+		for _, delta := range []int64{1, 2, 3} {
+			cc.Tx(func() {
+				Expect(state.NewCounter(ctx.State(), counterKey).Add(delta)).To(Succeed())
+			})
+		}
+
+		cc.Tx(func() {
+			// each tx writes own delta key
+			keys, err := ctx.State().Keys(state.CounterKey(counterKey).Append(state.Key{state.CounterDeltaKey}))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(HaveLen(3))
+
+			Expect(state.NewCounter(ctx.State(), counterKey).Value()).To(BeNumerically("==", 6))
+		})
+	})
+
+	It("Allow to add to counter multiple times in one tx", func() {
+		cc.Tx(func() {
+			counter := state.NewCounter(ctx.State(), counterKey)
+			Expect(counter.Inc()).To(Succeed())
+			Expect(counter.Inc()).To(Succeed())
+			Expect(counter.Dec()).To(Succeed())
+			Expect(counter.Value()).To(BeNumerically("==", 7))
+		})
+
+		cc.Tx(func() {
+			Expect(state.NewCounter(ctx.State(), counterKey).Value()).To(BeNumerically("==", 7))
+		})
+	})
+
+	It("Allow to compact counter", func() {
+		cc.Tx(func() {
+			Expect(state.NewCounter(ctx.State(), counterKey).Compact()).To(Succeed())
+		})
+
+		cc.Tx(func() {
+			keys, err := ctx.State().Keys(state.CounterKey(counterKey).Append(state.Key{state.CounterDeltaKey}))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(HaveLen(0))
+
+			Expect(state.NewCounter(ctx.State(), counterKey).Value()).To(BeNumerically("==", 7))
+		})
+	})
+
+	It("Allow to get counter value with cached state", func() {
+		cc.Tx(func() {
+			// cached state returns own writes, so tx delta key is listed with deltas of previous txs
+			cached, err := state.WithCache(ctx.State())
+			Expect(err).NotTo(HaveOccurred())
+			counter := state.NewCounter(cached, counterKey)
+			Expect(counter.Add(3)).To(Succeed())
+			Expect(counter.Value()).To(BeNumerically("==", 10))
+			Expect(counter.Compact()).To(Succeed())
+			Expect(counter.Value()).To(BeNumerically("==", 10))
+		})
+
+		cc.Tx(func() {
+			Expect(state.NewCounter(ctx.State(), counterKey).Value()).To(BeNumerically("==", 10))
+		})
+	})
+
+	It("Allow to generate human-readable ids with sequence", func() {
+		cc.Tx(func() {
+			seq := state.NewSequence(ctx.State(), sequenceKey, state.SequenceFormat(`INV-%04d`))
+			Expect(seq.NextID()).To(Equal(`INV-0001`))
+			Expect(seq.NextID()).To(Equal(`INV-0002`))
+		})
+
+		cc.Tx(func() {
+			seq := state.NewSequence(ctx.State(), sequenceKey)
+			Expect(seq.Current()).To(BeNumerically("==", 2))
+			Expect(seq.Next()).To(BeNumerically("==", 3))
+		})
+	})
+})
@@ -28,4 +28,28 @@ var (
 
 	// ErrKeyPartsLength can occurs when trying to create key consisting of zero parts
 	ErrKeyPartsLength = errors.New(`key parts length must be greater than zero`)
+
+	// ErrHistoryBookmarkNotFound can occurs when bookmark for entry history query is not found in entry history
+	ErrHistoryBookmarkNotFound = errors.New(`history bookmark not found`)
+
+	// ErrHistoryValueNotProto can occurs when converting history entries with non proto values to default list proto
+	ErrHistoryValueNotProto = errors.New(`history entry value is not proto`)
+
+	// ErrHistoryListItemsNotDefined can occurs when converting history entries to custom list proto without Items
+	ErrHistoryListItemsNotDefined = errors.New(`history list items not defined`)
+
+	// ErrKeyPartEmpty can occurs when entry key in batch contains empty part, for example not filled required attr
+	ErrKeyPartEmpty = errors.New(`key part is empty`)
+
+	// ErrBulkKeyDuplicated can occurs when batch contains entries with same key
+	ErrBulkKeyDuplicated = errors.New(`key duplicated in batch`)
+
+	// ErrBulkValidationFailed can occurs when one or more entries in batch are not valid, nothing is written to state
+	ErrBulkValidationFailed = errors.New(`bulk validation failed`)
+
+	// ErrKeyPrefixMismatch can occurs when trying to remove prefix from key without this prefix
+	ErrKeyPrefixMismatch = errors.New(`key prefix mismatch`)
+
+	// ErrStateCacheNotSupported can occurs when state wrapper does not allow to get underlying state implementation
+	ErrStateCacheNotSupported = errors.New(`state cache not supported`)
 )
@@ -56,22 +56,32 @@ func (e *EventImpl) UseNameTransformer(nt StringTransformer) Event {
 }
 
 func (e *EventImpl) Set(entry interface{}, values ...interface{}) error {
-	name, value, err := e.ArgNameValue(entry, values)
+	name, bb, err := e.NameBytes(entry, values)
 	if err != nil {
 		return err
 	}
 
+	return e.stub.SetEvent(name, bb)
+}
+
+// NameBytes returns transformed event name and event payload, converted to bytes
+func (e *EventImpl) NameBytes(entry interface{}, values []interface{}) (string, []byte, error) {
+	name, value, err := e.ArgNameValue(entry, values)
+	if err != nil {
+		return ``, nil, err
+	}
+
 	nameStr, err := e.nameTransformer(name)
 	if err != nil {
-		return err
+		return ``, nil, err
 	}
 
 	bb, err := e.toBytesConverter.ToBytesFrom(value)
 	if err != nil {
-		return err
+		return ``, nil, err
 	}
 
-	return e.stub.SetEvent(nameStr, bb)
+	return nameStr, bb, nil
 }
 
 func (e *EventImpl) ArgNameValue(arg interface{}, values []interface{}) (name string, value interface{}, err error) {
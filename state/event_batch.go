@@ -0,0 +1,82 @@
+package state
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+
+	"github.com/hyperledger-labs/cckit/serialize"
+	"github.com/hyperledger-labs/cckit/state/schema"
+)
+
+const (
+	// EventBatchName name of event with batch of events, set in transaction
+	EventBatchName = `_batch`
+)
+
+type (
+	// EventBatch accumulates events, set in transaction, and emits them as single event,
+	// because Fabric keeps only last event of transaction
+	EventBatch struct {
+		*EventImpl
+		events []*schema.BatchedEvent
+	}
+)
+
+// NewEventBatch creates wrapper on shim.ChaincodeStubInterface for accumulating events
+func NewEventBatch(stub shim.ChaincodeStubInterface) *EventBatch {
+	return &EventBatch{
+		EventImpl: NewEvent(stub),
+	}
+}
+
+func (b *EventBatch) UseToBytesConverter(toBytesConverter serialize.ToBytesConverter) Event {
+	b.EventImpl.UseToBytesConverter(toBytesConverter)
+	return b
+}
+
+func (b *EventBatch) UseNameTransformer(nt StringTransformer) Event {
+	b.EventImpl.UseNameTransformer(nt)
+	return b
+}
+
+// Set adds event to batch, event is emitted with Emit
+func (b *EventBatch) Set(entry interface{}, values ...interface{}) error {
+	name, bb, err := b.NameBytes(entry, values)
+	if err != nil {
+		return err
+	}
+
+	b.events = append(b.events, &schema.BatchedEvent{Name: name, Payload: bb})
+	return nil
+}
+
+// Events returns accumulated events
+func (b *EventBatch) Events() []*schema.BatchedEvent {
+	return b.events
+}
+
+// Emit sets accumulated events as transaction event. Single event is set as is,
+// multiple events are set as EventBatch with EventBatchName
+func (b *EventBatch) Emit() error {
+	switch len(b.events) {
+	case 0:
+		return nil
+	case 1:
+		return b.stub.SetEvent(b.events[0].Name, b.events[0].Payload)
+	}
+
+	bb, err := proto.Marshal(&schema.EventBatch{Events: b.events})
+	if err != nil {
+		return err
+	}
+	return b.stub.SetEvent(EventBatchName, bb)
+}
+
+// UnpackEventBatch returns events from batch event payload
+func UnpackEventBatch(payload []byte) ([]*schema.BatchedEvent, error) {
+	batch := &schema.EventBatch{}
+	if err := proto.Unmarshal(payload, batch); err != nil {
+		return nil, err
+	}
+	return batch.Events, nil
+}
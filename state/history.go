@@ -0,0 +1,334 @@
+package state
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"go.uber.org/zap"
+
+	"github.com/hyperledger-labs/cckit/state/schema"
+)
+
+type (
+	// HistoryEntry struct containing history information of a single entry
+	HistoryEntry struct {
+		TxId string `json:"txId"`
+		// Timestamp tx timestamp, seconds
+		Timestamp int64 `json:"timestamp"`
+		// Time tx timestamp with nanoseconds
+		Time      time.Time   `json:"time"`
+		IsDeleted bool        `json:"isDeleted"`
+		Value     interface{} `json:"value"`
+	}
+
+	// HistoryEntryList list of history entries
+	HistoryEntryList []HistoryEntry
+
+	// HistoryQuery filter for entry history records
+	HistoryQuery struct {
+		// From returns records with tx timestamp equal or after From, if not zero
+		From time.Time
+		// To returns records with tx timestamp before To, if not zero
+		To time.Time
+		// Reverse returns records in reverse ledger order.
+		// Starting in Fabric v2.0 ledger returns records from newest to oldest
+		Reverse bool
+	}
+
+	// HistoryOpt history query option
+	HistoryOpt func(*HistoryQuery)
+)
+
+// HistoryFrom sets lower bound (inclusive) of history records tx timestamp
+func HistoryFrom(from time.Time) HistoryOpt {
+	return func(q *HistoryQuery) {
+		q.From = from
+	}
+}
+
+// HistoryTo sets upper bound (exclusive) of history records tx timestamp
+func HistoryTo(to time.Time) HistoryOpt {
+	return func(q *HistoryQuery) {
+		q.To = to
+	}
+}
+
+// HistoryReverse sets reverse order of history records
+func HistoryReverse() HistoryOpt {
+	return func(q *HistoryQuery) {
+		q.Reverse = true
+	}
+}
+
+// Match checks history record tx timestamp is in query time range
+func (q *HistoryQuery) Match(t time.Time) bool {
+	if !q.From.IsZero() && t.Before(q.From) {
+		return false
+	}
+
+	if !q.To.IsZero() && !t.Before(q.To) {
+		return false
+	}
+
+	return true
+}
+
+// GetHistory by key from state, trying to convert to target interface
+func (s *Impl) GetHistory(entry interface{}, target interface{}) (HistoryEntryList, error) {
+	list, _, err := s.GetHistoryPaginated(entry, target, 0, ``)
+	return list, err
+}
+
+// GetHistoryPaginated by key from state, filtered by history query options, trying to convert to target interface.
+// Page size 0 means no limit, bookmark is tx id of last entry from previous page
+func (s *Impl) GetHistoryPaginated(
+	entry interface{}, target interface{}, pageSize int32, bookmark string, opts ...HistoryOpt) (
+	HistoryEntryList, *pb.QueryResponseMetadata, error) {
+	query := &HistoryQuery{}
+	for _, opt := range opts {
+		opt(query)
+	}
+
+	mods, err := s.historyModifications(entry, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// skip entries till bookmark
+	if bookmark != `` {
+		pos := -1
+		for i, mod := range mods {
+			if mod.TxId == bookmark {
+				pos = i
+				break
+			}
+		}
+		if pos < 0 {
+			return nil, nil, fmt.Errorf(`history bookmark=%s: %w`, bookmark, ErrHistoryBookmarkNotFound)
+		}
+		mods = mods[pos+1:]
+	}
+
+	md := &pb.QueryResponseMetadata{}
+	if pageSize > 0 && len(mods) > int(pageSize) {
+		mods = mods[:pageSize]
+		md.Bookmark = mods[pageSize-1].TxId
+	}
+
+	results := HistoryEntryList{}
+	for _, mod := range mods {
+		historyEntry, err := s.historyEntry(mod, target)
+		if err != nil {
+			return nil, nil, err
+		}
+		results = append(results, *historyEntry)
+	}
+	md.FetchedRecordsCount = int32(len(results))
+
+	return results, md, nil
+}
+
+// GetAt returns entry value as of time, based on entry history and tx timestamps
+func (s *Impl) GetAt(entry interface{}, at time.Time, target ...interface{}) (interface{}, error) {
+	mods, err := s.historyModifications(entry, &HistoryQuery{})
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		found     *queryresult.KeyModification
+		foundTime time.Time
+	)
+	for _, mod := range mods {
+		t, err := ptypes.Timestamp(mod.GetTimestamp())
+		if err != nil {
+			return nil, err
+		}
+		// ledger returns records from newest to oldest, first record wins for same timestamp
+		if t.After(at) || (found != nil && !t.After(foundTime)) {
+			continue
+		}
+		found, foundTime = mod, t
+	}
+
+	if found == nil || found.IsDelete {
+		key, _ := s.Key(entry)
+		return nil, fmt.Errorf(`get state at %s with key=%s: %w`, at, key.Origin, ErrKeyNotFound)
+	}
+
+	var t interface{}
+	if len(target) > 0 {
+		t = target[0]
+	}
+
+	return s.serializer.FromBytesTo(found.Value, t)
+}
+
+func (s *Impl) historyModifications(entry interface{}, query *HistoryQuery) ([]*queryresult.KeyModification, error) {
+	key, err := s.Key(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Debug(`state HISTORY`, zap.String(`key`, key.String))
+	iter, err := s.stub.GetHistoryForKey(key.String)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = iter.Close() }()
+
+	var mods []*queryresult.KeyModification
+	for iter.HasNext() {
+		mod, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		t, err := ptypes.Timestamp(mod.GetTimestamp())
+		if err != nil {
+			return nil, err
+		}
+
+		if query.Match(t) {
+			mods = append(mods, mod)
+		}
+	}
+
+	if query.Reverse {
+		for i, j := 0, len(mods)-1; i < j; i, j = i+1, j-1 {
+			mods[i], mods[j] = mods[j], mods[i]
+		}
+	}
+
+	return mods, nil
+}
+
+func (s *Impl) historyEntry(mod *queryresult.KeyModification, target interface{}) (*HistoryEntry, error) {
+	historyEntry := &HistoryEntry{
+		TxId:      mod.GetTxId(),
+		Timestamp: mod.GetTimestamp().GetSeconds(),
+		Time:      time.Unix(mod.GetTimestamp().GetSeconds(), int64(mod.GetTimestamp().GetNanos())),
+		IsDeleted: mod.GetIsDelete(),
+	}
+
+	// deleted entry has no value
+	if mod.GetIsDelete() {
+		return historyEntry, nil
+	}
+
+	value, err := s.serializer.FromBytesTo(mod.GetValue(), target)
+	if err != nil {
+		return nil, err
+	}
+	historyEntry.Value = value
+
+	return historyEntry, nil
+}
+
+// Proto converts history entries to list proto.
+// Custom list proto must have `Items` attr, items must have `TxId`, `Timestamp`, `IsDeleted`, `Value` attrs.
+// If custom list not defined, default list proto (schema.HistoryEntryList with Any values) is used
+func (hl HistoryEntryList) Proto(list ...proto.Message) (proto.Message, error) {
+	if len(list) > 0 && list[0] != nil {
+		return hl.customProto(list[0])
+	}
+
+	defList := &schema.HistoryEntryList{}
+	for _, e := range hl {
+		ts, err := ptypes.TimestampProto(e.Time)
+		if err != nil {
+			return nil, err
+		}
+
+		item := &schema.HistoryEntry{
+			TxId:      e.TxId,
+			Timestamp: ts,
+			IsDeleted: e.IsDeleted,
+		}
+
+		if e.Value != nil {
+			msg, ok := e.Value.(proto.Message)
+			if !ok {
+				return nil, fmt.Errorf(`history entry value %T: %w`, e.Value, ErrHistoryValueNotProto)
+			}
+			if item.Value, err = ptypes.MarshalAny(msg); err != nil {
+				return nil, err
+			}
+		}
+		defList.Items = append(defList.Items, item)
+	}
+
+	return defList, nil
+}
+
+func (hl HistoryEntryList) customProto(list proto.Message) (proto.Message, error) {
+	customList := proto.Clone(list)
+
+	items := reflect.ValueOf(customList).Elem().FieldByName(`Items`)
+	if err := checkHistoryListItems(items); err != nil {
+		return nil, fmt.Errorf(`history list %T: %w`, list, err)
+	}
+
+	for _, e := range hl {
+		ts, err := ptypes.TimestampProto(e.Time)
+		if err != nil {
+			return nil, err
+		}
+
+		// items is slice of pointers to history entry proto
+		item := reflect.New(items.Type().Elem().Elem())
+		itemElem := item.Elem()
+		itemElem.FieldByName(`TxId`).SetString(e.TxId)
+		itemElem.FieldByName(`Timestamp`).Set(reflect.ValueOf(ts))
+		itemElem.FieldByName(`IsDeleted`).SetBool(e.IsDeleted)
+		if e.Value != nil {
+			value, valueField := reflect.ValueOf(e.Value), itemElem.FieldByName(`Value`)
+			if !value.Type().AssignableTo(valueField.Type()) {
+				return nil, fmt.Errorf(`history list %T: value %T not assignable to %s: %w`,
+					list, e.Value, valueField.Type(), ErrHistoryListItemsNotDefined)
+			}
+			valueField.Set(value)
+		}
+
+		items.Set(reflect.Append(items, item))
+	}
+
+	return customList, nil
+}
+
+// checkHistoryListItems checks list items are slice of pointers to struct
+// with `TxId`, `Timestamp`, `IsDeleted` and `Value` fields of expected types
+func checkHistoryListItems(items reflect.Value) error {
+	if !items.IsValid() {
+		return ErrHistoryListItemsNotDefined
+	}
+
+	if items.Kind() != reflect.Slice || items.Type().Elem().Kind() != reflect.Ptr ||
+		items.Type().Elem().Elem().Kind() != reflect.Struct {
+		return fmt.Errorf(`items %s is not slice of pointers to struct: %w`, items.Type(), ErrHistoryListItemsNotDefined)
+	}
+
+	item := items.Type().Elem().Elem()
+	fields := map[string]reflect.Type{
+		`TxId`:      reflect.TypeOf(``),
+		`Timestamp`: reflect.TypeOf(ptypes.TimestampNow()),
+		`IsDeleted`: reflect.TypeOf(false),
+		`Value`:     nil,
+	}
+	for name, fieldType := range fields {
+		field, ok := item.FieldByName(name)
+		if !ok {
+			return fmt.Errorf(`item %s has no field %s: %w`, item, name, ErrHistoryListItemsNotDefined)
+		}
+		if fieldType != nil && field.Type != fieldType {
+			return fmt.Errorf(`item %s field %s is not %s: %w`, item, name, fieldType, ErrHistoryListItemsNotDefined)
+		}
+	}
+	return nil
+}
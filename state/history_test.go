@@ -0,0 +1,144 @@
+package state_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/cckit/state"
+	stateschema "github.com/hyperledger-labs/cckit/state/schema"
+	"github.com/hyperledger-labs/cckit/state/testdata"
+	"github.com/hyperledger-labs/cckit/state/testdata/schema"
+	testcc "github.com/hyperledger-labs/cckit/testing"
+	expectcc "github.com/hyperledger-labs/cckit/testing/expect"
+)
+
+var _ = Describe(`State history`, func() {
+
+	var (
+		historyCC *testcc.MockStub
+
+		book        = testdata.Books[0]
+		bookUpdated = schema.Book{Id: book.Id, Title: `updated title`, Chapters: book.Chapters}
+
+		t0 = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		t1 = t0.Add(time.Hour)
+		t2 = t1.Add(time.Hour)
+	)
+
+	at := func(t time.Time) *testcc.MockStub {
+		ts, _ := ptypes.TimestampProto(t)
+		return historyCC.At(ts)
+	}
+
+	history := func(req *schema.BookHistoryRequest) schema.BookHistory {
+		req.Id = book.Id
+		return expectcc.PayloadIs(
+			historyCC.Invoke(`bookHistory`, req), &schema.BookHistory{}).(schema.BookHistory)
+	}
+
+	It("Allow to modify entry at different times", func() {
+		historyCC = testcc.NewMockStub(`books_history`, testdata.NewBooksCC())
+		expectcc.ResponseOk(at(t0).Invoke(`bookInsert`, &book))
+		expectcc.ResponseOk(at(t1).Invoke(`bookUpsert`, &bookUpdated))
+		expectcc.ResponseOk(at(t2).Invoke(`bookDelete`, book.Id))
+	})
+
+	It("Allow to get entry history from newest to oldest", func() {
+		h := history(&schema.BookHistoryRequest{})
+		Expect(h.Items).To(HaveLen(3))
+		Expect(h.Next).To(BeEmpty())
+
+		Expect(h.Items[0].IsDeleted).To(BeTrue())
+		Expect(h.Items[0].Value).To(BeNil())
+		Expect(h.Items[0].Time.Equal(t2)).To(BeTrue())
+
+		Expect(*h.Items[1].Value).To(Equal(bookUpdated))
+		Expect(h.Items[1].Time.Equal(t1)).To(BeTrue())
+
+		Expect(*h.Items[2].Value).To(Equal(book))
+		Expect(h.Items[2].Time.Equal(t0)).To(BeTrue())
+	})
+
+	It("Allow to get entry history in reverse order", func() {
+		h := history(&schema.BookHistoryRequest{Reverse: true})
+		Expect(h.Items).To(HaveLen(3))
+		Expect(*h.Items[0].Value).To(Equal(book))
+		Expect(h.Items[2].IsDeleted).To(BeTrue())
+	})
+
+	It("Allow to filter entry history by time range", func() {
+		h := history(&schema.BookHistoryRequest{From: t1})
+		Expect(h.Items).To(HaveLen(2))
+		Expect(h.Items[0].IsDeleted).To(BeTrue())
+		Expect(*h.Items[1].Value).To(Equal(bookUpdated))
+
+		h = history(&schema.BookHistoryRequest{To: t1})
+		Expect(h.Items).To(HaveLen(1))
+		Expect(*h.Items[0].Value).To(Equal(book))
+
+		h = history(&schema.BookHistoryRequest{From: t0, To: t2})
+		Expect(h.Items).To(HaveLen(2))
+	})
+
+	It("Allow to get entry history with pagination", func() {
+		page1 := history(&schema.BookHistoryRequest{PageSize: 2})
+		Expect(page1.Items).To(HaveLen(2))
+		Expect(page1.Next).To(Equal(page1.Items[1].TxId))
+
+		page2 := history(&schema.BookHistoryRequest{PageSize: 2, Bookmark: page1.Next})
+		Expect(page2.Items).To(HaveLen(1))
+		Expect(page2.Next).To(BeEmpty())
+		Expect(*page2.Items[0].Value).To(Equal(book))
+	})
+
+	It("Disallow to get entry history with unknown bookmark", func() {
+		expectcc.ResponseError(
+			historyCC.Invoke(`bookHistory`, &schema.BookHistoryRequest{Id: book.Id, Bookmark: `unknown`}),
+			state.ErrHistoryBookmarkNotFound)
+	})
+
+	It("Allow to get entry value at time", func() {
+		Expect(expectcc.PayloadIs(historyCC.Invoke(`bookGetAt`, &schema.BookAtRequest{Id: book.Id, At: t0}),
+			&schema.Book{})).To(Equal(book))
+
+		Expect(expectcc.PayloadIs(historyCC.Invoke(`bookGetAt`,
+			&schema.BookAtRequest{Id: book.Id, At: t1.Add(time.Minute)}), &schema.Book{})).To(Equal(bookUpdated))
+	})
+
+	It("Disallow to get entry value before creation or after deletion", func() {
+		expectcc.ResponseError(historyCC.Invoke(`bookGetAt`,
+			&schema.BookAtRequest{Id: book.Id, At: t0.Add(-time.Minute)}), state.ErrKeyNotFound)
+
+		expectcc.ResponseError(historyCC.Invoke(`bookGetAt`,
+			&schema.BookAtRequest{Id: book.Id, At: t2}), state.ErrKeyNotFound)
+	})
+
+	It("Allow to convert entry history to default list proto", func() {
+		_, err := state.HistoryEntryList{{TxId: `tx`, Value: book}}.Proto()
+		Expect(err).To(MatchError(state.ErrHistoryValueNotProto))
+
+		list, err := state.HistoryEntryList{{TxId: `tx`, Time: t0, IsDeleted: true}}.Proto()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list).NotTo(BeNil())
+	})
+
+	It("Disallow to convert entry history to custom list proto with unexpected items", func() {
+		history := state.HistoryEntryList{{TxId: `tx`, Time: t0, Value: book}}
+
+		// no Items field
+		_, err := history.Proto(&stateschema.KeyRef{})
+		Expect(errors.Is(err, state.ErrHistoryListItemsNotDefined)).To(BeTrue())
+
+		// items without history entry fields
+		_, err = history.Proto(&stateschema.List{})
+		Expect(errors.Is(err, state.ErrHistoryListItemsNotDefined)).To(BeTrue())
+
+		// value type mismatch
+		_, err = history.Proto(&stateschema.HistoryEntryList{})
+		Expect(errors.Is(err, state.ErrHistoryListItemsNotDefined)).To(BeTrue())
+	})
+})
@@ -1,6 +1,9 @@
 package state
 
 import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"go.uber.org/zap"
 
@@ -13,7 +16,9 @@ type State interface {
 	Settable
 	Listable
 	ListablePaginated
+	ListableRange
 	Deletable
+	Bulkable
 	Historyable
 	Privateable
 
@@ -24,8 +29,28 @@ type State interface {
 	// namespace can be part of key (string or []string) or entity with defined mapping
 	Keys(namespace interface{}) ([]string, error)
 
+	// KeysFrom returns at most limit keys with namespace, starting from state key startKey,
+	// and state key of first entry after returned keys, empty if there are no more entries
+	KeysFrom(namespace interface{}, startKey string, limit int32) ([]string, string, error)
+
 	Logger() *zap.Logger
 
+	// TxID returns id of transaction, state is used in
+	TxID() string
+
+	// Transient returns transient map of transaction, state is used in
+	Transient() (map[string][]byte, error)
+
+	// TxTimestamp returns timestamp of transaction, state is used in
+	TxTimestamp() (*timestamp.Timestamp, error)
+
+	// TxCached returns value, cached in current tx with key, value is created with init if not cached yet.
+	// Fabric doesn't return own writes within tx, so tx values (counter deltas, sequence values) are cached in state
+	TxCached(key interface{}, init func() interface{}) (interface{}, error)
+
+	// FlushRewrites puts values, upgraded by serializer on Get in current tx, in current format
+	FlushRewrites() error
+
 	// Clone state for next changing transformers, state access methods etc
 	Clone() State
 }
@@ -43,6 +68,9 @@ type (
 		// Exists returns entry existence in state
 		// entry can be Key (string or []string) or type implementing Keyer interface
 		Exists(entry interface{}) (bool, error)
+		// GetHash returns hash of entry value as stored in state, same as hash of private state entry
+		// entry can be Key (string or []string) or type implementing Keyer interface
+		GetHash(entry interface{}) ([]byte, error)
 	}
 
 	Settable interface {
@@ -72,16 +100,47 @@ type (
 			interface{}, *pb.QueryResponseMetadata, error)
 	}
 
+	ListableRange interface {
+		// ListRange returns slice of target type with keys in range [from, to] inside namespace,
+		// with pagination if pageSize is greater than zero.
+		// namespace can be part of key (string or []string) or entity with defined mapping,
+		// from and to are key parts after namespace, empty from or to means range is not bounded.
+		// Bookmark is key of first entry of next page
+		ListRange(namespace interface{}, from, to Key, pageSize int32, bookmark string, target ...interface{}) (
+			interface{}, *pb.QueryResponseMetadata, error)
+	}
+
 	Deletable interface {
 		// Delete returns result of deleting entry from state
 		// entry can be Key (string or []string) or type implementing Keyer interface
 		Delete(entry interface{}) (err error)
 	}
 
+	Bulkable interface {
+		// PutMany validates all entries, then puts entries to state in key order, returns per entry results
+		// entries must be type implementing Keyer interface, value is entry itself
+		PutMany(entries ...interface{}) (BulkResults, error)
+		// InsertMany validates all entries, including existence in state, then inserts entries in key order.
+		// If any entry is not valid, nothing is written to state
+		InsertMany(entries ...interface{}) (BulkResults, error)
+		// DeleteMany validates all entries exist in state, then deletes entries in key order
+		DeleteMany(entries ...interface{}) (BulkResults, error)
+	}
+
 	Historyable interface {
 		// GetHistory returns slice of history records for entry, with values converted to target type
 		// entry can be Key (string or []string) or type implementing Keyer interface
 		GetHistory(entry interface{}, target interface{}) (HistoryEntryList, error)
+
+		// GetHistoryPaginated returns slice of history records for entry, filtered with history query options,
+		// with values converted to target type with pagination
+		// entry can be Key (string or []string) or type implementing Keyer interface
+		GetHistoryPaginated(entry interface{}, target interface{}, pageSize int32, bookmark string, opts ...HistoryOpt) (
+			HistoryEntryList, *pb.QueryResponseMetadata, error)
+
+		// GetAt returns entry value as of time, converted to target type
+		// entry can be Key (string or []string) or type implementing Keyer interface
+		GetAt(entry interface{}, at time.Time, target ...interface{}) (interface{}, error)
 	}
 
 	Privateable interface {
@@ -108,6 +167,14 @@ type (
 		// if false, used public state for iterate over keys and GetPrivateData for each key
 		ListPrivate(collection string, usePrivateDataIterator bool, namespace interface{}, target ...interface{}) (interface{}, error)
 
+		// KeysPrivate returns keys of private state entries
+		// namespace can be part of key (string or []string) or entity with defined mapping
+		KeysPrivate(collection string, namespace interface{}) ([]string, error)
+
+		// KeysFromPrivate returns at most limit keys of private state entries, starting from state key startKey,
+		// and state key of first entry after returned keys, empty if there are no more entries
+		KeysFromPrivate(collection string, namespace interface{}, startKey string, limit int32) ([]string, string, error)
+
 		// DeletePrivate returns result of deleting entry from private state
 		// entry can be Key (string or []string) or type implementing Keyer interface
 		DeletePrivate(collection string, entry interface{}) error
@@ -115,6 +182,17 @@ type (
 		// ExistsPrivate returns entry existence in private state
 		// entry can be Key (string or []string) or type implementing Keyer interface
 		ExistsPrivate(collection string, entry interface{}) (bool, error)
+
+		// GetPrivateHash returns hash of private state entry value, available for parties
+		// without access to collection data
+		// entry can be Key (string or []string) or type implementing Keyer interface
+		GetPrivateHash(collection string, entry interface{}) ([]byte, error)
+
+		// VerifyPrivate returns true if hash of presented value matches hash of private state entry
+		// entry can be Key (string or []string) or type implementing Keyer interface
+		// if entry is implements Keyer interface, and it's struct or type implementing
+		// ToByter interface value can be omitted
+		VerifyPrivate(collection string, entry interface{}, value ...interface{}) (bool, error)
 	}
 
 	WithSerializer interface {
@@ -125,5 +203,9 @@ type (
 	WithKeyTransformer interface {
 		UseKeyTransformer(KeyTransformer)
 		UseKeyReverseTransformer(KeyTransformer)
+		// KeyTransformer returns current key transformer, so it can be composed with another one
+		KeyTransformer() KeyTransformer
+		// KeyReverseTransformer returns current key reverse transformer
+		KeyReverseTransformer() KeyTransformer
 	}
 )
@@ -17,12 +17,76 @@ var (
 
 	ErrMappingUniqKeyExists = errors.New(`mapping uniq key exists`)
 
+	// ErrKeyValueOverflow occurs when field value cannot be encoded to key part with order preserving encoding
+	ErrKeyValueOverflow = errors.New(`key value overflow`)
+
+	// ErrKeyDecodingNotSupported occurs when key part cannot be decoded to field value
+	ErrKeyDecodingNotSupported = errors.New(`key decoding not supported`)
+
+	// ErrKeyNotInNamespace occurs when decoded key doesn't belong to mapping namespace
+	ErrKeyNotInNamespace = errors.New(`key not in mapping namespace`)
+
 	ErrFieldNotExists         = errors.New(`field is not exists`)
 	ErrPrimaryKeyerNotDefined = errors.New(`primary keyer is not defined`)
 
 	// ErrIndexAlreadyExists occurs when when trying to add index to mapping with existent name
 	ErrIndexAlreadyExists = errors.New(`index already exists`)
 
+	// ErrIndexNotExists occurs when index is not defined in mapping
+	ErrIndexNotExists = errors.New(`index not exists`)
+
+	// ErrRangeBoundaryNotSupported occurs when range boundary cannot be converted to index key
+	ErrRangeBoundaryNotSupported = errors.New(`range boundary not supported`)
+
+	// ErrCounterNotFound occurs when trying to get counter not defined in mapping
+	ErrCounterNotFound = errors.New(`counter not found`)
+
+	// ErrFieldTypeNotSupportedForSequence occurs when sequence field is not string
+	ErrFieldTypeNotSupportedForSequence = errors.New(`field type not supported for sequence`)
+
+	// ErrRefTargetNotFound occurs when entry referenced by mapped entry fields not exists in state
+	ErrRefTargetNotFound = errors.New(`referenced entry not found`)
+
+	// ErrEntryReferenced occurs when trying to delete entry, referenced by entries with restrict on delete behavior
+	ErrEntryReferenced = errors.New(`entry is referenced`)
+
+	// ErrCollectionPaginationNotSupported occurs when trying to list entries, stored in private data collection,
+	// with pagination or by range
+	ErrCollectionPaginationNotSupported = errors.New(`pagination not supported for private data collection`)
+
+	// ErrCollectionHistoryNotSupported occurs when trying to get history of entry, stored in private data collection
+	ErrCollectionHistoryNotSupported = errors.New(`history not supported for private data collection`)
+
+	// ErrFieldEncryptionNotSupported occurs when field type or field usage in keys doesn't allow field encryption
+	ErrFieldEncryptionNotSupported = errors.New(`field encryption not supported`)
+
+	// ErrFieldKeyNotDefined occurs when field encryption key is not provided
+	ErrFieldKeyNotDefined = errors.New(`field encryption key not defined`)
+
+	// ErrFieldCiphertextInvalid occurs when encrypted field value cannot be decrypted
+	ErrFieldCiphertextInvalid = errors.New(`field ciphertext invalid`)
+
+	// ErrFieldMaskInvalid occurs when field mask for patch is empty or contains paths not defined in entry schema
+	ErrFieldMaskInvalid = errors.New(`field mask invalid`)
+
+	// ErrIndexCheckPageSizeInvalid occurs when batch size of index check or rebuild is not positive
+	ErrIndexCheckPageSizeInvalid = errors.New(`index check page size invalid`)
+
+	// ErrIndexCheckBookmarkInvalid occurs when bookmark of index check or rebuild is not produced by previous batch
+	ErrIndexCheckBookmarkInvalid = errors.New(`index check bookmark invalid`)
+
+	// ErrIndexHandlersAccessRequired occurs when index check and rebuild handlers are added without access middleware
+	ErrIndexHandlersAccessRequired = errors.New(`index handlers access middleware required`)
+
+	// ErrExpiryFieldNotSupported occurs when expiry field is not timestamp
+	ErrExpiryFieldNotSupported = errors.New(`expiry field not supported`)
+
+	// ErrExpiryNotDefined occurs when trying to purge expired entries of mapping without expiry
+	ErrExpiryNotDefined = errors.New(`expiry not defined`)
+
+	// ErrChangeEventNotDefined occurs when mapping has change events, but event is not set for mapped state
+	ErrChangeEventNotDefined = errors.New(`change event not defined`)
+
 	// ErrIndexReferenceNotFound occurs when trying to find entry by index
 	ErrIndexReferenceNotFound = errors.New(`index reference not found`)
 )
@@ -0,0 +1,76 @@
+package mapping_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/cckit/router"
+	"github.com/hyperledger-labs/cckit/state"
+	"github.com/hyperledger-labs/cckit/state/mapping"
+	"github.com/hyperledger-labs/cckit/state/mapping/testdata/schema"
+	testcc "github.com/hyperledger-labs/cckit/testing"
+	expectcc "github.com/hyperledger-labs/cckit/testing/expect"
+)
+
+var _ = Describe(`Event batch`, func() {
+
+	var (
+		create = &schema.CreateEntityWithCompositeId{IdFirstPart: `A`, Name: `name`}
+		update = &schema.UpdateEntityWithCompositeId{IdFirstPart: `A`, Name: `new name`}
+
+		newChaincode = func() *router.Chaincode {
+			r := router.New(`event_batch`)
+			r.Use(mapping.BatchEvents())
+			r.Use(mapping.MapEvents(mapping.EventMappings{}.
+				Add(&schema.CreateEntityWithCompositeId{}).
+				Add(&schema.UpdateEntityWithCompositeId{})))
+
+			r.Invoke(`one`, func(c router.Context) (interface{}, error) {
+				return nil, c.Event().Set(create)
+			}).
+				Invoke(`two`, func(c router.Context) (interface{}, error) {
+					if err := c.Event().Set(create); err != nil {
+						return nil, err
+					}
+					return nil, c.Event().Set(update)
+				}).
+				Invoke(`failed`, func(c router.Context) (interface{}, error) {
+					if err := c.Event().Set(create); err != nil {
+						return nil, err
+					}
+					return nil, errors.New(`failed`)
+				})
+
+			return router.NewChaincode(r)
+		}
+
+		cc = testcc.NewMockStub(`event_batch`, newChaincode())
+	)
+
+	It("Allow to emit single event as is", func() {
+		expectcc.ResponseOk(cc.Invoke(`one`))
+		expectcc.EventStringerEqual(cc.ChaincodeEvent, `CreateEntityWithCompositeId`, create, cc.Serializer)
+	})
+
+	It("Allow to emit multiple events as batch", func() {
+		expectcc.ResponseOk(cc.Invoke(`two`))
+		Expect(cc.ChaincodeEvent.EventName).To(Equal(state.EventBatchName))
+
+		events, err := state.UnpackEventBatch(cc.ChaincodeEvent.Payload)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(events).To(HaveLen(2))
+		Expect(events[0].Name).To(Equal(`CreateEntityWithCompositeId`))
+		Expect(events[1].Name).To(Equal(`UpdateEntityWithCompositeId`))
+
+		e, err := cc.Serializer.FromBytesTo(events[1].Payload, &schema.UpdateEntityWithCompositeId{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(e.(*schema.UpdateEntityWithCompositeId).Name).To(Equal(update.Name))
+	})
+
+	It("Disallow to emit events of failed handler", func() {
+		expectcc.ResponseError(cc.Invoke(`failed`), `failed`)
+		Expect(cc.ChaincodeEvent).To(BeNil())
+	})
+})
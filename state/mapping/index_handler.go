@@ -0,0 +1,44 @@
+package mapping
+
+import (
+	"github.com/hyperledger-labs/cckit/router"
+	"github.com/hyperledger-labs/cckit/router/param"
+	"github.com/hyperledger-labs/cckit/state/schema"
+)
+
+const (
+	QueryIndexCheckFunc    = `IndexCheck`
+	InvokeIndexRebuildFunc = `IndexRebuild`
+)
+
+// IndexCheckParam parameter for index check and rebuild
+var IndexCheckParam = param.Proto(`request`, &schema.IndexCheckRequest{})
+
+// AddIndexHandlers adds index check and rebuild handlers for mapped entries to router.
+// Rebuild changes key refs of all mapped entries, so access middleware, for example owner.Only, is required,
+// more middleware can be added
+func AddIndexHandlers(r *router.Group, prefix string, mappings StateMappings,
+	access router.MiddlewareFunc, middleware ...router.MiddlewareFunc) {
+	if access == nil {
+		panic(ErrIndexHandlersAccessRequired)
+	}
+	middleware = append([]router.MiddlewareFunc{IndexCheckParam, access}, middleware...)
+
+	// check indexes of mapped entries in batch
+	r.Query(prefix+QueryIndexCheckFunc, indexCheckHandler(mappings, false), middleware...)
+
+	// rebuild indexes of mapped entries in batch
+	r.Invoke(prefix+InvokeIndexRebuildFunc, indexCheckHandler(mappings, true), middleware...)
+}
+
+func indexCheckHandler(mappings StateMappings, rebuild bool) router.HandlerFunc {
+	return func(c router.Context) (interface{}, error) {
+		req := c.Param(`request`).(*schema.IndexCheckRequest)
+		m, err := mappings.getBySchemaName(req.Schema)
+		if err != nil {
+			return nil, err
+		}
+
+		return WrapState(c.State(), mappings).checkIndexes(m.Schema(), req.PageSize, req.Bookmark, rebuild)
+	}
+}
@@ -0,0 +1,379 @@
+package mapping
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/hyperledger-labs/cckit/serialize"
+	"github.com/hyperledger-labs/cckit/state"
+	"github.com/hyperledger-labs/cckit/state/schema"
+)
+
+const (
+	// EntryMapped entry of mapped schema
+	EntryMapped EntryKind = `entry`
+	// EntryKeyRef uniq or non-unique index key ref (`_idx` namespace)
+	EntryKeyRef EntryKind = `key_ref`
+	// EntryRef back reference (`_ref` namespace)
+	EntryRef EntryKind = `ref`
+	// EntryCounter counter delta, compacted counter total or sequence value
+	EntryCounter EntryKind = `counter`
+	// EntryPrivateHash private data hash, key of which is not known to decoder
+	EntryPrivateHash EntryKind = `private_hash`
+	// EntryUnknown entry, not matched with mappings
+	EntryUnknown EntryKind = `unknown`
+)
+
+const (
+	compositeKeyNamespace = "\x00"
+	compositeKeyDelimiter = "\x00"
+)
+
+type (
+	// EntryKind kind of decoded ledger entry
+	EntryKind string
+
+	// KeyDecoder decodes ledger keys and values, for example from block write sets, with state mappings.
+	// It can be used in block explorers and CLIs, without chaincode stub
+	KeyDecoder struct {
+		mappings              StateMappings
+		serializer            serialize.FromBytesConverter
+		keyReverseTransformer state.KeyTransformer
+		fieldKey              []byte
+		// knownKeys ledger keys by hex encoded sha256 hash, for resolving private data hashes
+		knownKeys map[string]string
+	}
+
+	KeyDecoderOpt func(*KeyDecoder)
+
+	// DecodedEntry ledger entry, decoded with mappings
+	DecodedEntry struct {
+		// Key state key, after key reverse transformation
+		Key  state.Key
+		Kind EntryKind
+		// Schema name (proto full name) of mapped entry, indexed entry for key refs or counted entry for counters
+		Schema string
+		// Index name for key refs
+		Index string
+		// PrimaryKey instance of schema with primary key fields filled, if primary key is based on fields
+		PrimaryKey interface{}
+		// Value decoded value: mapped schema instance, *schema.KeyRef for refs, string for counters
+		Value interface{}
+		// Raw value, as stored in ledger
+		Raw []byte
+		// Encrypted is true if mapped entry has encrypted fields, not decrypted with decoder key
+		Encrypted bool
+		// Collection, KeyHash and ValueHash are set for private data hashes
+		Collection string
+		KeyHash    []byte
+		ValueHash  []byte
+		// DecodeErr error of key transformation or value decoding, entry is returned with other attributes decoded
+		DecodeErr error
+	}
+
+	decodedEntryJSON struct {
+		Key        []string        `json:"key"`
+		Kind       EntryKind       `json:"kind"`
+		Schema     string          `json:"schema,omitempty"`
+		Index      string          `json:"index,omitempty"`
+		PrimaryKey json.RawMessage `json:"primary_key,omitempty"`
+		Value      json.RawMessage `json:"value,omitempty"`
+		Raw        []byte          `json:"raw,omitempty"`
+		Encrypted  bool            `json:"encrypted,omitempty"`
+		Collection string          `json:"collection,omitempty"`
+		KeyHash    string          `json:"key_hash,omitempty"`
+		ValueHash  string          `json:"value_hash,omitempty"`
+		Error      string          `json:"error,omitempty"`
+	}
+)
+
+// NewKeyDecoder creates decoder of ledger entries with mappings, values are decoded
+// with serialize.DefaultSerializer by default
+func NewKeyDecoder(mappings StateMappings, opts ...KeyDecoderOpt) *KeyDecoder {
+	d := &KeyDecoder{
+		mappings:   mappings,
+		serializer: serialize.DefaultSerializer,
+		knownKeys:  make(map[string]string),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// WithDecodeSerializer sets converter for decoding values, for example encryption serializer
+func WithDecodeSerializer(serializer serialize.FromBytesConverter) KeyDecoderOpt {
+	return func(d *KeyDecoder) {
+		d.serializer = serializer
+	}
+}
+
+// WithDecodeKeyReverseTransformer sets transformer of ledger keys to state keys, for example key decryptor
+// or prefix remover, as used with state UseKeyReverseTransformer
+func WithDecodeKeyReverseTransformer(kt state.KeyTransformer) KeyDecoderOpt {
+	return func(d *KeyDecoder) {
+		d.keyReverseTransformer = kt
+	}
+}
+
+// WithDecodeFieldKey sets key for decrypting encrypted fields of mapped entries
+func WithDecodeFieldKey(key []byte) KeyDecoderOpt {
+	return func(d *KeyDecoder) {
+		d.fieldKey = key
+	}
+}
+
+// WithDecodeKnownKeys sets ledger keys for resolving private data hashes, keys are state keys as stored in ledger
+func WithDecodeKnownKeys(keys ...state.Key) KeyDecoderOpt {
+	return func(d *KeyDecoder) {
+		for _, key := range keys {
+			ledgerKey := ledgerKeyString(key)
+			hash := sha256.Sum256([]byte(ledgerKey))
+			d.knownKeys[hex.EncodeToString(hash[:])] = ledgerKey
+		}
+	}
+}
+
+// Decode decodes ledger key (composite or simple) and value. Error is returned only for invalid composite key,
+// key transformation and value decoding errors are set to DecodeErr of returned entry
+func (d *KeyDecoder) Decode(key string, value []byte) (*DecodedEntry, error) {
+	stateKey, err := splitLedgerKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &DecodedEntry{Key: stateKey, Kind: EntryUnknown, Raw: value}
+	if d.keyReverseTransformer != nil {
+		if entry.Key, err = d.keyReverseTransformer(stateKey); err != nil {
+			entry.Key = stateKey
+			entry.DecodeErr = fmt.Errorf(`reverse transform key: %w`, err)
+			return entry, nil
+		}
+	}
+
+	switch entry.Key[0] {
+	case KeyRefNamespace:
+		d.decodeKeyRef(entry, EntryKeyRef)
+	case RefNamespace:
+		d.decodeKeyRef(entry, EntryRef)
+	case state.CounterNamespace, state.SequenceNamespace:
+		d.decodeCounter(entry)
+	default:
+		d.decodeMapped(entry)
+	}
+	return entry, nil
+}
+
+// DecodePrivateHash decodes private data hashes of key and value from block write set. If key is set
+// with WithDecodeKnownKeys, key is decoded, otherwise entry has EntryPrivateHash kind
+func (d *KeyDecoder) DecodePrivateHash(collection string, keyHash, valueHash []byte) (*DecodedEntry, error) {
+	privateHashEntry := &DecodedEntry{Kind: EntryPrivateHash}
+	ledgerKey, ok := d.knownKeys[hex.EncodeToString(keyHash)]
+	if ok {
+		entry, err := d.Decode(ledgerKey, nil)
+		if err != nil {
+			return nil, err
+		}
+		privateHashEntry = entry
+	}
+
+	privateHashEntry.Collection = collection
+	privateHashEntry.KeyHash = keyHash
+	privateHashEntry.ValueHash = valueHash
+	return privateHashEntry, nil
+}
+
+// mapper returns mapper with the longest namespace, matching key prefix
+func (d *KeyDecoder) mapper(key state.Key) *StateMapping {
+	var found *StateMapping
+	for _, m := range d.mappings {
+		if m.keyerForSchema != nil || len(m.namespace) == 0 || len(m.namespace) > len(key) {
+			continue
+		}
+		if !keyHasPrefix(key, m.namespace) {
+			continue
+		}
+		if found == nil || len(m.namespace) > len(found.namespace) {
+			found = m
+		}
+	}
+	return found
+}
+
+// mapperByRefSchema returns mapper by schema name of key ref
+func (d *KeyDecoder) mapperByRefSchema(refSchema string) *StateMapping {
+	for _, m := range d.mappings {
+		if m.keyerForSchema == nil && strings.Join(m.DefaultNamespace(), `-`) == refSchema {
+			return m
+		}
+	}
+	return nil
+}
+
+func (d *KeyDecoder) decodeMapped(entry *DecodedEntry) {
+	m := d.mapper(entry.Key)
+	if m == nil {
+		return
+	}
+
+	entry.Kind = EntryMapped
+	entry.Schema = serialize.TypeName(m.schema)
+	if len(m.primaryKeyAttrs) > 0 {
+		if pkey, err := m.DecodeKey(entry.Key); err == nil {
+			entry.PrimaryKey = pkey
+		}
+	}
+
+	if len(entry.Raw) == 0 {
+		return
+	}
+	value, err := d.serializer.FromBytesTo(entry.Raw, m.schema)
+	if err != nil {
+		entry.DecodeErr = fmt.Errorf(`decode value: %w`, err)
+		return
+	}
+	entry.Value = value
+
+	if len(m.fieldEncryption) == 0 {
+		return
+	}
+	if d.fieldKey == nil {
+		entry.Encrypted = true
+		return
+	}
+	// fields are decrypted on copy, so value with encrypted fields is kept, if decryption fails
+	decrypted := proto.Clone(value.(proto.Message))
+	if err = decryptFields(m, decrypted, func(*StateFieldEncryption) ([]byte, error) {
+		return d.fieldKey, nil
+	}); err != nil {
+		entry.Encrypted = true
+		entry.DecodeErr = err
+		return
+	}
+	entry.Value = decrypted
+}
+
+func (d *KeyDecoder) decodeKeyRef(entry *DecodedEntry, kind EntryKind) {
+	entry.Kind = kind
+	// key ref key is <`_idx`, {SchemaName}, {idxName}, {RefKey}...>,
+	// back reference key is <`_ref`, {RefKey}..., {SchemaName}, {idxName}, {PKey}...>, so schema is taken from value
+	if kind == EntryKeyRef && len(entry.Key) > 2 {
+		entry.Index = entry.Key[2]
+		if m := d.mapperByRefSchema(entry.Key[1]); m != nil {
+			entry.Schema = serialize.TypeName(m.schema)
+		}
+	}
+
+	if len(entry.Raw) == 0 {
+		return
+	}
+	value, err := d.serializer.FromBytesTo(entry.Raw, &schema.KeyRef{})
+	if err != nil {
+		entry.DecodeErr = fmt.Errorf(`decode key ref: %w`, err)
+		return
+	}
+	keyRef := value.(*schema.KeyRef)
+	entry.Value = keyRef
+	entry.Index = keyRef.Idx
+	if m := d.mapperByRefSchema(keyRef.Schema); m != nil {
+		entry.Schema = serialize.TypeName(m.schema)
+	}
+}
+
+func (d *KeyDecoder) decodeCounter(entry *DecodedEntry) {
+	entry.Kind = EntryCounter
+	if m := d.mapper(entry.Key[1:]); m != nil {
+		entry.Schema = serialize.TypeName(m.schema)
+	}
+
+	if len(entry.Raw) == 0 {
+		return
+	}
+	value, err := d.serializer.FromBytesTo(entry.Raw, serialize.TypeString)
+	if err != nil {
+		entry.DecodeErr = fmt.Errorf(`decode counter: %w`, err)
+		return
+	}
+	entry.Value = value
+}
+
+// MarshalJSON renders decoded entry for explorers and CLIs, proto values are rendered with proto field names
+func (e *DecodedEntry) MarshalJSON() ([]byte, error) {
+	rendered := &decodedEntryJSON{
+		Key:        e.Key,
+		Kind:       e.Kind,
+		Schema:     e.Schema,
+		Index:      e.Index,
+		Encrypted:  e.Encrypted,
+		Collection: e.Collection,
+		KeyHash:    hex.EncodeToString(e.KeyHash),
+		ValueHash:  hex.EncodeToString(e.ValueHash),
+	}
+
+	var err error
+	if rendered.PrimaryKey, err = renderJSON(e.PrimaryKey); err != nil {
+		return nil, err
+	}
+	if rendered.Value, err = renderJSON(e.Value); err != nil {
+		return nil, err
+	}
+	// raw value is rendered only if value is not decoded
+	if e.Value == nil {
+		rendered.Raw = e.Raw
+	}
+	if e.DecodeErr != nil {
+		rendered.Error = e.DecodeErr.Error()
+	}
+	return json.Marshal(rendered)
+}
+
+func renderJSON(v interface{}) (json.RawMessage, error) {
+	switch val := v.(type) {
+	case nil:
+		return nil, nil
+	case proto.Message:
+		return protojson.MarshalOptions{UseProtoNames: true}.Marshal(proto.MessageV2(val))
+	default:
+		return json.Marshal(val)
+	}
+}
+
+// splitLedgerKey converts composite or simple ledger key to state key
+func splitLedgerKey(key string) (state.Key, error) {
+	if key == `` {
+		return nil, state.ErrKeyPartsLength
+	}
+	if !strings.HasPrefix(key, compositeKeyNamespace) {
+		return state.Key{key}, nil
+	}
+	if !strings.HasSuffix(key, compositeKeyDelimiter) || len(key) < 2 {
+		return nil, fmt.Errorf(`%w: invalid composite key %q`, ErrKeyDecodingNotSupported, key)
+	}
+	return strings.Split(key[1:len(key)-1], compositeKeyDelimiter), nil
+}
+
+// ledgerKeyString returns ledger key of state key, as state does: composite key for key with multiple parts
+func ledgerKeyString(key state.Key) string {
+	if len(key) == 1 {
+		return key[0]
+	}
+	return compositeKeyNamespace + strings.Join(key, compositeKeyDelimiter) + compositeKeyDelimiter
+}
+
+func keyHasPrefix(key, prefix state.Key) bool {
+	if len(key) < len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if key[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
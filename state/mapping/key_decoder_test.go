@@ -0,0 +1,154 @@
+package mapping_test
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"sort"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/cckit/state"
+	"github.com/hyperledger-labs/cckit/state/mapping"
+	"github.com/hyperledger-labs/cckit/state/mapping/testdata/schema"
+	stateschema "github.com/hyperledger-labs/cckit/state/schema"
+	testcc "github.com/hyperledger-labs/cckit/testing"
+)
+
+var _ = Describe(`Key decoder`, func() {
+
+	const transientKey = `ENCODE_KEY`
+
+	var (
+		encKey = []byte(`0123456789abcdef0123456789abcdef`)
+
+		mappings = mapping.StateMappings{}.Add(&schema.EntityWithIndexes{},
+			mapping.PKeyId(),
+			mapping.List(&schema.EntityWithIndexesList{}),
+			mapping.UniqKey(`ExternalId`),
+			mapping.Counter(`total`),
+			mapping.EncryptedFields(mapping.TransientFieldKey(transientKey), `OptionalExternalIds`))
+
+		cc, ctx = testcc.NewTxHandler(`decoder`)
+
+		// ledgerEntries returns ledger keys and values, as in block write sets
+		ledgerEntries = func() ([]string, map[string][]byte) {
+			var keys []string
+			for k := range cc.MockStub.State {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			return keys, cc.MockStub.State
+		}
+
+		decodeAll = func(d *mapping.KeyDecoder) map[mapping.EntryKind][]*mapping.DecodedEntry {
+			keys, values := ledgerEntries()
+			decoded := make(map[mapping.EntryKind][]*mapping.DecodedEntry)
+			for _, k := range keys {
+				entry, err := d.Decode(k, values[k])
+				Expect(err).NotTo(HaveOccurred())
+				decoded[entry.Kind] = append(decoded[entry.Kind], entry)
+			}
+			return decoded
+		}
+	)
+
+	It("Allow to put mapped entries", func() {
+		cc.MockStub.WithTransient(map[string][]byte{transientKey: encKey})
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(s.Insert(&schema.EntityWithIndexes{
+				Id: `aaa`, ExternalId: `aaa_ext`, OptionalExternalIds: []string{`aaa_opt`}, Value: 1})).To(Succeed())
+			Expect(ctx.State().Put(`raw`, []byte(`raw value`))).To(Succeed())
+		})
+	})
+
+	It("Allow to decode mapped entries, key refs and counters", func() {
+		decoded := decodeAll(mapping.NewKeyDecoder(mappings, mapping.WithDecodeFieldKey(encKey)))
+
+		Expect(decoded[mapping.EntryMapped]).To(HaveLen(1))
+		entry := decoded[mapping.EntryMapped][0]
+		Expect(entry.Key).To(Equal(state.Key{`EntityWithIndexes`, `aaa`}))
+		Expect(entry.Schema).To(Equal(`schema.EntityWithIndexes`))
+		Expect(entry.PrimaryKey.(*schema.EntityWithIndexes).Id).To(Equal(`aaa`))
+		Expect(entry.Value.(*schema.EntityWithIndexes).OptionalExternalIds).To(Equal([]string{`aaa_opt`}))
+		Expect(entry.Encrypted).To(BeFalse())
+
+		Expect(decoded[mapping.EntryKeyRef]).To(HaveLen(1))
+		keyRef := decoded[mapping.EntryKeyRef][0]
+		Expect(keyRef.Schema).To(Equal(`schema.EntityWithIndexes`))
+		Expect(keyRef.Index).To(Equal(`ExternalId`))
+		Expect(keyRef.Value.(*stateschema.KeyRef).PKey).To(Equal([]string{`EntityWithIndexes`, `aaa`}))
+
+		Expect(decoded[mapping.EntryCounter]).To(HaveLen(1))
+		Expect(decoded[mapping.EntryCounter][0].Schema).To(Equal(`schema.EntityWithIndexes`))
+		Expect(decoded[mapping.EntryCounter][0].Value).To(Equal(`1`))
+
+		Expect(decoded[mapping.EntryUnknown]).To(HaveLen(1))
+		Expect(decoded[mapping.EntryUnknown][0].Key).To(Equal(state.Key{`raw`}))
+		Expect(decoded[mapping.EntryUnknown][0].Raw).To(Equal([]byte(`raw value`)))
+	})
+
+	It("Allow to decode entries with encrypted fields without key", func() {
+		decoded := decodeAll(mapping.NewKeyDecoder(mappings))
+		entry := decoded[mapping.EntryMapped][0]
+		Expect(entry.Encrypted).To(BeTrue())
+		Expect(entry.DecodeErr).NotTo(HaveOccurred())
+		Expect(entry.Value.(*schema.EntityWithIndexes).OptionalExternalIds).NotTo(Equal([]string{`aaa_opt`}))
+
+		decoded = decodeAll(mapping.NewKeyDecoder(mappings, mapping.WithDecodeFieldKey([]byte(`fedcba9876543210`))))
+		entry = decoded[mapping.EntryMapped][0]
+		Expect(entry.Encrypted).To(BeTrue())
+		Expect(entry.DecodeErr).To(HaveOccurred())
+	})
+
+	It("Allow to decode keys with key reverse transformer", func() {
+		prefix := state.Key{`env`}
+		decoder := mapping.NewKeyDecoder(mappings, mapping.WithDecodeKeyReverseTransformer(state.KeyWithoutPrefix(prefix)))
+
+		entry, err := decoder.Decode("\x00env\x00EntityWithIndexes\x00bbb\x00", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entry.Kind).To(Equal(mapping.EntryMapped))
+		Expect(entry.Key).To(Equal(state.Key{`EntityWithIndexes`, `bbb`}))
+		Expect(entry.Value).To(BeNil())
+
+		_, err = decoder.Decode("\x00EntityWithIndexes\x00bbb", nil)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Allow to decode private data hashes", func() {
+		key := state.Key{`EntityWithIndexes`, `ccc`}
+		keyHash := sha256.Sum256([]byte("\x00EntityWithIndexes\x00ccc\x00"))
+		valueHash := sha256.Sum256([]byte(`value`))
+
+		entry, err := mapping.NewKeyDecoder(mappings).DecodePrivateHash(`coll`, keyHash[:], valueHash[:])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entry.Kind).To(Equal(mapping.EntryPrivateHash))
+		Expect(entry.Collection).To(Equal(`coll`))
+
+		entry, err = mapping.NewKeyDecoder(mappings, mapping.WithDecodeKnownKeys(key)).
+			DecodePrivateHash(`coll`, keyHash[:], valueHash[:])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entry.Kind).To(Equal(mapping.EntryMapped))
+		Expect(entry.Key).To(Equal(key))
+		Expect(entry.ValueHash).To(Equal(valueHash[:]))
+	})
+
+	It("Allow to render decoded entries as JSON", func() {
+		decoded := decodeAll(mapping.NewKeyDecoder(mappings, mapping.WithDecodeFieldKey(encKey)))
+
+		bb, err := json.Marshal(decoded[mapping.EntryMapped][0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(bb)).To(MatchJSON(`{
+			"key": ["EntityWithIndexes", "aaa"],
+			"kind": "entry",
+			"schema": "schema.EntityWithIndexes",
+			"primary_key": {"id": "aaa"},
+			"value": {"id": "aaa", "external_id": "aaa_ext", "optional_external_ids": ["aaa_opt"], "value": 1}
+		}`))
+
+		bb, err = json.Marshal(decoded[mapping.EntryUnknown][0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(bb)).To(MatchJSON(`{"key": ["raw"], "kind": "unknown", "raw": "cmF3IHZhbHVl"}`))
+	})
+})
@@ -0,0 +1,436 @@
+package mapping
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/hyperledger-labs/cckit/serialize"
+	"github.com/hyperledger-labs/cckit/state"
+)
+
+const (
+	// SortableTimestampKeyLayout RFC3339Nano layout with fixed width fractional seconds,
+	// timestamps are encoded in UTC, so lexicographic order of key parts matches time order
+	SortableTimestampKeyLayout = `2006-01-02T15:04:05.000000000Z07:00`
+
+	// sortableIntWidth width of decimal representation of max uint64
+	sortableIntWidth = 20
+)
+
+type (
+	// KeyEncoder encodes field value to state key part and decodes key part back to field value.
+	// Order preserving encoders produce key parts, which lexicographic order matches natural order of values
+	KeyEncoder interface {
+		Encode(v reflect.Value) (string, error)
+		// Decode sets field value v from key part
+		Decode(part string, v reflect.Value) error
+	}
+
+	// KeyParter is implemented by field types with own order preserving key part encoding,
+	// for example token.Decimal
+	KeyParter interface {
+		KeyPart() (string, error)
+		SetKeyPart(part string) error
+	}
+
+	// keyEncoders key encoders of mapping fields, encoder with empty field name is used for all fields
+	keyEncoders map[string]KeyEncoder
+
+	paddedEncoder struct {
+		width int
+	}
+
+	sortableIntEncoder struct{}
+
+	sortableTimestampEncoder struct{}
+
+	bigNumberEncoder struct{}
+
+	orderedEncoder struct{}
+)
+
+var (
+	// KeyEncodingSortableInt sign-aware fixed width encoding for integers, value is shifted by 2^63
+	KeyEncodingSortableInt KeyEncoder = &sortableIntEncoder{}
+
+	// KeyEncodingSortableTimestamp RFC3339Nano-sortable encoding for timestamps
+	KeyEncodingSortableTimestamp KeyEncoder = &sortableTimestampEncoder{}
+
+	// KeyEncodingBigNumber order preserving encoding for *big.Int, *big.Rat with finite decimal representation
+	// and types implementing KeyParter, numbers with different scales are ordered as numbers
+	KeyEncodingBigNumber KeyEncoder = &bigNumberEncoder{}
+
+	// KeyEncodingOrdered chooses order preserving encoding by field type: sign-aware fixed width for integers,
+	// zero-padded for unsigned integers, RFC3339Nano-sortable for timestamps and big number encoding
+	// for big numbers and decimals.
+	// Strings, enums and other types are encoded as is
+	KeyEncodingOrdered KeyEncoder = &orderedEncoder{}
+)
+
+// KeyEncodingPadded zero-padded fixed width encoding for non-negative integers
+func KeyEncodingPadded(width int) KeyEncoder {
+	return &paddedEncoder{width: width}
+}
+
+// For returns key encoder for field, field specific encoder has priority over encoder for all fields
+func (ke keyEncoders) For(field string) KeyEncoder {
+	if enc, ok := ke[field]; ok {
+		return enc
+	}
+	return ke[``]
+}
+
+// encode encodes field value to key part with key encoders
+func (ke keyEncoders) encode(field string, value interface{}) (state.Key, error) {
+	return keyFromValueWith(ke, field, reflect.ValueOf(value))
+}
+
+// withDefault returns copy of key encoders with encoder for fields without field specific encoder
+func (ke keyEncoders) withDefault(encoder KeyEncoder, fields []string) keyEncoders {
+	encoders := make(keyEncoders, len(ke)+len(fields))
+	for field, enc := range ke {
+		encoders[field] = enc
+	}
+	for _, field := range fields {
+		if _, ok := ke[field]; !ok {
+			encoders[field] = encoder
+		}
+	}
+	return encoders
+}
+
+func (e *paddedEncoder) Encode(v reflect.Value) (string, error) {
+	var u uint64
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u = v.Uint()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Int() < 0 {
+			return ``, fmt.Errorf(`%w: negative value %d`, ErrKeyValueOverflow, v.Int())
+		}
+		u = uint64(v.Int())
+	default:
+		return ``, ErrFieldTypeNotSupportedForKeyExtraction
+	}
+
+	part := fmt.Sprintf(`%0*d`, e.width, u)
+	if len(part) > e.width {
+		return ``, fmt.Errorf(`%w: value %d exceeds width %d`, ErrKeyValueOverflow, u, e.width)
+	}
+	return part, nil
+}
+
+func (e *paddedEncoder) Decode(part string, v reflect.Value) error {
+	return setIntValue(part, v)
+}
+
+func (e *sortableIntEncoder) Encode(v reflect.Value) (string, error) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return fmt.Sprintf(`%0*d`, sortableIntWidth, uint64(v.Int())^(1<<63)), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() > math.MaxInt64 {
+			return ``, fmt.Errorf(`%w: value %d exceeds max int64`, ErrKeyValueOverflow, v.Uint())
+		}
+		return fmt.Sprintf(`%0*d`, sortableIntWidth, v.Uint()^(1<<63)), nil
+	default:
+		return ``, ErrFieldTypeNotSupportedForKeyExtraction
+	}
+}
+
+func (e *sortableIntEncoder) Decode(part string, v reflect.Value) error {
+	u, err := strconv.ParseUint(part, 10, 64)
+	if err != nil {
+		return err
+	}
+	return setIntValue(strconv.FormatInt(int64(u^(1<<63)), 10), v)
+}
+
+func (e *sortableTimestampEncoder) Encode(v reflect.Value) (string, error) {
+	ts, ok := v.Interface().(*timestamp.Timestamp)
+	if !ok {
+		return ``, ErrFieldTypeNotSupportedForKeyExtraction
+	}
+
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return ``, fmt.Errorf(`timestamp key to time: %w`, err)
+	}
+	return t.UTC().Format(SortableTimestampKeyLayout), nil
+}
+
+func (e *sortableTimestampEncoder) Decode(part string, v reflect.Value) error {
+	return setTimestampValue(part, SortableTimestampKeyLayout, v)
+}
+
+func (e *bigNumberEncoder) Encode(v reflect.Value) (string, error) {
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return ``, fmt.Errorf(`%w: %s is nil`, state.ErrKeyPartEmpty, v.Type())
+	}
+
+	switch val := v.Interface().(type) {
+	case *big.Int:
+		return serialize.EncodeBigIntKey(val)
+	case *big.Rat:
+		return serialize.EncodeBigRatKey(val)
+	case KeyParter:
+		return val.KeyPart()
+	default:
+		return ``, ErrFieldTypeNotSupportedForKeyExtraction
+	}
+}
+
+func (e *bigNumberEncoder) Decode(part string, v reflect.Value) error {
+	switch v.Interface().(type) {
+	case *big.Int:
+		i, err := serialize.DecodeBigIntKey(part)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(i))
+	case *big.Rat:
+		r, err := serialize.DecodeBigRatKey(part)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(r))
+	case KeyParter:
+		keyParter := reflect.New(v.Type().Elem())
+		if err := keyParter.Interface().(KeyParter).SetKeyPart(part); err != nil {
+			return err
+		}
+		v.Set(keyParter)
+	default:
+		return ErrFieldTypeNotSupportedForKeyExtraction
+	}
+	return nil
+}
+
+// isBigNumber checks value is big number or implements KeyParter
+func isBigNumber(v reflect.Value) bool {
+	switch v.Interface().(type) {
+	case *big.Int, *big.Rat, KeyParter:
+		return true
+	}
+	return false
+}
+
+func (e *orderedEncoder) encoder(v reflect.Value) KeyEncoder {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// enum in protobuf is encoded as string
+		if _, ok := v.Interface().(fmt.Stringer); ok {
+			return nil
+		}
+		return KeyEncodingSortableInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return KeyEncodingPadded(sortableIntWidth)
+	case reflect.Ptr:
+		if v.Type() == reflect.TypeOf(&timestamp.Timestamp{}) {
+			return KeyEncodingSortableTimestamp
+		}
+		if isBigNumber(v) {
+			return KeyEncodingBigNumber
+		}
+	}
+	return nil
+}
+
+func (e *orderedEncoder) Encode(v reflect.Value) (string, error) {
+	if enc := e.encoder(v); enc != nil {
+		return enc.Encode(v)
+	}
+
+	// strings, enums, etc. are encoded as is, if value is one key part
+	key, err := keyFromValue(v)
+	if err != nil {
+		return ``, err
+	}
+	if len(key) != 1 {
+		return ``, ErrFieldTypeNotSupportedForKeyExtraction
+	}
+	return key[0], nil
+}
+
+func (e *orderedEncoder) Decode(part string, v reflect.Value) error {
+	if enc := e.encoder(v); enc != nil {
+		return enc.Decode(part, v)
+	}
+	return valueFromKeyPart(part, v)
+}
+
+// keyFromValueWith creates key part from value with encoder,
+// if encoder is set for all fields and doesn't support value type - default key extraction is used
+func keyFromValueWith(encoders keyEncoders, field string, v reflect.Value) (state.Key, error) {
+	enc := encoders.For(field)
+	if enc == nil {
+		return keyFromValue(v)
+	}
+
+	part, err := enc.Encode(v)
+	if err != nil {
+		if _, fieldSpecific := encoders[field]; !fieldSpecific &&
+			errors.Is(err, ErrFieldTypeNotSupportedForKeyExtraction) {
+			return keyFromValue(v)
+		}
+		return nil, err
+	}
+
+	return state.Key{part}, nil
+}
+
+// valueFromKeyPart sets field value from key part, created with default key extraction
+func valueFromKeyPart(part string, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(part)
+		return nil
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(part)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return setIntValue(part, v)
+
+	case reflect.Ptr:
+		if v.Type() == reflect.TypeOf(&timestamp.Timestamp{}) {
+			return setTimestampValue(part, TimestampKeyLayout, v)
+		}
+	}
+
+	return fmt.Errorf(`%w: %s`, ErrKeyDecodingNotSupported, v.Type())
+}
+
+// valueFromKeyPartWith sets field value from key part with encoder,
+// if encoder is set for all fields and doesn't support value type - default key decoding is used
+func valueFromKeyPartWith(encoders keyEncoders, field string, part string, v reflect.Value) error {
+	enc := encoders.For(field)
+	if enc == nil {
+		return valueFromKeyPart(part, v)
+	}
+
+	err := enc.Decode(part, v)
+	if err != nil {
+		if _, fieldSpecific := encoders[field]; !fieldSpecific &&
+			errors.Is(err, ErrFieldTypeNotSupportedForKeyExtraction) {
+			return valueFromKeyPart(part, v)
+		}
+	}
+	return err
+}
+
+func setIntValue(part string, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// protobuf enum
+		if enumMap := enumValueMap(v); enumMap != nil {
+			if i, ok := enumMap[part]; ok {
+				v.SetInt(int64(i))
+				return nil
+			}
+		}
+
+		i, err := strconv.ParseInt(part, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(part, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+
+	default:
+		return ErrFieldTypeNotSupportedForKeyExtraction
+	}
+
+	return nil
+}
+
+func setTimestampValue(part, layout string, v reflect.Value) error {
+	if v.Type() != reflect.TypeOf(&timestamp.Timestamp{}) {
+		return ErrFieldTypeNotSupportedForKeyExtraction
+	}
+
+	t, err := time.Parse(layout, part)
+	if err != nil {
+		return err
+	}
+	ts, err := ptypes.TimestampProto(t)
+	if err != nil {
+		return err
+	}
+	v.Set(reflect.ValueOf(ts))
+	return nil
+}
+
+// enumValueMap returns name to value map for protobuf enum type
+func enumValueMap(v reflect.Value) map[string]int32 {
+	enum, ok := v.Interface().(protoreflect.Enum)
+	if !ok {
+		return nil
+	}
+
+	values := enum.Descriptor().Values()
+	enumMap := make(map[string]int32, values.Len())
+	for i := 0; i < values.Len(); i++ {
+		enumMap[string(values.Get(i).Name())] = int32(values.Get(i).Number())
+	}
+	return enumMap
+}
+
+// EncodeKey encodes field value to key part with mapping key encoders
+func (sm *StateMapping) EncodeKey(field string, value interface{}) (state.Key, error) {
+	return sm.keyEncoders.encode(field, value)
+}
+
+// DecodeKey decodes primary key of mapped entry to instance of mapping schema with primary key fields filled.
+// Primary key should be defined with key fields, for example with PKeyAttr or PKeySchema
+func (sm *StateMapping) DecodeKey(key state.Key) (interface{}, error) {
+	if len(sm.primaryKeyAttrs) == 0 {
+		return nil, fmt.Errorf(`%w: primary key fields not defined for %s`, ErrKeyDecodingNotSupported, mapKey(sm.schema))
+	}
+
+	if len(key) != len(sm.namespace)+len(sm.primaryKeyAttrs) {
+		return nil, fmt.Errorf(`%w: key=%s`, state.ErrKeyPartsLength, key)
+	}
+
+	for i, part := range sm.namespace {
+		if key[i] != part {
+			return nil, fmt.Errorf(`%w: key=%s, namespace=%s`, ErrKeyNotInNamespace, key, sm.namespace)
+		}
+	}
+
+	instance := reflect.New(reflect.TypeOf(sm.schema).Elem())
+	for i, attr := range sm.primaryKeyAttrs {
+		field := instance.Elem().FieldByName(attr)
+		if !field.IsValid() {
+			return nil, fmt.Errorf(`%w: %s`, ErrFieldNotExists, attr)
+		}
+
+		if err := valueFromKeyPartWith(sm.keyEncoders, attr, key[len(sm.namespace)+i], field); err != nil {
+			return nil, fmt.Errorf(`decode key field %s: %w`, attr, err)
+		}
+	}
+
+	return instance.Interface(), nil
+}
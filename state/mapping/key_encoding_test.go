@@ -0,0 +1,193 @@
+package mapping_test
+
+import (
+	"math/big"
+	"sort"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/hyperledger-labs/cckit/extensions/token"
+	"github.com/hyperledger-labs/cckit/state/mapping"
+	"github.com/hyperledger-labs/cckit/state/mapping/testdata/schema"
+	testcc "github.com/hyperledger-labs/cckit/testing"
+)
+
+var _ = Describe(`Order preserving key encoding`, func() {
+
+	var (
+		mappings = mapping.StateMappings{}.Add(&schema.EntityWithCompositeId{},
+			mapping.PKeySchema(&schema.EntityCompositeId{}),
+			mapping.List(&schema.EntityWithCompositeIdList{}),
+			mapping.OrderedKeys())
+
+		cc, ctx = testcc.NewTxHandler(`ordered keys`)
+
+		entities = []*schema.EntityWithCompositeId{{
+			IdFirstPart:  `A`,
+			IdSecondPart: 10,
+			IdThirdPart:  testcc.MustTime(`2021-02-15T10:00:00Z`),
+		}, {
+			IdFirstPart:  `A`,
+			IdSecondPart: 9,
+			IdThirdPart:  testcc.MustTime(`2021-02-15T10:00:00Z`),
+		}, {
+			IdFirstPart:  `A`,
+			IdSecondPart: 9,
+			IdThirdPart:  testcc.MustTime(`2021-02-15T09:30:00.5Z`),
+		}, {
+			IdFirstPart:  `A`,
+			IdSecondPart: 100,
+			IdThirdPart:  testcc.MustTime(`2021-02-14T23:00:00Z`),
+		}}
+	)
+
+	It("Allow to encode integers with sign-aware fixed width encoding", func() {
+		m, err := mapping.StateMappings{}.Add(&schema.EntityWithIndexes{},
+			mapping.PKeyAttr(`Value`),
+			mapping.OrderedKeys()).Get(&schema.EntityWithIndexes{})
+		Expect(err).NotTo(HaveOccurred())
+
+		var keys []string
+		for _, v := range []int32{20, -5, 3, -100, 0} {
+			key, err := m.PrimaryKey(&schema.EntityWithIndexes{Value: v})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key[1]).To(HaveLen(20))
+			keys = append(keys, key[1])
+
+			decoded, err := m.DecodeKey(key)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(decoded.(*schema.EntityWithIndexes).Value).To(Equal(v))
+		}
+
+		sort.Strings(keys)
+		var values []int32
+		for _, k := range keys {
+			decoded, err := m.DecodeKey(append(mapping.SchemaNamespace(&schema.EntityWithIndexes{}), k))
+			Expect(err).NotTo(HaveOccurred())
+			values = append(values, decoded.(*schema.EntityWithIndexes).Value)
+		}
+		Expect(values).To(Equal([]int32{-100, -5, 0, 3, 20}))
+	})
+
+	It("Allow to encode big numbers and decimals with order preserving encoding", func() {
+		type bigNumbers struct {
+			Int *big.Int
+			Rat *big.Rat
+		}
+		m, err := mapping.StateMappings{}.Add(&bigNumbers{},
+			mapping.PKeyAttr(`Int`, `Rat`),
+			mapping.OrderedKeys()).Get(&bigNumbers{})
+		Expect(err).NotTo(HaveOccurred())
+
+		key, err := m.PrimaryKey(&bigNumbers{Int: big.NewInt(-15), Rat: big.NewRat(5, 4)})
+		Expect(err).NotTo(HaveOccurred())
+		decoded, err := m.DecodeKey(key)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decoded.(*bigNumbers).Int.String()).To(Equal(`-15`))
+		Expect(decoded.(*bigNumbers).Rat.RatString()).To(Equal(`5/4`))
+
+		_, err = m.PrimaryKey(&bigNumbers{Int: big.NewInt(1), Rat: big.NewRat(1, 3)})
+		Expect(err).To(HaveOccurred())
+
+		utxoMapping, err := mapping.StateMappings{}.Add(&token.UTXO{},
+			mapping.PKeyAttr(`Symbol`, `Amount`),
+			mapping.OrderedKeys()).Get(&token.UTXO{})
+		Expect(err).NotTo(HaveOccurred())
+
+		var keys []string
+		for _, amount := range []string{`10`, `-2.5`, `0.05`, `1.5`} {
+			d, err := token.ParseDecimal(amount)
+			Expect(err).NotTo(HaveOccurred())
+			key, err := utxoMapping.PrimaryKey(&token.UTXO{Symbol: `A`, Amount: d})
+			Expect(err).NotTo(HaveOccurred())
+			keys = append(keys, key[2])
+
+			decoded, err := utxoMapping.DecodeKey(key)
+			Expect(err).NotTo(HaveOccurred())
+			cmp, err := token.DecimalCmp(decoded.(*token.UTXO).Amount, d)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cmp).To(Equal(0))
+		}
+
+		sort.Strings(keys)
+		var amounts []string
+		for _, k := range keys {
+			d, err := token.DecimalFromKey(k)
+			Expect(err).NotTo(HaveOccurred())
+			amount, err := d.Text()
+			Expect(err).NotTo(HaveOccurred())
+			amounts = append(amounts, amount)
+		}
+		Expect(amounts).To(Equal([]string{`-2.5`, `0.05`, `1.5`, `10`}))
+	})
+
+	It("Disallow to encode value exceeding padded width", func() {
+		m, err := mapping.StateMappings{}.Add(&schema.EntityWithIndexes{},
+			mapping.PKeyAttr(`Value`),
+			mapping.WithKeyEncoding(mapping.KeyEncodingPadded(2), `Value`)).Get(&schema.EntityWithIndexes{})
+		Expect(err).NotTo(HaveOccurred())
+
+		key, err := m.PrimaryKey(&schema.EntityWithIndexes{Value: 7})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(key[1]).To(Equal(`07`))
+
+		_, err = m.PrimaryKey(&schema.EntityWithIndexes{Value: 100})
+		Expect(err).To(MatchError(ContainSubstring(mapping.ErrKeyValueOverflow.Error())))
+	})
+
+	It("Allow to insert entries with ordered keys", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			for _, e := range entities {
+				Expect(s.Insert(e)).To(Succeed())
+			}
+		})
+	})
+
+	It("Allow to list entries in natural key order", func() {
+		cc.Tx(func() {
+			list, err := mapping.WrapState(ctx.State(), mappings).List(&schema.EntityWithCompositeId{})
+			Expect(err).NotTo(HaveOccurred())
+
+			items := list.(*schema.EntityWithCompositeIdList).Items
+			Expect(items).To(HaveLen(4))
+			// same day timestamps don't collide and ordered by time
+			Expect(proto.Equal(items[0], entities[2])).To(BeTrue())
+			Expect(proto.Equal(items[1], entities[1])).To(BeTrue())
+			Expect(proto.Equal(items[2], entities[0])).To(BeTrue())
+			Expect(proto.Equal(items[3], entities[3])).To(BeTrue())
+		})
+	})
+
+	It("Allow to get entry by primary key schema with ordered keys", func() {
+		cc.Tx(func() {
+			entity, err := mapping.WrapState(ctx.State(), mappings).Get(&schema.EntityCompositeId{
+				IdFirstPart:  `A`,
+				IdSecondPart: 9,
+				IdThirdPart:  testcc.MustTime(`2021-02-15T09:30:00.5Z`),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(entity.(proto.Message), entities[2])).To(BeTrue())
+		})
+	})
+
+	It("Allow to decode primary key", func() {
+		m, err := mappings.Get(&schema.EntityWithCompositeId{})
+		Expect(err).NotTo(HaveOccurred())
+
+		key, err := m.PrimaryKey(entities[2])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(key[1:]).To(BeEquivalentTo([]string{`A`, `00000000000000000009`, `2021-02-15T09:30:00.500000000Z`}))
+
+		decoded, err := m.DecodeKey(key)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(proto.Equal(decoded.(proto.Message), &schema.EntityWithCompositeId{
+			IdFirstPart:  entities[2].IdFirstPart,
+			IdSecondPart: entities[2].IdSecondPart,
+			IdThirdPart:  entities[2].IdThirdPart,
+		})).To(BeTrue())
+	})
+})
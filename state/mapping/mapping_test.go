@@ -159,6 +159,25 @@ var _ = Describe(`State mapping in chaincode`, func() {
 			expectcc.ResponseError(compositeIDCC.Invoke(`get`, toDelete), state.ErrKeyNotFound)
 		})
 
+		It("Allow to get entry history as typed list", func() {
+			history := expectcc.PayloadIs(
+				compositeIDCC.Query(`history`, &schema.EntityCompositeId{
+					IdFirstPart:  create1.IdFirstPart,
+					IdSecondPart: create1.IdSecondPart,
+					IdThirdPart:  create1.IdThirdPart,
+				}),
+				&schema.EntityWithCompositeIdHistory{}, compositeIDCC.Serializer).(*schema.EntityWithCompositeIdHistory)
+
+			// create, update, delete - from newest to oldest
+			Expect(len(history.Items)).To(Equal(3))
+			Expect(history.Items[0].IsDeleted).To(BeTrue())
+			Expect(history.Items[0].Value).To(BeNil())
+			Expect(history.Items[1].Value.Name).To(Equal(`New name`))
+			Expect(history.Items[2].Value.Name).To(Equal(create1.Name))
+			Expect(history.Items[2].TxId).NotTo(BeEmpty())
+			Expect(history.Items[2].Timestamp).NotTo(BeNil())
+		})
+
 		It("Allow to insert entry once more time", func() {
 			expectcc.ResponseOk(compositeIDCC.Invoke(`create`, create1))
 		})
@@ -365,6 +384,85 @@ var _ = Describe(`State mapping in chaincode`, func() {
 			expectcc.ResponseOk(indexesCC.Invoke(`create`, create2))
 		})
 
+		It("Disallow to insert many entries with uniq key collision in batch", func() {
+			expectcc.ResponseError(indexesCC.Invoke(`insertMany`, &schema.EntityWithIndexesList{
+				Items: []*schema.EntityWithIndexes{
+					{Id: `ccc`, ExternalId: `ccc_ccc`},
+					{Id: `ddd`, ExternalId: `ccc_ccc`},
+				}}), mapping.ErrMappingUniqKeyExists)
+
+			// nothing is written
+			expectcc.ResponseError(indexesCC.Invoke(`get`, `ccc`), state.ErrKeyNotFound)
+		})
+
+		It("Disallow to insert many entries with uniq key collision with state", func() {
+			expectcc.ResponseError(indexesCC.Invoke(`insertMany`, &schema.EntityWithIndexesList{
+				Items: []*schema.EntityWithIndexes{
+					{Id: `ccc`, ExternalId: `ccc_ccc`},
+					{Id: `ddd`, ExternalId: create1.ExternalId},
+				}}), mapping.ErrMappingUniqKeyExists)
+
+			expectcc.ResponseError(indexesCC.Invoke(`get`, `ccc`), state.ErrKeyNotFound)
+		})
+
+		It("Disallow to insert many entries with duplicated or empty keys", func() {
+			expectcc.ResponseError(indexesCC.Invoke(`insertMany`, &schema.EntityWithIndexesList{
+				Items: []*schema.EntityWithIndexes{
+					{Id: `ccc`, ExternalId: `ccc_ccc`},
+					{Id: `ccc`, ExternalId: `ddd_ddd`},
+				}}), state.ErrBulkKeyDuplicated)
+
+			expectcc.ResponseError(indexesCC.Invoke(`insertMany`, &schema.EntityWithIndexesList{
+				Items: []*schema.EntityWithIndexes{
+					{Id: `ccc`},
+				}}), state.ErrKeyPartEmpty)
+		})
+
+		It("Allow to insert many entries", func() {
+			expectcc.ResponseOk(indexesCC.Invoke(`insertMany`, &schema.EntityWithIndexesList{
+				Items: []*schema.EntityWithIndexes{
+					{Id: `ddd`, ExternalId: `ddd_ddd`},
+					{Id: `ccc`, ExternalId: `ccc_ccc`},
+				}}))
+
+			fromCCByExtId := expectcc.PayloadIs(
+				indexesCC.Query(`getByExternalId`, `ddd_ddd`),
+				&schema.EntityWithIndexes{}, indexesCC.Serializer).(*schema.EntityWithIndexes)
+			Expect(fromCCByExtId.Id).To(Equal(`ddd`))
+		})
+
+		It("Allow to put many entries with same uniq keys", func() {
+			expectcc.ResponseOk(indexesCC.Invoke(`putMany`, &schema.EntityWithIndexesList{
+				Items: []*schema.EntityWithIndexes{
+					{Id: `ccc`, ExternalId: `ccc_ccc`, Value: 10},
+					{Id: `ddd`, ExternalId: `ddd_new`, Value: 20},
+				}}))
+
+			fromCCByExtId := expectcc.PayloadIs(
+				indexesCC.Query(`getByExternalId`, `ddd_new`),
+				&schema.EntityWithIndexes{}, indexesCC.Serializer).(*schema.EntityWithIndexes)
+			Expect(fromCCByExtId.Value).To(BeNumerically("==", 20))
+		})
+
+		It("Disallow to put many entries with uniq key used by another entry", func() {
+			expectcc.ResponseError(indexesCC.Invoke(`putMany`, &schema.EntityWithIndexesList{
+				Items: []*schema.EntityWithIndexes{
+					{Id: `ccc`, ExternalId: `ddd_new`},
+				}}), mapping.ErrMappingUniqKeyExists)
+		})
+
+		It("Allow to delete many entries", func() {
+			expectcc.ResponseError(indexesCC.Invoke(`deleteMany`, &schema.EntityWithIndexesList{
+				Items: []*schema.EntityWithIndexes{{Id: `ccc`}, {Id: `eee`}}}), state.ErrKeyNotFound)
+
+			expectcc.ResponseOk(indexesCC.Invoke(`deleteMany`, &schema.EntityWithIndexesList{
+				Items: []*schema.EntityWithIndexes{{Id: `ccc`}, {Id: `ddd`}}}))
+
+			expectcc.ResponseError(indexesCC.Invoke(`get`, `ccc`), state.ErrKeyNotFound)
+			expectcc.ResponseError(
+				indexesCC.Query(`getByExternalId`, `ddd_new`), mapping.ErrIndexReferenceNotFound)
+		})
+
 	})
 
 	Describe(`Entity with static key`, func() {
@@ -2,12 +2,13 @@ package mapping
 
 import (
 	"github.com/hyperledger-labs/cckit/router"
+	"github.com/hyperledger-labs/cckit/state"
 )
 
 func MapStates(stateMappings StateMappings) router.MiddlewareFunc {
 	return func(next router.HandlerFunc, pos ...int) router.HandlerFunc {
 		return func(c router.Context) (interface{}, error) {
-			c.UseState(WrapState(c.State(), stateMappings))
+			c.UseState(WrapState(c.State(), stateMappings).UseEvent(c.Event))
 			return next(c)
 		}
 	}
@@ -21,3 +22,24 @@ func MapEvents(eventMappings EventMappings) router.MiddlewareFunc {
 		}
 	}
 }
+
+// BatchEvents accumulates all events, set by handler, and emits them after handler as single batch event.
+// Must be used before other middleware, wrapping or configuring context event (MapEvents, encryption)
+func BatchEvents() router.MiddlewareFunc {
+	return func(next router.HandlerFunc, pos ...int) router.HandlerFunc {
+		return func(c router.Context) (interface{}, error) {
+			batch := state.NewEventBatch(c.Stub())
+			batch.UseToBytesConverter(c.Event().ToBytesConverter())
+			c.UseEvent(batch)
+
+			res, err := next(c)
+			if err != nil {
+				return nil, err
+			}
+			if err = batch.Emit(); err != nil {
+				return nil, err
+			}
+			return res, nil
+		}
+	}
+}
@@ -0,0 +1,250 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        (unknown)
+// source: mapping/options/state.proto
+
+// State mapping options, used by protoc-gen-cc-mapping for generating state mappings
+
+package options
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Index definition of message field
+type Index struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// index name, field name by default. Fields with same index name form index with multiple fields
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// uniq index - only one entry can have index value
+	Uniq bool `protobuf:"varint,2,opt,name=uniq,proto3" json:"uniq,omitempty"`
+	// required index value
+	Required bool `protobuf:"varint,3,opt,name=required,proto3" json:"required,omitempty"`
+	// range index - entries can be listed by index value range
+	Range bool `protobuf:"varint,4,opt,name=range,proto3" json:"range,omitempty"`
+}
+
+func (x *Index) Reset() {
+	*x = Index{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mapping_options_state_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Index) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Index) ProtoMessage() {}
+
+func (x *Index) ProtoReflect() protoreflect.Message {
+	mi := &file_mapping_options_state_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Index.ProtoReflect.Descriptor instead.
+func (*Index) Descriptor() ([]byte, []int) {
+	return file_mapping_options_state_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Index) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Index) GetUniq() bool {
+	if x != nil {
+		return x.Uniq
+	}
+	return false
+}
+
+func (x *Index) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *Index) GetRange() bool {
+	if x != nil {
+		return x.Range
+	}
+	return false
+}
+
+var file_mapping_options_state_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         51000,
+		Name:          "cckit.state.namespace",
+		Tag:           "bytes,51000,opt,name=namespace",
+		Filename:      "mapping/options/state.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         51000,
+		Name:          "cckit.state.pkey",
+		Tag:           "varint,51000,opt,name=pkey",
+		Filename:      "mapping/options/state.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*Index)(nil),
+		Field:         51001,
+		Name:          "cckit.state.index",
+		Tag:           "bytes,51001,opt,name=index",
+		Filename:      "mapping/options/state.proto",
+	},
+}
+
+// Extension fields to descriptorpb.MessageOptions.
+var (
+	// namespace of mapped entries state keys, schema type name by default
+	//
+	// optional string namespace = 51000;
+	E_Namespace = &file_mapping_options_state_proto_extTypes[0]
+)
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// field is part of primary key, fields are included in primary key in declaration order
+	//
+	// optional bool pkey = 51000;
+	E_Pkey = &file_mapping_options_state_proto_extTypes[1]
+	// field is indexed
+	//
+	// optional cckit.state.Index index = 51001;
+	E_Index = &file_mapping_options_state_proto_extTypes[2]
+)
+
+var File_mapping_options_state_proto protoreflect.FileDescriptor
+
+var file_mapping_options_state_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x6d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x63,
+	0x63, 0x6b, 0x69, 0x74, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x61, 0x0a, 0x05,
+	0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x6e, 0x69,
+	0x71, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x75, 0x6e, 0x69, 0x71, 0x12, 0x1a, 0x0a,
+	0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x61, 0x6e,
+	0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x3a,
+	0x3f, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x1f, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xb8, 0x8e,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x3a, 0x33, 0x0a, 0x04, 0x70, 0x6b, 0x65, 0x79, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xb8, 0x8e, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x04, 0x70, 0x6b, 0x65, 0x79, 0x3a, 0x49, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1d,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xb9, 0x8e,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x63, 0x6b, 0x69, 0x74, 0x2e, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x42, 0x39, 0x5a, 0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68,
+	0x79, 0x70, 0x65, 0x72, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x2d, 0x6c, 0x61, 0x62, 0x73, 0x2f,
+	0x63, 0x63, 0x6b, 0x69, 0x74, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2f, 0x6d, 0x61, 0x70, 0x70,
+	0x69, 0x6e, 0x67, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_mapping_options_state_proto_rawDescOnce sync.Once
+	file_mapping_options_state_proto_rawDescData = file_mapping_options_state_proto_rawDesc
+)
+
+func file_mapping_options_state_proto_rawDescGZIP() []byte {
+	file_mapping_options_state_proto_rawDescOnce.Do(func() {
+		file_mapping_options_state_proto_rawDescData = protoimpl.X.CompressGZIP(file_mapping_options_state_proto_rawDescData)
+	})
+	return file_mapping_options_state_proto_rawDescData
+}
+
+var file_mapping_options_state_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_mapping_options_state_proto_goTypes = []interface{}{
+	(*Index)(nil),                       // 0: cckit.state.Index
+	(*descriptorpb.MessageOptions)(nil), // 1: google.protobuf.MessageOptions
+	(*descriptorpb.FieldOptions)(nil),   // 2: google.protobuf.FieldOptions
+}
+var file_mapping_options_state_proto_depIdxs = []int32{
+	1, // 0: cckit.state.namespace:extendee -> google.protobuf.MessageOptions
+	2, // 1: cckit.state.pkey:extendee -> google.protobuf.FieldOptions
+	2, // 2: cckit.state.index:extendee -> google.protobuf.FieldOptions
+	0, // 3: cckit.state.index:type_name -> cckit.state.Index
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	3, // [3:4] is the sub-list for extension type_name
+	0, // [0:3] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_mapping_options_state_proto_init() }
+func file_mapping_options_state_proto_init() {
+	if File_mapping_options_state_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_mapping_options_state_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Index); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_mapping_options_state_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 3,
+			NumServices:   0,
+		},
+		GoTypes:           file_mapping_options_state_proto_goTypes,
+		DependencyIndexes: file_mapping_options_state_proto_depIdxs,
+		MessageInfos:      file_mapping_options_state_proto_msgTypes,
+		ExtensionInfos:    file_mapping_options_state_proto_extTypes,
+	}.Build()
+	File_mapping_options_state_proto = out.File
+	file_mapping_options_state_proto_rawDesc = nil
+	file_mapping_options_state_proto_goTypes = nil
+	file_mapping_options_state_proto_depIdxs = nil
+}
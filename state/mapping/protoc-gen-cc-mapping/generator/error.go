@@ -0,0 +1,17 @@
+package generator
+
+import "errors"
+
+var (
+	// ErrPrimaryKeyNotDefined occurs when message has state options, but has no primary key fields
+	ErrPrimaryKeyNotDefined = errors.New("primary key fields not defined")
+
+	// ErrFieldTypeNotSupported occurs when field type can't be used as key field
+	ErrFieldTypeNotSupported = errors.New("field type not supported for key")
+
+	// ErrIndexDefinitionMismatch occurs when fields of one index have different index options
+	ErrIndexDefinitionMismatch = errors.New("index definition mismatch")
+
+	// ErrIndexNotSupported occurs when index options combination is not supported
+	ErrIndexNotSupported = errors.New("index not supported")
+)
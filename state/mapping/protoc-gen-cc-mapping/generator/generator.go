@@ -0,0 +1,298 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	protobuf "github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/golang/protobuf/protoc-gen-go/generator"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+	"github.com/grpc-ecosystem/grpc-gateway/protoc-gen-grpc-gateway/descriptor"
+	protov2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/hyperledger-labs/cckit/state/mapping/options"
+)
+
+const (
+	MappingPkg   = `github.com/hyperledger-labs/cckit/state/mapping`
+	TimestampMsg = `.google.protobuf.Timestamp`
+)
+
+type (
+	Generator struct {
+		reg  *descriptor.Registry
+		Opts Opts
+	}
+
+	// StateMapping state mapping, defined with proto options for message
+	StateMapping struct {
+		GoType    string
+		Namespace string
+		PKey      []string
+		List      string
+		Indexes   []*StateIndex
+	}
+
+	// StateIndex index, defined with proto options for message fields
+	StateIndex struct {
+		Name     string
+		Fields   []string
+		Uniq     bool
+		Required bool
+		Multi    bool
+		Range    bool
+	}
+
+	// EventMapping event mapping, defined with proto options for message
+	EventMapping struct {
+		GoType string
+	}
+)
+
+// New returns a new generator which generates state and event mappings.
+func New(reg *descriptor.Registry) *Generator {
+	return &Generator{
+		reg:  reg,
+		Opts: Opts{},
+	}
+}
+
+func (g *Generator) Generate(targets []*descriptor.File) ([]*plugin.CodeGeneratorResponse_File, error) {
+	var files []*plugin.CodeGeneratorResponse_File
+	for _, file := range targets {
+		stateMappings, eventMappings, err := g.mappings(file)
+		if err != nil {
+			return nil, fmt.Errorf(`%s: %w`, file.GetName(), err)
+		}
+
+		if len(stateMappings) == 0 && len(eventMappings) == 0 {
+			continue
+		}
+
+		code, err := g.generateMapping(file, stateMappings, eventMappings)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, code)
+	}
+
+	return files, nil
+}
+
+func (g *Generator) generateMapping(
+	file *descriptor.File, stateMappings []*StateMapping, eventMappings []*EventMapping) (
+	*plugin.CodeGeneratorResponse_File, error) {
+
+	var buf bytes.Buffer
+	if err := mappingTemplate.Execute(&buf, TemplateParams{
+		File:          file,
+		MappingPkg:    MappingPkg,
+		StateMappings: stateMappings,
+		EventMappings: eventMappings,
+	}); err != nil {
+		return nil, err
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	name := filepath.Base(file.GetName())
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+
+	basePath := path.Dir(*file.FileDescriptorProto.Name)
+	if !g.Opts.PathsSourceRelative {
+		basePath = file.GoPkg.Path
+	}
+
+	output := fmt.Sprintf(filepath.Join(basePath, "%s.pb.mapping.go"), base)
+	output = filepath.Clean(output)
+
+	return &plugin.CodeGeneratorResponse_File{
+		Name:    proto.String(output),
+		Content: proto.String(string(formatted)),
+	}, nil
+}
+
+// mappings returns state and event mappings for file messages, validates mapping options
+func (g *Generator) mappings(file *descriptor.File) ([]*StateMapping, []*EventMapping, error) {
+	var (
+		stateMappings []*StateMapping
+		eventMappings []*EventMapping
+	)
+
+	for _, msg := range file.Messages {
+		if msg.GetOptions().GetMapEntry() {
+			continue
+		}
+
+		if isEvent(msg) {
+			eventMappings = append(eventMappings, &EventMapping{GoType: msg.GoType(file.GoPkg.Path)})
+		}
+
+		sm, err := stateMapping(file, msg)
+		if err != nil {
+			return nil, nil, fmt.Errorf(`message %s: %w`, msg.GetName(), err)
+		}
+		if sm != nil {
+			stateMappings = append(stateMappings, sm)
+		}
+	}
+
+	return stateMappings, eventMappings, nil
+}
+
+func isEvent(msg *descriptor.Message) bool {
+	if msg.GetOptions() == nil {
+		return false
+	}
+	event, _ := extension(msg.GetOptions(), options.E_Event).(bool)
+	return event
+}
+
+func stateMapping(file *descriptor.File, msg *descriptor.Message) (*StateMapping, error) {
+	sm := &StateMapping{
+		GoType: msg.GoType(file.GoPkg.Path),
+	}
+
+	if msg.GetOptions() != nil {
+		sm.Namespace, _ = extension(msg.GetOptions(), options.E_Namespace).(string)
+	}
+
+	indexes := make(map[string]*StateIndex)
+	for _, field := range msg.Fields {
+		if field.GetOptions() == nil {
+			continue
+		}
+
+		if pkey, _ := extension(field.GetOptions(), options.E_Pkey).(bool); pkey {
+			if err := checkKeyField(field, false); err != nil {
+				return nil, err
+			}
+			sm.PKey = append(sm.PKey, goFieldName(field))
+		}
+
+		idxOpt, _ := extension(field.GetOptions(), options.E_Index).(*options.Index)
+		if idxOpt == nil {
+			continue
+		}
+
+		if err := addIndexField(sm, indexes, field, idxOpt); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(sm.PKey) == 0 {
+		if sm.Namespace != `` || len(sm.Indexes) > 0 {
+			return nil, ErrPrimaryKeyNotDefined
+		}
+		return nil, nil
+	}
+
+	for _, idx := range sm.Indexes {
+		if idx.Multi && len(idx.Fields) > 1 {
+			return nil, fmt.Errorf(`%w: index %s with repeated field must have one field`, ErrIndexNotSupported, idx.Name)
+		}
+	}
+
+	sm.List = listFor(file, msg)
+	return sm, nil
+}
+
+func addIndexField(
+	sm *StateMapping, indexes map[string]*StateIndex, field *descriptor.Field, idxOpt *options.Index) error {
+
+	multi := field.GetLabel() == protobuf.FieldDescriptorProto_LABEL_REPEATED
+	if err := checkKeyField(field, !idxOpt.Range); err != nil {
+		return err
+	}
+
+	name := idxOpt.Name
+	if name == `` {
+		name = goFieldName(field)
+	}
+
+	if idxOpt.Range && (idxOpt.Uniq || idxOpt.Required) {
+		return fmt.Errorf(`%w: range index %s can't be uniq or required`, ErrIndexNotSupported, name)
+	}
+
+	idx, ok := indexes[name]
+	if !ok {
+		idx = &StateIndex{
+			Name:     name,
+			Uniq:     idxOpt.Uniq,
+			Required: idxOpt.Required,
+			Range:    idxOpt.Range,
+			Multi:    multi,
+		}
+		indexes[name] = idx
+		sm.Indexes = append(sm.Indexes, idx)
+	} else if idx.Uniq != idxOpt.Uniq || idx.Required != idxOpt.Required || idx.Range != idxOpt.Range {
+		return fmt.Errorf(`%w: index %s field %s`, ErrIndexDefinitionMismatch, name, field.GetName())
+	}
+
+	idx.Multi = idx.Multi || multi
+	idx.Fields = append(idx.Fields, goFieldName(field))
+	return nil
+}
+
+// checkKeyField checks field type can be used as key part,
+// repeated string fields are allowed only for indexes, each value refers to entry
+func checkKeyField(field *descriptor.Field, allowRepeated bool) error {
+	if field.GetLabel() == protobuf.FieldDescriptorProto_LABEL_REPEATED {
+		if allowRepeated && field.GetType() == protobuf.FieldDescriptorProto_TYPE_STRING {
+			return nil
+		}
+		return fmt.Errorf(`%w: repeated field %s`, ErrFieldTypeNotSupported, field.GetName())
+	}
+
+	switch field.GetType() {
+	case protobuf.FieldDescriptorProto_TYPE_BYTES, protobuf.FieldDescriptorProto_TYPE_GROUP,
+		protobuf.FieldDescriptorProto_TYPE_FLOAT, protobuf.FieldDescriptorProto_TYPE_DOUBLE:
+		return fmt.Errorf(`%w: field %s type %s`, ErrFieldTypeNotSupported, field.GetName(), field.GetType())
+
+	case protobuf.FieldDescriptorProto_TYPE_MESSAGE:
+		if field.GetTypeName() != TimestampMsg {
+			return fmt.Errorf(`%w: field %s type %s`, ErrFieldTypeNotSupported, field.GetName(), field.GetTypeName())
+		}
+	}
+
+	return nil
+}
+
+// listFor returns go type of <MessageName>List message with repeated items of message type, if exists in file
+func listFor(file *descriptor.File, msg *descriptor.Message) string {
+	for _, list := range file.Messages {
+		if list.GetName() != msg.GetName()+`List` || len(list.Outers) != len(msg.Outers) {
+			continue
+		}
+		for _, field := range list.Fields {
+			if field.GetName() == `items` &&
+				field.GetLabel() == protobuf.FieldDescriptorProto_LABEL_REPEATED &&
+				field.GetTypeName() == msg.FQMN() {
+				return list.GoType(file.GoPkg.Path)
+			}
+		}
+	}
+	return ``
+}
+
+// extension returns extension value from descriptor options or nil, if extension is not set
+func extension(opts protoreflect.ProtoMessage, ext protoreflect.ExtensionType) interface{} {
+	if !protov2.HasExtension(opts, ext) {
+		return nil
+	}
+	return protov2.GetExtension(opts, ext)
+}
+
+func goFieldName(field *descriptor.Field) string {
+	return generator.CamelCase(field.GetName())
+}
@@ -0,0 +1,187 @@
+package generator_test
+
+import (
+	"go/format"
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+	gwdescriptor "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-grpc-gateway/descriptor"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hyperledger-labs/cckit/state/mapping/options"
+	"github.com/hyperledger-labs/cckit/state/mapping/protoc-gen-cc-mapping/generator"
+	"github.com/hyperledger-labs/cckit/state/mapping/testdata/schema"
+)
+
+func TestGenerator(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Mapping generator suite")
+}
+
+const testFile = `mapping/testdata/schema/generator_test.proto`
+
+// generate runs generator for files, last file is file to generate
+func generate(files ...*descriptor.FileDescriptorProto) ([]*plugin.CodeGeneratorResponse_File, error) {
+	deps := []*descriptor.FileDescriptorProto{
+		protodesc.ToFileDescriptorProto(descriptorpb.File_google_protobuf_descriptor_proto),
+		protodesc.ToFileDescriptorProto(timestamppb.File_google_protobuf_timestamp_proto),
+		protodesc.ToFileDescriptorProto(options.File_mapping_options_state_proto),
+		protodesc.ToFileDescriptorProto(options.File_mapping_options_event_proto),
+	}
+
+	reg := gwdescriptor.NewRegistry()
+	if err := reg.Load(&plugin.CodeGeneratorRequest{
+		FileToGenerate: []string{files[len(files)-1].GetName()},
+		ProtoFile:      append(deps, files...),
+	}); err != nil {
+		return nil, err
+	}
+
+	target, err := reg.LookupFile(files[len(files)-1].GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	g := generator.New(reg)
+	g.Opts = generator.OptsFromParams(`paths=source_relative`)
+	return g.Generate([]*gwdescriptor.File{target})
+}
+
+// message returns file with one message, fields are created with field options
+func message(msgOpts *descriptor.MessageOptions, fields ...*descriptor.FieldDescriptorProto) *descriptor.FileDescriptorProto {
+	for i, field := range fields {
+		field.Number = proto.Int32(int32(i + 1))
+		field.JsonName = proto.String(field.GetName())
+	}
+
+	return &descriptor.FileDescriptorProto{
+		Name:       proto.String(testFile),
+		Package:    proto.String(`schema`),
+		Syntax:     proto.String(`proto3`),
+		Dependency: []string{`google/protobuf/timestamp.proto`, `mapping/options/state.proto`},
+		Options: &descriptor.FileOptions{
+			GoPackage: proto.String(`github.com/hyperledger-labs/cckit/state/mapping/testdata/schema`),
+		},
+		MessageType: []*descriptor.DescriptorProto{{
+			Name:    proto.String(`Entity`),
+			Options: msgOpts,
+			Field:   fields,
+		}},
+	}
+}
+
+func field(name string, typ descriptor.FieldDescriptorProto_Type, opts ...func(*descriptor.FieldOptions)) *descriptor.FieldDescriptorProto {
+	f := &descriptor.FieldDescriptorProto{
+		Name:  proto.String(name),
+		Type:  typ.Enum(),
+		Label: descriptor.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+	}
+
+	if len(opts) > 0 {
+		f.Options = &descriptor.FieldOptions{}
+		for _, opt := range opts {
+			opt(f.Options)
+		}
+	}
+	return f
+}
+
+func repeated(f *descriptor.FieldDescriptorProto) *descriptor.FieldDescriptorProto {
+	f.Label = descriptor.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	return f
+}
+
+func pkey(opts *descriptor.FieldOptions) {
+	proto.SetExtension(opts, options.E_Pkey, true)
+}
+
+func index(idx *options.Index) func(*descriptor.FieldOptions) {
+	return func(opts *descriptor.FieldOptions) {
+		proto.SetExtension(opts, options.E_Index, idx)
+	}
+}
+
+var _ = Describe(`State mapping generator`, func() {
+
+	It("Allow to generate mappings from proto options", func() {
+		files, err := generate(protodesc.ToFileDescriptorProto(schema.File_mapping_testdata_schema_with_options_proto))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(HaveLen(1))
+		Expect(files[0].GetName()).To(Equal(`mapping/testdata/schema/with_options.pb.mapping.go`))
+
+		expected, err := os.ReadFile(`../../testdata/schema/with_options.pb.mapping.go`)
+		Expect(err).NotTo(HaveOccurred())
+		// testdata is formatted with gofmt of older go version, doc comment lists are reformatted
+		expected, err = format.Source(expected)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(files[0].GetContent()).To(Equal(string(expected)))
+	})
+
+	It("Allow to skip file without mapping options", func() {
+		files, err := generate(message(nil, field(`id`, descriptor.FieldDescriptorProto_TYPE_STRING)))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(HaveLen(0))
+	})
+
+	It("Disallow to generate mapping with index options, but without primary key", func() {
+		_, err := generate(message(nil,
+			field(`id`, descriptor.FieldDescriptorProto_TYPE_STRING),
+			field(`external_id`, descriptor.FieldDescriptorProto_TYPE_STRING, index(&options.Index{Uniq: true}))))
+		Expect(err).To(MatchError(ContainSubstring(generator.ErrPrimaryKeyNotDefined.Error())))
+
+		msgOpts := &descriptor.MessageOptions{}
+		proto.SetExtension(msgOpts, options.E_Namespace, `entity`)
+		_, err = generate(message(msgOpts, field(`id`, descriptor.FieldDescriptorProto_TYPE_STRING)))
+		Expect(err).To(MatchError(ContainSubstring(generator.ErrPrimaryKeyNotDefined.Error())))
+	})
+
+	It("Disallow to use fields with unsupported types as key fields", func() {
+		_, err := generate(message(nil, field(`id`, descriptor.FieldDescriptorProto_TYPE_BYTES, pkey)))
+		Expect(err).To(MatchError(ContainSubstring(generator.ErrFieldTypeNotSupported.Error())))
+
+		_, err = generate(message(nil, repeated(field(`id`, descriptor.FieldDescriptorProto_TYPE_STRING, pkey))))
+		Expect(err).To(MatchError(ContainSubstring(generator.ErrFieldTypeNotSupported.Error())))
+
+		_, err = generate(message(nil,
+			field(`id`, descriptor.FieldDescriptorProto_TYPE_STRING, pkey),
+			field(`amount`, descriptor.FieldDescriptorProto_TYPE_DOUBLE, index(&options.Index{}))))
+		Expect(err).To(MatchError(ContainSubstring(generator.ErrFieldTypeNotSupported.Error())))
+
+		_, err = generate(message(nil,
+			field(`id`, descriptor.FieldDescriptorProto_TYPE_STRING, pkey),
+			repeated(field(`codes`, descriptor.FieldDescriptorProto_TYPE_INT32, index(&options.Index{})))))
+		Expect(err).To(MatchError(ContainSubstring(generator.ErrFieldTypeNotSupported.Error())))
+	})
+
+	It("Disallow to define index fields with conflicting options", func() {
+		_, err := generate(message(nil,
+			field(`id`, descriptor.FieldDescriptorProto_TYPE_STRING, pkey),
+			field(`category`, descriptor.FieldDescriptorProto_TYPE_STRING,
+				index(&options.Index{Name: `CategoryValue`, Uniq: true})),
+			field(`value`, descriptor.FieldDescriptorProto_TYPE_INT32,
+				index(&options.Index{Name: `CategoryValue`}))))
+		Expect(err).To(MatchError(ContainSubstring(generator.ErrIndexDefinitionMismatch.Error())))
+		Expect(err).To(MatchError(ContainSubstring(`message Entity`)))
+	})
+
+	It("Disallow to define unsupported index options combination", func() {
+		_, err := generate(message(nil,
+			field(`id`, descriptor.FieldDescriptorProto_TYPE_STRING, pkey),
+			field(`value`, descriptor.FieldDescriptorProto_TYPE_INT32, index(&options.Index{Range: true, Uniq: true}))))
+		Expect(err).To(MatchError(ContainSubstring(generator.ErrIndexNotSupported.Error())))
+
+		_, err = generate(message(nil,
+			field(`id`, descriptor.FieldDescriptorProto_TYPE_STRING, pkey),
+			repeated(field(`tags`, descriptor.FieldDescriptorProto_TYPE_STRING, index(&options.Index{Name: `TagValue`}))),
+			field(`value`, descriptor.FieldDescriptorProto_TYPE_INT32, index(&options.Index{Name: `TagValue`}))))
+		Expect(err).To(MatchError(ContainSubstring(generator.ErrIndexNotSupported.Error())))
+	})
+})
@@ -0,0 +1,35 @@
+package generator
+
+import (
+	"strings"
+)
+
+const (
+	ParamPaths               = `paths`
+	ParamPathsSourceRelative = `source_relative`
+)
+
+// Opts by default all opts are disabled
+type Opts struct {
+	PathsSourceRelative bool
+}
+
+func OptsFromParams(params string) Opts {
+	opts := Opts{}
+	for _, param := range strings.Split(params, ",") {
+		var value string
+		if i := strings.Index(param, "="); i >= 0 {
+			value = param[i+1:]
+			param = param[0:i]
+		}
+		switch param {
+		case ParamPaths:
+			switch value {
+			case ParamPathsSourceRelative:
+				opts.PathsSourceRelative = true
+			}
+		}
+	}
+
+	return opts
+}
@@ -0,0 +1,77 @@
+package generator
+
+import (
+	"text/template"
+
+	"github.com/grpc-ecosystem/grpc-gateway/protoc-gen-grpc-gateway/descriptor"
+)
+
+type TemplateParams struct {
+	*descriptor.File
+	MappingPkg    string
+	StateMappings []*StateMapping
+	EventMappings []*EventMapping
+}
+
+var (
+	funcMap = template.FuncMap{
+		"baseName":  baseName,
+		"goStrings": goStrings,
+	}
+
+	mappingTemplate = template.Must(template.New("mapping").Funcs(funcMap).Parse(`
+// Code generated by protoc-gen-cc-mapping. DO NOT EDIT.
+// source: {{ .GetName }}
+
+/*
+Package {{ .GoPkg.Name }} contains
+  *   state mappings, defined with (cckit.state.*) options
+  *   event mappings, defined with (cckit.event) option
+*/
+package {{ .GoPkg.Name }}
+
+import (
+	cckit_mapping "{{ .MappingPkg }}"
+)
+
+{{ $file := . }}
+{{ $base := .GetName | baseName }}
+
+{{ if .StateMappings }}
+// {{ $base }}StateMappings state mappings for messages with primary key fields
+var {{ $base }}StateMappings = cckit_mapping.StateMappings{}
+{{- range $sm := .StateMappings }}.
+	Add(&{{ $sm.GoType }}{},
+	{{- if $sm.Namespace }}
+		cckit_mapping.WithNamespace([]string{ "{{ $sm.Namespace }}" }),
+	{{- end }}
+		cckit_mapping.PKeyAttr({{ goStrings $sm.PKey }}),
+	{{- if $sm.List }}
+		cckit_mapping.List(&{{ $sm.List }}{}),
+	{{- end }}
+	{{- range $idx := $sm.Indexes }}
+	{{- if $idx.Range }}
+		cckit_mapping.RangeIndex("{{ $idx.Name }}", []string{ {{ goStrings $idx.Fields }} }),
+	{{- else }}
+		cckit_mapping.WithIndex(&cckit_mapping.StateIndexDef{
+			Name:     "{{ $idx.Name }}",
+			Fields:   []string{ {{ goStrings $idx.Fields }} },
+			Required: {{ $idx.Required }},
+			Multi:    {{ $idx.Multi }},
+			NonUniq:  {{ not $idx.Uniq }},
+		}),
+	{{- end }}
+	{{- end }}
+	)
+{{- end }}
+{{ end }}
+
+{{ if .EventMappings }}
+// {{ $base }}EventMappings event mappings for messages with (cckit.event) option
+var {{ $base }}EventMappings = cckit_mapping.EventMappings{}
+{{- range $em := .EventMappings }}.
+	Add(&{{ $em.GoType }}{})
+{{- end }}
+{{ end }}
+`))
+)
@@ -0,0 +1,24 @@
+package generator
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/protoc-gen-go/generator"
+)
+
+// baseName returns camel cased proto file name without extension
+func baseName(fileName string) string {
+	name := filepath.Base(fileName)
+	return generator.CamelCase(strings.TrimSuffix(name, filepath.Ext(name)))
+}
+
+// goStrings returns comma separated quoted strings
+func goStrings(ss []string) string {
+	quoted := make([]string, len(ss))
+	for i, s := range ss {
+		quoted[i] = strconv.Quote(s)
+	}
+	return strings.Join(quoted, `, `)
+}
@@ -2,8 +2,13 @@ package mapping
 
 import (
 	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/pkg/errors"
@@ -29,11 +34,53 @@ type (
 
 		// GetByKey
 		GetByKey(schema interface{}, idx string, idxVal []string, target ...interface{}) (result interface{}, err error)
+
+		// ListByIndex returns entries with index value, index value can be partial for index with multiple fields
+		ListByIndex(schema interface{}, idx string, idxVal []string) (result interface{}, err error)
+
+		// ListByIndexPaginated returns entries with index value with pagination
+		ListByIndexPaginated(schema interface{}, idx string, idxVal []string, pageSize int32, bookmark string) (
+			result interface{}, metadata *pb.QueryResponseMetadata, err error)
+
+		// ListByRange returns entries with index value in range [from, to] with pagination, if pageSize > 0.
+		// Boundary can be nil (range is not bounded), instance of schema, index value for index with one field
+		// or state.Key with already encoded index key parts
+		ListByRange(schema interface{}, idx string, from, to interface{}, pageSize int32, bookmark string) (
+			result interface{}, metadata *pb.QueryResponseMetadata, err error)
+
+		// GetHistoryList returns entry history records as list proto, defined in mapping, or default history list proto
+		GetHistoryList(entry interface{}, pageSize int32, bookmark string, opts ...state.HistoryOpt) (
+			list proto.Message, metadata *pb.QueryResponseMetadata, err error)
+
+		// ListReferencing returns entries of referencing schema, which reference entry with refs, defined in mapping
+		ListReferencing(entry interface{}, referencing interface{}) (result interface{}, err error)
+
+		// Patch applies fields from field mask, taken from entry, to entry value in state,
+		// returns patched entry
+		Patch(entry interface{}, mask *fieldmaskpb.FieldMask, opts ...PatchOpt) (result interface{}, err error)
+
+		// CheckIndexes checks key refs of mapped entries in batch, returns missing, orphaned and mismatched key refs
+		CheckIndexes(schema interface{}, pageSize int32, bookmark string) (*schema.IndexCheckResult, error)
+
+		// RebuildIndexes checks key refs of mapped entries in batch and fixes found issues
+		RebuildIndexes(schema interface{}, pageSize int32, bookmark string) (*schema.IndexCheckResult, error)
+
+		// PurgeExpired deletes at most limit expired entries, returns primary keys of deleted entries
+		PurgeExpired(schema interface{}, limit int32) ([]state.Key, error)
+
+		// Counter returns conflict-free counter of mapped entries, defined in mapping
+		Counter(schema interface{}, name string, groupValues ...string) (*state.Counter, error)
+
+		// Mappings returns state mappings
+		Mappings() StateMappings
 	}
 
 	Impl struct {
 		state.State
 		mappings StateMappings
+
+		// event for change events of mapped entries
+		event func() state.Event
 	}
 )
 
@@ -44,6 +91,10 @@ func WrapState(s state.State, mappings StateMappings) *Impl {
 	}
 }
 
+func (s *Impl) Mappings() StateMappings {
+	return s.mappings
+}
+
 func (s *Impl) MappingNamespace(schema interface{}) (state.Key, error) {
 	m, err := s.mappings.Get(schema)
 	if err != nil {
@@ -54,6 +105,12 @@ func (s *Impl) MappingNamespace(schema interface{}) (state.Key, error) {
 }
 
 func (s *Impl) Get(entry interface{}, target ...interface{}) (interface{}, error) {
+	// expired entries are treated as not existing
+	return s.notExpired(s.get(entry, target...))
+}
+
+// get returns entry from state, including expired
+func (s *Impl) get(entry interface{}, target ...interface{}) (interface{}, error) {
 	mapped, err := s.mappings.Map(entry)
 	if err != nil { // mapping is not exists
 		return s.State.Get(entry, target...) // return as is
@@ -61,16 +118,23 @@ func (s *Impl) Get(entry interface{}, target ...interface{}) (interface{}, error
 
 	// target was not set, but we can knew about target from mapping
 	if len(target) == 0 {
-		var targetFromMapping interface{}
-		if mapped.Mapper().KeyerFor() != nil {
-			targetFromMapping = mapped.Mapper().KeyerFor()
-		} else {
-			targetFromMapping = mapped.Mapper().Schema()
-		}
-		target = append(target, targetFromMapping)
+		target = append(target, targetFromMapping(mapped))
+	}
+
+	result, err := s.stateFor(mapped.Mapper()).Get(mapped, target...)
+	if err != nil {
+		return nil, err
 	}
 
-	return s.State.Get(mapped, target...)
+	return result, s.decryptEntry(result)
+}
+
+// targetFromMapping returns target type for mapped entry, if entry is keyer - target is keyer for schema
+func targetFromMapping(mapped *StateInstance) interface{} {
+	if mapped.Mapper().KeyerFor() != nil {
+		return mapped.Mapper().KeyerFor()
+	}
+	return mapped.Mapper().Schema()
 }
 
 func (s *Impl) GetHistory(entry interface{}, target interface{}) (state.HistoryEntryList, error) {
@@ -79,7 +143,100 @@ func (s *Impl) GetHistory(entry interface{}, target interface{}) (state.HistoryE
 		return s.State.GetHistory(entry, target) // return as is
 	}
 
-	return s.State.GetHistory(mapped, target)
+	if err = historySupported(mapped); err != nil {
+		return nil, err
+	}
+
+	if target == nil {
+		target = targetFromMapping(mapped)
+	}
+
+	list, err := s.State.GetHistory(mapped, target)
+	if err != nil {
+		return nil, err
+	}
+	return list, s.decryptHistory(list)
+}
+
+func (s *Impl) GetHistoryPaginated(
+	entry interface{}, target interface{}, pageSize int32, bookmark string, opts ...state.HistoryOpt) (
+	state.HistoryEntryList, *pb.QueryResponseMetadata, error) {
+	mapped, err := s.mappings.Map(entry)
+	if err != nil { // mapping is not exists
+		return s.State.GetHistoryPaginated(entry, target, pageSize, bookmark, opts...) // return as is
+	}
+
+	if err = historySupported(mapped); err != nil {
+		return nil, nil, err
+	}
+
+	if target == nil {
+		target = targetFromMapping(mapped)
+	}
+
+	list, md, err := s.State.GetHistoryPaginated(mapped, target, pageSize, bookmark, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return list, md, s.decryptHistory(list)
+}
+
+func (s *Impl) GetHistoryList(entry interface{}, pageSize int32, bookmark string, opts ...state.HistoryOpt) (
+	proto.Message, *pb.QueryResponseMetadata, error) {
+	mapped, err := s.mappings.Map(entry)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list, md, err := s.GetHistoryPaginated(mapped.instance, targetFromMapping(mapped), pageSize, bookmark, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// entry can be primary key schema, history list is defined in mapping of schema, primary key is for
+	historyList := mapped.Mapper().HistoryList()
+	if keyerFor := mapped.Mapper().KeyerFor(); keyerFor != nil {
+		if schemaMapper, err := s.mappings.Get(keyerFor); err == nil {
+			historyList = schemaMapper.HistoryList()
+		}
+	}
+
+	listProto, err := list.Proto(historyList)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return listProto, md, nil
+}
+
+func (s *Impl) GetAt(entry interface{}, at time.Time, target ...interface{}) (interface{}, error) {
+	mapped, err := s.mappings.Map(entry)
+	if err != nil { // mapping is not exists
+		return s.State.GetAt(entry, at, target...) // return as is
+	}
+
+	if err = historySupported(mapped); err != nil {
+		return nil, err
+	}
+
+	if len(target) == 0 {
+		target = append(target, targetFromMapping(mapped))
+	}
+
+	result, err := s.State.GetAt(mapped, at, target...)
+	if err != nil {
+		return nil, err
+	}
+	return result, s.decryptEntry(result)
+}
+
+// historySupported returns error if mapped entries are stored in private data collection,
+// history of private data is not available for chaincode
+func historySupported(mapped *StateInstance) error {
+	if c := mapped.Mapper().Collection(); c != nil {
+		return fmt.Errorf(`%w: collection=%s`, ErrCollectionHistoryNotSupported, c.Name)
+	}
+	return nil
 }
 
 func (s *Impl) Exists(entry interface{}) (bool, error) {
@@ -88,15 +245,54 @@ func (s *Impl) Exists(entry interface{}) (bool, error) {
 		return s.State.Exists(entry) // return as is
 	}
 
-	return s.State.Exists(mapped)
+	exists, err := s.exists(mapped)
+	if err != nil || !exists || mapped.Mapper().Expiry() == nil {
+		return exists, err
+	}
+
+	// expired entries are treated as not existing
+	existing, err := s.get(entry)
+	if err != nil {
+		return false, err
+	}
+	expired, err := s.isExpired(existing)
+	return !expired, err
 }
 
 func (s *Impl) Put(entry interface{}, value ...interface{}) error {
-	mapped, err := s.mappings.Map(entry)
+	// fill expiry field from ttl, if defined in mapping
+	if err := s.fillExpiry(entry); err != nil {
+		return err
+	}
+
+	// encryption errors must not fall back to unmapped state, entry can be stored as plaintext
+	encrypted, err := s.encryptEntry(entry)
+	if err != nil {
+		return err
+	}
+
+	mapped, err := s.mappings.Map(encrypted)
 	if err != nil { // mapping is not exists
 		return s.State.Put(entry, value...) // return as is
 	}
 
+	st := s.stateFor(mapped.Mapper())
+
+	prevValueHash, err := s.prevValueHash(mapped)
+	if err != nil {
+		return err
+	}
+
+	var prevMapped *StateInstance
+	if len(mapped.Mapper().Indexes()) > 0 || len(mapped.Mapper().Counters()) > 0 || len(mapped.Mapper().Refs()) > 0 {
+		//get previous entry value, expired entry key refs are also replaced
+		if prevEntry, err := s.get(entry); err == nil { // prev exists
+			if prevMapped, err = s.mapEncrypted(prevEntry); err != nil {
+				return errors.Wrap(err, `get prev`)
+			}
+		}
+	}
+
 	// update ref keys
 	if len(mapped.Mapper().Indexes()) > 0 {
 		keyRefs, err := mapped.Keys() // key refs based on current entry value, defined by mapping indexes
@@ -105,16 +301,10 @@ func (s *Impl) Put(entry interface{}, value ...interface{}) error {
 		}
 
 		var insertKeyRefs, deleteKeyRefs []state.KeyValue
-		//get previous entry value
-		prevEntry, err := s.Get(entry)
 
-		if err == nil { // prev exists
+		if prevMapped != nil { // prev exists
 
 			// prev entry exists, calculate refs to delete and to insert
-			prevMapped, err := s.mappings.Map(prevEntry)
-			if err != nil {
-				return errors.Wrap(err, `get prev`)
-			}
 			prevKeyRefs, err := prevMapped.Keys() // key refs based on current entry value, defined by mapping indexes
 			if err != nil {
 				return errors.Wrap(err, `previ keys`)
@@ -132,28 +322,94 @@ func (s *Impl) Put(entry interface{}, value ...interface{}) error {
 
 		// delete previous key refs if key exists
 		for _, kr := range deleteKeyRefs {
-			if err = s.State.Delete(kr); err != nil {
+			if err = st.Delete(kr); err != nil {
 				return errors.Wrap(err, `delete previous mapping key ref`)
 			}
 		}
 
 		// insert new key refs
 		for _, kr := range insertKeyRefs {
-			if err = s.State.Insert(kr); err != nil {
+			if err = st.Insert(kr); err != nil {
 				return fmt.Errorf(`%s: %s`, ErrMappingUniqKeyExists, err)
 			}
 		}
 	}
 
-	return s.State.Put(mapped)
+	// check referenced entries and update back refs
+	if err = s.putRefs(mapped, prevMapped); err != nil {
+		return err
+	}
+
+	// update counters
+	if prevMapped != nil {
+		err = s.updateCounters(prevMapped, mapped)
+	} else {
+		err = s.addCounters(mapped, 1)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err = s.putPublic(mapped); err != nil {
+		return err
+	}
+
+	if err = st.Put(mapped); err != nil {
+		return err
+	}
+
+	op := schema.EntryChanged_UPDATE
+	if prevValueHash == nil {
+		op = schema.EntryChanged_CREATE
+	}
+	return s.changeEvent(op, mapped, prevValueHash)
 }
 
 func (s *Impl) Insert(entry interface{}, value ...interface{}) error {
-	mapped, err := s.mappings.Map(entry)
+	// fill primary key field from sequence, if defined in mapping
+	if err := s.fillSequence(entry); err != nil {
+		return err
+	}
+
+	// fill expiry field from ttl, if defined in mapping
+	if err := s.fillExpiry(entry); err != nil {
+		return err
+	}
+
+	// encryption errors must not fall back to unmapped state, entry can be stored as plaintext
+	encrypted, err := s.encryptEntry(entry)
+	if err != nil {
+		return err
+	}
+
+	mapped, err := s.mappings.Map(encrypted)
 	if err != nil { // mapping is not exists
 		return s.State.Insert(entry, value...) // return as is
 	}
 
+	st := s.stateFor(mapped.Mapper())
+
+	// expired entry is replaced with inserted entry
+	if mapped.Mapper().Expiry() != nil {
+		if prevEntry, err := s.get(entry); err == nil {
+			if expired, err := s.isExpired(prevEntry); err != nil {
+				return err
+			} else if expired {
+				return s.Put(entry)
+			}
+		}
+	}
+
+	// public stub or hash of private entry is checked for existence
+	if c := mapped.Mapper().Collection(); c != nil && c.Public != CollectionPublicNone {
+		if exists, err := s.exists(mapped); err != nil {
+			return err
+		} else if exists {
+			key, _ := mapped.Key()
+			return fmt.Errorf(`%w: %s`, state.ErrKeyAlreadyExists, key)
+		}
+	}
+
 	keyRefs, err := mapped.Keys() // key refs, defined by mapping indexes
 	if err != nil {
 		return err
@@ -161,12 +417,29 @@ func (s *Impl) Insert(entry interface{}, value ...interface{}) error {
 
 	// insert key refs, if key already exists - error returned
 	for _, kr := range keyRefs {
-		if err = s.State.Insert(kr); err != nil {
+		if err = st.Insert(kr); err != nil {
 			return fmt.Errorf(`%s: %s`, ErrMappingUniqKeyExists, err)
 		}
 	}
 
-	return s.State.Insert(mapped)
+	// check referenced entries and insert back refs
+	if err = s.putRefs(mapped, nil); err != nil {
+		return err
+	}
+
+	if err = s.putPublic(mapped); err != nil {
+		return err
+	}
+
+	if err = st.Insert(mapped); err != nil {
+		return err
+	}
+
+	if err = s.addCounters(mapped, 1); err != nil {
+		return err
+	}
+
+	return s.changeEvent(schema.EntryChanged_CREATE, mapped, nil)
 }
 
 func (s *Impl) List(entry interface{}, target ...interface{}) (interface{}, error) {
@@ -182,7 +455,7 @@ func (s *Impl) List(entry interface{}, target ...interface{}) (interface{}, erro
 	namespace := m.Namespace()
 	s.Logger().Debug(`state mapped LIST`, zap.String(`namespace`, namespace.String()))
 
-	return s.State.List(namespace, m.Schema(), m.List())
+	return s.unexpiredList(s.decryptedList(s.stateFor(m).List(namespace, m.Schema(), m.List())))
 }
 
 func (s *Impl) ListPaginated(entry interface{}, pageSize int32, bookmark string, target ...interface{}) (
@@ -200,7 +473,8 @@ func (s *Impl) ListPaginated(entry interface{}, pageSize int32, bookmark string,
 	s.Logger().Debug(`state mapped LIST`, zap.String(`namespace`, namespace.String()),
 		zap.Int32("pageSize", pageSize), zap.String("bookmark", bookmark))
 
-	return s.State.ListPaginated(namespace, pageSize, bookmark, m.Schema(), m.List())
+	return s.unexpiredListPaginated(s.decryptedListPaginated(
+		s.stateFor(m).ListPaginated(namespace, pageSize, bookmark, m.Schema(), m.List())))
 }
 
 func (s *Impl) ListWith(entry interface{}, key state.Key) (result interface{}, err error) {
@@ -215,7 +489,7 @@ func (s *Impl) ListWith(entry interface{}, key state.Key) (result interface{}, e
 	namespace := m.Namespace()
 	s.Logger().Debug(`state mapped LIST`, zap.String(`namespace`, namespace.String()), zap.String(`list`, namespace.Append(key).String()))
 
-	return s.State.List(namespace.Append(key), m.Schema(), m.List())
+	return s.unexpiredList(s.decryptedList(s.stateFor(m).List(namespace.Append(key), m.Schema(), m.List())))
 }
 
 func (s *Impl) ListPaginatedWith(
@@ -234,7 +508,8 @@ func (s *Impl) ListPaginatedWith(
 		zap.String(`namespace`, namespace.String()), zap.String(`list`, namespace.Append(key).String()),
 		zap.Int32("pageSize", pageSize), zap.String("bookmark", bookmark))
 
-	return s.State.ListPaginated(namespace.Append(key), pageSize, bookmark, m.Schema(), m.List())
+	return s.unexpiredListPaginated(s.decryptedListPaginated(
+		s.stateFor(m).ListPaginated(namespace.Append(key), pageSize, bookmark, m.Schema(), m.List())))
 }
 
 func (s *Impl) GetByUniqKey(
@@ -245,16 +520,198 @@ func (s *Impl) GetByUniqKey(
 func (s *Impl) GetByKey(
 	entry interface{}, idx string, idxVal []string, target ...interface{}) (result interface{}, err error) {
 
-	if !s.mappings.Exists(entry) {
+	m, err := s.mappings.Get(entry)
+	if err != nil {
 		return nil, ErrStateMappingNotFound
 	}
+	st := s.stateFor(m)
+
+	if idxVal, err = s.encryptIndexValue(m, idx, idxVal); err != nil {
+		return nil, err
+	}
 
-	keyRef, err := s.State.Get(NewKeyRefIDInstance(entry, idx, idxVal, s.Serializer()), &schema.KeyRef{})
+	keyRef, err := st.Get(NewKeyRefIDInstance(entry, idx, idxVal, s.Serializer()), &schema.KeyRef{})
 	if err != nil {
 		return nil, errors.Errorf(`%s: {%s}.%s: %s`, ErrIndexReferenceNotFound, mapKey(entry), idx, err)
 	}
 
-	return s.State.Get(keyRef.(*schema.KeyRef).PKey, target...)
+	result, err = s.notExpired(st.Get(keyRef.(*schema.KeyRef).PKey, target...))
+	if err != nil {
+		return nil, err
+	}
+
+	return result, s.decryptEntry(result)
+}
+
+func (s *Impl) ListByIndex(entry interface{}, idx string, idxVal []string) (interface{}, error) {
+	m, err := s.indexMapping(entry, idx)
+	if err != nil {
+		return nil, err
+	}
+
+	if idxVal, err = s.encryptIndexValue(m, idx, idxVal); err != nil {
+		return nil, err
+	}
+
+	prefix := KeyRefPrefix(entry, idx, idxVal)
+	s.Logger().Debug(`state mapped LIST by index`, zap.String(`prefix`, prefix.String()))
+
+	refs, err := s.stateFor(m).List(prefix, &schema.KeyRef{})
+	if err != nil {
+		return nil, errors.Wrap(err, `index refs`)
+	}
+
+	return s.listFromKeyRefs(m, refs)
+}
+
+func (s *Impl) ListByIndexPaginated(
+	entry interface{}, idx string, idxVal []string, pageSize int32, bookmark string) (
+	interface{}, *pb.QueryResponseMetadata, error) {
+	m, err := s.indexMapping(entry, idx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if idxVal, err = s.encryptIndexValue(m, idx, idxVal); err != nil {
+		return nil, nil, err
+	}
+
+	prefix := KeyRefPrefix(entry, idx, idxVal)
+	s.Logger().Debug(`state mapped LIST by index`, zap.String(`prefix`, prefix.String()),
+		zap.Int32("pageSize", pageSize), zap.String("bookmark", bookmark))
+
+	refs, md, err := s.stateFor(m).ListPaginated(prefix, pageSize, bookmark, &schema.KeyRef{})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, `index refs`)
+	}
+
+	list, err := s.listFromKeyRefs(m, refs)
+	return list, md, err
+}
+
+func (s *Impl) ListByRange(
+	entry interface{}, idx string, from, to interface{}, pageSize int32, bookmark string) (
+	interface{}, *pb.QueryResponseMetadata, error) {
+	m, err := s.indexMapping(entry, idx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fromKey, err := rangeBoundary(m, m.Index(idx), from)
+	if err != nil {
+		return nil, nil, fmt.Errorf(`range from: %w`, err)
+	}
+	toKey, err := rangeBoundary(m, m.Index(idx), to)
+	if err != nil {
+		return nil, nil, fmt.Errorf(`range to: %w`, err)
+	}
+
+	refs, md, err := s.stateFor(m).ListRange(
+		KeyRefPrefix(entry, idx, nil), fromKey, toKey, pageSize, bookmark, &schema.KeyRef{})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, `index refs`)
+	}
+
+	list, err := s.listFromKeyRefs(m, refs)
+	return list, md, err
+}
+
+// rangeBoundary converts range boundary to index key parts
+func rangeBoundary(m StateMapper, idx *StateIndex, boundary interface{}) (state.Key, error) {
+	switch b := boundary.(type) {
+	case nil:
+		return nil, nil
+	case state.Key:
+		return b, nil
+	case []string:
+		return b, nil
+	}
+
+	// instance of mapped schema
+	if mapKey(boundary) == mapKey(m.Schema()) {
+		keys, err := idx.Keyer(boundary)
+		if err != nil {
+			return nil, err
+		}
+		if len(keys) != 1 {
+			return nil, fmt.Errorf(`%w: index %s has %d keys`, ErrRangeBoundaryNotSupported, idx.Name, len(keys))
+		}
+		return keys[0], nil
+	}
+
+	// index field value
+	if len(idx.Fields) != 1 {
+		return nil, fmt.Errorf(`%w: index %s has %d fields`, ErrRangeBoundaryNotSupported, idx.Name, len(idx.Fields))
+	}
+	if idx.keyEncoders != nil {
+		return idx.keyEncoders.encode(idx.Fields[0], boundary)
+	}
+	return m.EncodeKey(idx.Fields[0], boundary)
+}
+
+// ListRange returns mapped entries with primary key in range [from, to],
+// from and to are primary key parts after mapping namespace
+func (s *Impl) ListRange(
+	entry interface{}, from, to state.Key, pageSize int32, bookmark string, target ...interface{}) (
+	interface{}, *pb.QueryResponseMetadata, error) {
+	if !s.mappings.Exists(entry) {
+		return s.State.ListRange(entry, from, to, pageSize, bookmark, target...)
+	}
+
+	m, err := s.mappings.Get(entry)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, `mapping`)
+	}
+
+	return s.unexpiredListPaginated(s.decryptedListPaginated(
+		s.stateFor(m).ListRange(m.Namespace(), from, to, pageSize, bookmark, m.Schema(), m.List())))
+}
+
+func (s *Impl) indexMapping(entry interface{}, idx string) (StateMapper, error) {
+	if !s.mappings.Exists(entry) {
+		return nil, ErrStateMappingNotFound
+	}
+	m, err := s.mappings.Get(entry)
+	if err != nil {
+		return nil, errors.Wrap(err, `mapping`)
+	}
+
+	if m.Index(idx) == nil {
+		return nil, fmt.Errorf(`%w: {%s}.%s`, ErrIndexNotExists, mapKey(entry), idx)
+	}
+
+	return m, nil
+}
+
+// listFromKeyRefs gets entries referenced by key refs and returns them as list, defined in mapping
+func (s *Impl) listFromKeyRefs(m StateMapper, refs interface{}) (interface{}, error) {
+	stateList, err := state.NewStateList(m.Schema(), m.List())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range refs.(*schema.List).Items {
+		keyRef := &schema.KeyRef{}
+		if err = ptypes.UnmarshalAny(item, keyRef); err != nil {
+			return nil, errors.Wrap(err, `index ref`)
+		}
+
+		entry, err := s.stateFor(m).Get(keyRef.PKey, m.Schema())
+		if err != nil {
+			return nil, errors.Wrap(err, `indexed entry`)
+		}
+		if expired, err := s.isExpired(entry); err != nil {
+			return nil, err
+		} else if expired {
+			continue
+		}
+		if err = s.decryptEntry(entry); err != nil {
+			return nil, err
+		}
+		stateList.AddElementToList(entry)
+	}
+
+	return stateList.Get()
 }
 
 func (s *Impl) Delete(entry interface{}) error {
@@ -262,38 +719,91 @@ func (s *Impl) Delete(entry interface{}) error {
 		return s.State.Delete(entry) // return as is
 	}
 
+	return s.delete(entry, make(map[string]bool))
+}
+
+// delete deletes mapped entry with key refs and applies on delete behavior to referencing entries,
+// deleting contains keys of entries already being deleted by cascade
+func (s *Impl) delete(entry interface{}, deleting map[string]bool) error {
 	// we need full entry data fro state
 	// AND entry can be record to delete or reference to record
 	// If entry is keyer entity for another entry (reference)
-	entry, err := s.Get(entry)
+	entry, err := s.get(entry)
 	if err != nil {
 		return err
 	}
 
-	mapped, err := s.mappings.Map(entry)
+	mapped, err := s.mapEncrypted(entry)
 	if err != nil {
 		return err
 	}
 
+	key, err := mapped.Key()
+	if err != nil {
+		return err
+	}
+	deleting[key.String()] = true
+
+	if err = s.deleteReferencing(mapped, deleting); err != nil {
+		return err
+	}
+
+	if err = s.deleteRefs(mapped); err != nil {
+		return err
+	}
+
 	keyRefs, err := mapped.Keys() // additional keys
 	if err != nil {
 		return err
 	}
 
+	st := s.stateFor(mapped.Mapper())
+
 	// delete uniq key refs
 	for _, kr := range keyRefs {
-		if err = s.State.Delete(kr); err != nil {
+		if err = st.Delete(kr); err != nil {
 			return errors.Wrap(err, `delete ref key`)
 		}
 	}
 
-	return s.State.Delete(mapped)
+	if err = s.addCounters(mapped, -1); err != nil {
+		return err
+	}
+
+	if err = s.deletePublic(mapped); err != nil {
+		return err
+	}
+
+	prevValueHash, err := s.prevValueHash(mapped)
+	if err != nil {
+		return err
+	}
+
+	if err = st.Delete(mapped); err != nil {
+		return err
+	}
+
+	return s.changeEvent(schema.EntryChanged_DELETE, mapped, prevValueHash)
 }
 
 func (s *Impl) Logger() *zap.Logger {
 	return s.State.Logger()
 }
 
+// Unwrap returns wrapped state
+func (s *Impl) Unwrap() state.State {
+	return s.State
+}
+
+// Clone returns mapped state with clone of wrapped state
+func (s *Impl) Clone() state.State {
+	return &Impl{
+		State:    s.State.Clone(),
+		mappings: s.mappings,
+		event:    s.event,
+	}
+}
+
 func (s *Impl) UseKeyTransformer(kt state.KeyTransformer) {
 	s.State.UseKeyTransformer(kt)
 }
@@ -383,3 +893,31 @@ func (s *Impl) ExistsPrivate(collection string, entry interface{}) (exists bool,
 
 	return s.State.ExistsPrivate(collection, mapped)
 }
+
+// GetHash returns hash of mapped entry value as stored in public state or private data collection
+func (s *Impl) GetHash(entry interface{}) ([]byte, error) {
+	mapped, err := s.mappings.Map(entry)
+	if err != nil { // mapping is not exists
+		return s.State.GetHash(entry) // return as is
+	}
+
+	return s.stateFor(mapped.Mapper()).GetHash(mapped)
+}
+
+func (s *Impl) GetPrivateHash(collection string, entry interface{}) ([]byte, error) {
+	mapped, err := s.mappings.Map(entry)
+	if err != nil { // mapping is not exists
+		return s.State.GetPrivateHash(collection, entry) // return as is
+	}
+
+	return s.State.GetPrivateHash(collection, mapped)
+}
+
+func (s *Impl) VerifyPrivate(collection string, entry interface{}, value ...interface{}) (bool, error) {
+	mapped, err := s.mappings.Map(entry)
+	if err != nil { // mapping is not exists
+		return s.State.VerifyPrivate(collection, entry, value...) // return as is
+	}
+
+	return s.State.VerifyPrivate(collection, mapped, value...)
+}
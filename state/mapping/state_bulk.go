@@ -0,0 +1,199 @@
+package mapping
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger-labs/cckit/state"
+	"github.com/hyperledger-labs/cckit/state/schema"
+)
+
+// bulkUniqKeys tracks uniq key refs of entries in batch
+type bulkUniqKeys map[string]state.Key
+
+// PutMany validates all entries (primary and uniq keys, uniq key collisions in batch and with state),
+// then puts entries to state in primary key order. Unmapped entries in batch are validated and put as is
+func (s *Impl) PutMany(entries ...interface{}) (state.BulkResults, error) {
+	if !s.anyMapped(entries) {
+		return s.State.PutMany(entries...)
+	}
+
+	uniqKeys := make(bulkUniqKeys)
+	op := &state.BulkOp{
+		Key: s.bulkKey,
+		Check: func(entry interface{}, key state.Key) error {
+			if !s.mappings.Exists(entry) {
+				_, err := s.Serializer().ToBytesFrom(entry)
+				return err
+			}
+			return s.checkUniqKeys(entry, key, uniqKeys, false)
+		},
+		Apply: func(entry interface{}) error {
+			return s.Put(entry)
+		},
+	}
+
+	return op.Run(entries)
+}
+
+// InsertMany validates all entries (primary and uniq keys, uniq key collisions in batch and with state,
+// primary key existence in state), then inserts entries to state in primary key order.
+// Unmapped entries in batch are validated and inserted as is
+func (s *Impl) InsertMany(entries ...interface{}) (state.BulkResults, error) {
+	if !s.anyMapped(entries) {
+		return s.State.InsertMany(entries...)
+	}
+
+	// fill primary key fields with next sequence values in batch order for keys validation,
+	// sequences are incremented only if batch is valid
+	sequences, err := s.peekSequences(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	uniqKeys := make(bulkUniqKeys)
+	op := &state.BulkOp{
+		Key: s.bulkKey,
+		Check: func(entry interface{}, key state.Key) error {
+			if exists, err := s.Exists(entry); err != nil {
+				return err
+			} else if exists {
+				return fmt.Errorf(`%w: %s`, state.ErrKeyAlreadyExists, key)
+			}
+			if !s.mappings.Exists(entry) {
+				_, err := s.Serializer().ToBytesFrom(entry)
+				return err
+			}
+			return s.checkUniqKeys(entry, key, uniqKeys, true)
+		},
+		Prepare: sequences.consume,
+		Apply: func(entry interface{}) error {
+			return s.Insert(entry)
+		},
+	}
+
+	results, err := op.Run(entries)
+	if err != nil && errors.Is(err, state.ErrBulkValidationFailed) {
+		sequences.reset()
+	}
+	return results, err
+}
+
+// DeleteMany validates all entries exist in state, then deletes entries and its uniq key refs in primary key order
+func (s *Impl) DeleteMany(entries ...interface{}) (state.BulkResults, error) {
+	if !s.anyMapped(entries) {
+		return s.State.DeleteMany(entries...)
+	}
+
+	op := &state.BulkOp{
+		Key: s.bulkKey,
+		Check: func(entry interface{}, key state.Key) error {
+			if exists, err := s.Exists(entry); err != nil {
+				return err
+			} else if !exists {
+				return fmt.Errorf(`%w: %s`, state.ErrKeyNotFound, key)
+			}
+			return nil
+		},
+		Apply: s.Delete,
+	}
+
+	return op.Run(entries)
+}
+
+func (s *Impl) anyMapped(entries []interface{}) bool {
+	for _, entry := range entries {
+		if s.mappings.Exists(entry) {
+			return true
+		}
+	}
+	return false
+}
+
+// bulkKey returns primary key of mapped entry or state key of unmapped entry
+func (s *Impl) bulkKey(entry interface{}) (state.Key, error) {
+	if !s.mappings.Exists(entry) {
+		return s.unmappedKey(entry)
+	}
+
+	mapped, err := s.mappings.Map(entry)
+	if err != nil {
+		return nil, err
+	}
+	return mapped.Key()
+}
+
+// unmappedKey returns key of unmapped entry, as it is normalized by underlying state
+func (s *Impl) unmappedKey(entry interface{}) (state.Key, error) {
+	if keyer, ok := s.State.(interface {
+		Key(interface{}) (*state.TransformedKey, error)
+	}); ok {
+		trKey, err := keyer.Key(entry)
+		if err != nil {
+			return nil, err
+		}
+		return trKey.Origin, nil
+	}
+	// string key can't be split to parts without stub
+	if _, ok := entry.(string); ok {
+		return nil, fmt.Errorf(`%w: string key of unmapped entry`, state.ErrUnableToCreateStateKey)
+	}
+	return state.NormalizeKey(nil, entry)
+}
+
+// checkUniqKeys checks uniq keys of entry are filled, not duplicated in batch and not used by another entry in state
+func (s *Impl) checkUniqKeys(entry interface{}, pKey state.Key, uniqKeys bulkUniqKeys, insert bool) error {
+	mapped, err := s.mapEncrypted(entry)
+	if err != nil {
+		return err
+	}
+
+	for _, idx := range mapped.Mapper().Indexes() {
+		idxKeys, err := idx.Keyer(mapped.instance)
+		if err != nil {
+			return fmt.Errorf(`uniq key %s: %w`, idx.Name, err)
+		}
+
+		for _, idxKey := range idxKeys {
+			if idx.Required && strings.Join(idxKey, ``) == `` {
+				return fmt.Errorf(`uniq key %s: %w`, idx.Name, state.ErrKeyPartEmpty)
+			}
+
+			// non-unique index values can be shared between entries
+			if !idx.Uniq {
+				continue
+			}
+
+			keyRef := NewKeyRefInstance(mapped.Mapper().Schema(), idx.Name, idxKey, pKey)
+			keyRefKey, err := keyRef.Key()
+			if err != nil {
+				return err
+			}
+
+			// uniq key collision in batch
+			keyRefStr := keyRefKey.String()
+			if refPKey, ok := uniqKeys[keyRefStr]; ok {
+				return fmt.Errorf(`%w: %s=%s, used by %s in batch`,
+					ErrMappingUniqKeyExists, idx.Name, idxKey, refPKey)
+			}
+			uniqKeys[keyRefStr] = pKey
+
+			// uniq key collision with state
+			existing, err := s.stateFor(mapped.Mapper()).Get(keyRef, &schema.KeyRef{})
+			if err != nil {
+				if errors.Is(err, state.ErrKeyNotFound) {
+					continue
+				}
+				return err
+			}
+
+			refPKey := state.Key(existing.(*schema.KeyRef).PKey)
+			if insert || refPKey.String() != pKey.String() {
+				return fmt.Errorf(`%w: %s=%s, used by %s`, ErrMappingUniqKeyExists, idx.Name, idxKey, refPKey)
+			}
+		}
+	}
+
+	return nil
+}
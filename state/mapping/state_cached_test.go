@@ -0,0 +1,110 @@
+package mapping_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/cckit/state"
+	"github.com/hyperledger-labs/cckit/state/mapping"
+	"github.com/hyperledger-labs/cckit/state/mapping/testdata/schema"
+	testcc "github.com/hyperledger-labs/cckit/testing"
+)
+
+var _ = Describe(`State mapping with cache`, func() {
+
+	var (
+		mappings = mapping.StateMappings{}.Add(&schema.EntityWithIndexes{},
+			mapping.PKeyId(),
+			mapping.List(&schema.EntityWithIndexesList{}),
+			mapping.UniqKey(`ExternalId`))
+
+		cc, ctx = testcc.NewTxHandler(`mapping_cached`)
+	)
+
+	It("Allow to read mapped entries written in same tx", func() {
+		cc.Tx(func() {
+			cached, err := state.WithCache(mapping.WrapState(ctx.State(), mappings))
+			Expect(err).NotTo(HaveOccurred())
+			// mapped state clone with cache
+			s := cached.State.(*mapping.Impl)
+
+			Expect(s.Insert(&schema.EntityWithIndexes{Id: `aaa`, ExternalId: `aaa_ext`})).To(Succeed())
+			Expect(cached.Insert(&schema.EntityWithIndexes{Id: `bbb`, ExternalId: `bbb_ext`})).To(Succeed())
+			Expect(s.Insert(&schema.EntityWithIndexes{Id: `ccc`, ExternalId: `ccc_ext`})).To(Succeed())
+
+			// uniq key is checked against key refs written in same tx
+			Expect(s.Insert(&schema.EntityWithIndexes{Id: `ddd`, ExternalId: `aaa_ext`})).To(HaveOccurred())
+
+			e, err := s.Get(&schema.EntityWithIndexes{Id: `aaa`})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(e.(*schema.EntityWithIndexes).ExternalId).To(Equal(`aaa_ext`))
+
+			e, err = s.GetByKey(&schema.EntityWithIndexes{}, `ExternalId`, []string{`bbb_ext`}, &schema.EntityWithIndexes{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(e.(*schema.EntityWithIndexes).Id).To(Equal(`bbb`))
+
+			list, md, err := s.ListPaginated(&schema.EntityWithIndexes{}, 2, ``)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.(*schema.EntityWithIndexesList).Items).To(HaveLen(2))
+
+			list, md, err = s.ListPaginated(&schema.EntityWithIndexes{}, 2, md.Bookmark)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.(*schema.EntityWithIndexesList).Items).To(HaveLen(1))
+			Expect(md.Bookmark).To(BeEmpty())
+		})
+	})
+
+	It("Allow to read mapped entries deleted in same tx", func() {
+		cc.Tx(func() {
+			cached, err := state.WithCache(mapping.WrapState(ctx.State(), mappings))
+			Expect(err).NotTo(HaveOccurred())
+			s := cached.State.(*mapping.Impl)
+
+			Expect(s.Delete(&schema.EntityWithIndexes{Id: `aaa`})).To(Succeed())
+
+			exists, err := s.Exists(&schema.EntityWithIndexes{Id: `aaa`})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+
+			list, err := s.List(&schema.EntityWithIndexes{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.(*schema.EntityWithIndexesList).Items).To(HaveLen(2))
+
+			// uniq key of deleted entry can be used in same tx
+			Expect(s.Insert(&schema.EntityWithIndexes{Id: `ddd`, ExternalId: `aaa_ext`})).To(Succeed())
+		})
+	})
+
+	It("Allow to use cache without changing source state", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			cached, err := state.WithCache(s)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(cached.Insert(&schema.EntityWithIndexes{Id: `eee`, ExternalId: `eee_ext`})).To(Succeed())
+
+			exists, err := cached.Exists(&schema.EntityWithIndexes{Id: `eee`})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeTrue())
+
+			// source state is not cached, so entry written in current tx is not visible
+			exists, err = s.Exists(&schema.EntityWithIndexes{Id: `eee`})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+		})
+	})
+
+	It("Disallow to use cache with state, not allowing to unwrap state implementation", func() {
+		_, err := state.WithCache(&notUnwrappableState{State: ctx.State()})
+		Expect(err).To(MatchError(ContainSubstring(state.ErrStateCacheNotSupported.Error())))
+	})
+})
+
+// notUnwrappableState state wrapper without Unwrap method
+type notUnwrappableState struct {
+	state.State
+}
+
+func (s *notUnwrappableState) Clone() state.State {
+	return &notUnwrappableState{State: s.State.Clone()}
+}
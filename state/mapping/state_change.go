@@ -0,0 +1,89 @@
+package mapping
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/hyperledger-labs/cckit/state"
+	"github.com/hyperledger-labs/cckit/state/schema"
+)
+
+const (
+	// ChangeEventName name of event, emitted on change of mapped entry with change events option
+	ChangeEventName = `EntryChanged`
+)
+
+// ChangeEventMappings event mappings for resolving change events, for example on gateway side
+var ChangeEventMappings = EventMappings{}.Add(&schema.EntryChanged{})
+
+// WithChangeEvents emits EntryChanged event on create, update or delete of mapped entry.
+// Mapped state must have event, set with UseEvent (MapStates middleware sets context event)
+func WithChangeEvents() StateMappingOpt {
+	return func(sm *StateMapping, smm StateMappings) {
+		sm.changeEvents = true
+	}
+}
+
+func (sm *StateMapping) ChangeEvents() bool {
+	return sm.changeEvents
+}
+
+// UseEvent sets event for change events of mapped entries. Event is got on each change,
+// so middleware, replacing context event, can be used after MapStates
+func (s *Impl) UseEvent(event func() state.Event) *Impl {
+	s.event = event
+	return s
+}
+
+// prevValueHash returns hash of entry value as stored in state before change, nil if entry not exists
+// or change events are not defined in mapping. Hash of entry stored in private data collection is hash from ledger
+func (s *Impl) prevValueHash(mapped *StateInstance) ([]byte, error) {
+	if !mapped.Mapper().ChangeEvents() {
+		return nil, nil
+	}
+
+	hash, err := s.stateFor(mapped.Mapper()).GetHash(mapped)
+	if err != nil {
+		if errors.Is(err, state.ErrKeyNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return hash, nil
+}
+
+// changeEvent emits change event of mapped entry, if defined in mapping. Value in event is the same as in state,
+// encrypted fields are not exposed, value of entry stored in private data collection is not included
+func (s *Impl) changeEvent(op schema.EntryChanged_Operation, mapped *StateInstance, prevValueHash []byte) error {
+	m := mapped.Mapper()
+	if !m.ChangeEvents() {
+		return nil
+	}
+	if s.event == nil {
+		return fmt.Errorf(`%w: %s`, ErrChangeEventNotDefined, mapKey(m.Schema()))
+	}
+
+	key, err := mapped.Key()
+	if err != nil {
+		return err
+	}
+
+	changed := &schema.EntryChanged{
+		Operation:     op,
+		Schema:        schemaName(m.Schema()),
+		Namespace:     m.Namespace(),
+		PKey:          key,
+		PrevValueHash: prevValueHash,
+	}
+
+	if msg, ok := mapped.instance.(proto.Message); ok && op != schema.EntryChanged_DELETE && m.Collection() == nil {
+		if changed.Value, err = ptypes.MarshalAny(msg); err != nil {
+			return err
+		}
+	}
+
+	return s.event().Set(ChangeEventName, changed)
+}
@@ -0,0 +1,165 @@
+package mapping_test
+
+import (
+	"errors"
+
+	"github.com/golang/protobuf/ptypes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/cckit/router"
+	"github.com/hyperledger-labs/cckit/serialize"
+	"github.com/hyperledger-labs/cckit/state"
+	"github.com/hyperledger-labs/cckit/state/mapping"
+	"github.com/hyperledger-labs/cckit/state/mapping/testdata/schema"
+	stateschema "github.com/hyperledger-labs/cckit/state/schema"
+	testcc "github.com/hyperledger-labs/cckit/testing"
+	expectcc "github.com/hyperledger-labs/cckit/testing/expect"
+)
+
+var _ = Describe(`State mapping change events`, func() {
+
+	var (
+		mappings = mapping.StateMappings{}.Add(&schema.EntityWithIndexes{},
+			mapping.PKeyId(),
+			mapping.List(&schema.EntityWithIndexesList{}),
+			mapping.UniqKey(`ExternalId`),
+			mapping.WithChangeEvents())
+
+		cc, ctx = testcc.NewTxHandler(`change_events`)
+
+		// changeEvent returns change event of last tx, resolved with change event mappings
+		changeEvent = func() *stateschema.EntryChanged {
+			e := cc.TxEvent()
+			Expect(e.EventName).To(Equal(mapping.ChangeEventName))
+			changed, err := mapping.ChangeEventMappings.Resolve(e.EventName, e.Payload, serialize.DefaultSerializer)
+			Expect(err).NotTo(HaveOccurred())
+			return changed.(*stateschema.EntryChanged)
+		}
+
+		prevBytes []byte
+	)
+
+	It("Allow to emit change event on insert", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings).UseEvent(ctx.Event)
+			Expect(s.Insert(&schema.EntityWithIndexes{Id: `aaa`, ExternalId: `aaa_ext`, Value: 1})).To(Succeed())
+		})
+
+		changed := changeEvent()
+		Expect(changed.Operation).To(Equal(stateschema.EntryChanged_CREATE))
+		Expect(changed.Schema).To(Equal(`EntityWithIndexes`))
+		Expect(changed.Namespace).To(Equal([]string{`EntityWithIndexes`}))
+		Expect(changed.PKey).To(Equal([]string{`EntityWithIndexes`, `aaa`}))
+		Expect(changed.PrevValueHash).To(BeEmpty())
+
+		value := &schema.EntityWithIndexes{}
+		Expect(ptypes.UnmarshalAny(changed.Value, value)).To(Succeed())
+		Expect(value.ExternalId).To(Equal(`aaa_ext`))
+
+		cc.Tx(func() {
+			key, err := ctx.Stub().CreateCompositeKey(`EntityWithIndexes`, []string{`aaa`})
+			Expect(err).NotTo(HaveOccurred())
+			// value as stored in state
+			prevBytes, err = ctx.Stub().GetState(key)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	It("Allow to emit change event on put with previous value hash", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings).UseEvent(ctx.Event)
+			Expect(s.Put(&schema.EntityWithIndexes{Id: `aaa`, ExternalId: `aaa_ext`, Value: 2})).To(Succeed())
+		})
+
+		changed := changeEvent()
+		Expect(changed.Operation).To(Equal(stateschema.EntryChanged_UPDATE))
+		Expect(changed.PrevValueHash).To(Equal(state.PrivateDataHash(prevBytes)))
+
+		value := &schema.EntityWithIndexes{}
+		Expect(ptypes.UnmarshalAny(changed.Value, value)).To(Succeed())
+		Expect(value.Value).To(Equal(int32(2)))
+	})
+
+	It("Allow to emit change event with hash of value as stored in state", func() {
+		versioned := serialize.NewVersionedSerializer(serialize.DefaultSerializer,
+			serialize.WithVersion(&schema.EntityWithIndexes{}, 1))
+
+		cc.Tx(func() {
+			key, err := ctx.Stub().CreateCompositeKey(`EntityWithIndexes`, []string{`aaa`})
+			Expect(err).NotTo(HaveOccurred())
+			bb, err := versioned.ToBytesFrom(&schema.EntityWithIndexes{Id: `aaa`, ExternalId: `aaa_ext`, Value: 3})
+			Expect(err).NotTo(HaveOccurred())
+			// stored value differs from value, decoded and serialized again
+			prevBytes = bb
+			Expect(ctx.Stub().PutState(key, prevBytes)).To(Succeed())
+		})
+
+		cc.Tx(func() {
+			hash, err := mapping.WrapState(ctx.State(), mappings).GetHash(&schema.EntityWithIndexes{Id: `aaa`})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hash).To(Equal(state.PrivateDataHash(prevBytes)))
+
+			st := ctx.State().Clone()
+			st.UseSerializer(versioned)
+			s := mapping.WrapState(st, mappings).UseEvent(ctx.Event)
+			Expect(s.Put(&schema.EntityWithIndexes{Id: `aaa`, ExternalId: `aaa_ext`, Value: 4})).To(Succeed())
+		})
+
+		Expect(changeEvent().PrevValueHash).To(Equal(state.PrivateDataHash(prevBytes)))
+	})
+
+	It("Allow to emit change event on delete", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings).UseEvent(ctx.Event)
+			Expect(s.Delete(&schema.EntityWithIndexes{Id: `aaa`})).To(Succeed())
+		})
+
+		changed := changeEvent()
+		Expect(changed.Operation).To(Equal(stateschema.EntryChanged_DELETE))
+		Expect(changed.PKey).To(Equal([]string{`EntityWithIndexes`, `aaa`}))
+		Expect(changed.Value).To(BeNil())
+		Expect(changed.PrevValueHash).NotTo(BeEmpty())
+	})
+
+	It("Disallow to change entries without event", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			err := s.Insert(&schema.EntityWithIndexes{Id: `bbb`, ExternalId: `bbb_ext`})
+			Expect(errors.Is(err, mapping.ErrChangeEventNotDefined)).To(BeTrue())
+		})
+	})
+
+	Context(`Chaincode with handler events`, func() {
+
+		var (
+			newChaincode = func() *router.Chaincode {
+				r := router.New(`change_events`).
+					Use(mapping.BatchEvents()).
+					Use(mapping.MapStates(mappings))
+
+				r.Invoke(`create`, func(c router.Context) (interface{}, error) {
+					entity := &schema.EntityWithIndexes{Id: `ccc`, ExternalId: `ccc_ext`}
+					if err := c.Event().Set(`EntityCreated`, entity); err != nil {
+						return nil, err
+					}
+					return entity, c.State().Insert(entity)
+				})
+				return router.NewChaincode(r)
+			}
+
+			changeCC = testcc.NewMockStub(`change_events`, newChaincode())
+		)
+
+		It("Allow to emit change events with handler events in one tx", func() {
+			expectcc.ResponseOk(changeCC.Invoke(`create`))
+			Expect(changeCC.ChaincodeEvent.EventName).To(Equal(state.EventBatchName))
+
+			events, err := state.UnpackEventBatch(changeCC.ChaincodeEvent.Payload)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(2))
+			Expect(events[0].Name).To(Equal(`EntityCreated`))
+			Expect(events[1].Name).To(Equal(mapping.ChangeEventName))
+		})
+	})
+})
@@ -0,0 +1,156 @@
+package mapping
+
+import (
+	"fmt"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+
+	"github.com/hyperledger-labs/cckit/state"
+)
+
+type (
+	// CollectionPublic defines what is stored in public state for entry of mapping with private collection
+	CollectionPublic int
+
+	// StateCollection private data collection, mapped entries are stored in
+	StateCollection struct {
+		Name   string
+		Public CollectionPublic
+	}
+
+	// collectionState routes state operations to private data collection
+	collectionState struct {
+		state.State
+		collection string
+	}
+)
+
+const (
+	// CollectionPublicNone nothing is stored in public state
+	CollectionPublicNone CollectionPublic = iota
+	// CollectionPublicStub collection name is stored in public state under entry key
+	CollectionPublicStub
+	// CollectionPublicHash SHA-256 hash of entry value is stored in public state under entry key
+	CollectionPublicHash
+)
+
+// WithCollection stores mapped entries, its key refs, back refs and counters in private data collection,
+// Put, Get, List, Delete and other mapped state methods use collection transparently
+func WithCollection(name string) StateMappingOpt {
+	return withCollection(name, CollectionPublicNone)
+}
+
+// WithCollectionStub stores mapped entries in private data collection and public stub under the same key,
+// so entry existence can be checked by parties without access to collection
+func WithCollectionStub(name string) StateMappingOpt {
+	return withCollection(name, CollectionPublicStub)
+}
+
+// WithCollectionHash stores mapped entries in private data collection and hash of entry value
+// in public state under the same key
+func WithCollectionHash(name string) StateMappingOpt {
+	return withCollection(name, CollectionPublicHash)
+}
+
+func withCollection(name string, public CollectionPublic) StateMappingOpt {
+	return func(sm *StateMapping, smm StateMappings) {
+		sm.collection = &StateCollection{Name: name, Public: public}
+	}
+}
+
+// Collection returns private data collection of mapped entries, nil if entries are stored in public state
+func (sm *StateMapping) Collection() *StateCollection {
+	return sm.collection
+}
+
+// stateFor returns state for mapped entries, public state or private data collection
+func (s *Impl) stateFor(m StateMapper) state.State {
+	if c := m.Collection(); c != nil {
+		return &collectionState{State: s.State, collection: c.Name}
+	}
+	return s.State
+}
+
+// exists checks mapped entry existence, public stub or hash is used if defined in mapping
+func (s *Impl) exists(mapped *StateInstance) (bool, error) {
+	if c := mapped.Mapper().Collection(); c != nil && c.Public != CollectionPublicNone {
+		return s.State.Exists(mapped)
+	}
+	return s.stateFor(mapped.Mapper()).Exists(mapped)
+}
+
+// putPublic puts public stub or hash of mapped entry, if defined in mapping
+func (s *Impl) putPublic(mapped *StateInstance) error {
+	c := mapped.Mapper().Collection()
+	if c == nil {
+		return nil
+	}
+
+	switch c.Public {
+	case CollectionPublicStub:
+		return s.State.Put(mapped, []byte(c.Name))
+	case CollectionPublicHash:
+		bb, err := mapped.ToBytes(s.Serializer())
+		if err != nil {
+			return err
+		}
+		return s.State.Put(mapped, state.PrivateDataHash(bb))
+	}
+	return nil
+}
+
+// deletePublic deletes public stub or hash of mapped entry, if defined in mapping
+func (s *Impl) deletePublic(mapped *StateInstance) error {
+	if c := mapped.Mapper().Collection(); c != nil && c.Public != CollectionPublicNone {
+		return s.State.Delete(mapped)
+	}
+	return nil
+}
+
+func (cs *collectionState) Get(entry interface{}, target ...interface{}) (interface{}, error) {
+	return cs.State.GetPrivate(cs.collection, entry, target...)
+}
+
+func (cs *collectionState) Exists(entry interface{}) (bool, error) {
+	return cs.State.ExistsPrivate(cs.collection, entry)
+}
+
+func (cs *collectionState) GetHash(entry interface{}) ([]byte, error) {
+	return cs.State.GetPrivateHash(cs.collection, entry)
+}
+
+func (cs *collectionState) Put(entry interface{}, value ...interface{}) error {
+	return cs.State.PutPrivate(cs.collection, entry, value...)
+}
+
+func (cs *collectionState) Insert(entry interface{}, value ...interface{}) error {
+	return cs.State.InsertPrivate(cs.collection, entry, value...)
+}
+
+func (cs *collectionState) Delete(entry interface{}) error {
+	return cs.State.DeletePrivate(cs.collection, entry)
+}
+
+func (cs *collectionState) Keys(namespace interface{}) ([]string, error) {
+	return cs.State.KeysPrivate(cs.collection, namespace)
+}
+
+func (cs *collectionState) KeysFrom(namespace interface{}, startKey string, limit int32) ([]string, string, error) {
+	return cs.State.KeysFromPrivate(cs.collection, namespace, startKey, limit)
+}
+
+func (cs *collectionState) List(namespace interface{}, target ...interface{}) (interface{}, error) {
+	return cs.State.ListPrivate(cs.collection, true, namespace, target...)
+}
+
+func (cs *collectionState) ListPaginated(
+	namespace interface{}, pageSize int32, bookmark string, target ...interface{}) (
+	interface{}, *pb.QueryResponseMetadata, error) {
+	return nil, nil, fmt.Errorf(`%w: collection=%s`, ErrCollectionPaginationNotSupported, cs.collection)
+}
+
+func (cs *collectionState) ListRange(
+	namespace interface{}, from, to state.Key, pageSize int32, bookmark string, target ...interface{}) (
+	interface{}, *pb.QueryResponseMetadata, error) {
+	return nil, nil, fmt.Errorf(`%w: collection=%s`, ErrCollectionPaginationNotSupported, cs.collection)
+}
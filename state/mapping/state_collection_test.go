@@ -0,0 +1,202 @@
+package mapping_test
+
+import (
+	"errors"
+
+	"github.com/golang/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/cckit/state"
+	"github.com/hyperledger-labs/cckit/state/mapping"
+	"github.com/hyperledger-labs/cckit/state/mapping/testdata/schema"
+	testcc "github.com/hyperledger-labs/cckit/testing"
+)
+
+var _ = Describe(`State mapping with private collection`, func() {
+
+	const collection = `private`
+
+	var (
+		mappings = mapping.StateMappings{}.Add(&schema.EntityWithIndexes{},
+			mapping.PKeyId(),
+			mapping.List(&schema.EntityWithIndexesList{}),
+			mapping.UniqKey(`ExternalId`),
+			mapping.WithCollectionHash(collection))
+
+		cc, ctx = testcc.NewTxHandler(`collection`)
+
+		entity = &schema.EntityWithIndexes{Id: `aaa`, ExternalId: `aaa_ext`, Value: 1}
+	)
+
+	It("Allow to insert entry to private collection", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(s.Insert(entity)).To(Succeed())
+			Expect(s.Insert(&schema.EntityWithIndexes{Id: `bbb`, ExternalId: `bbb_ext`, Value: 2})).To(Succeed())
+		})
+
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			err := s.Insert(&schema.EntityWithIndexes{Id: `aaa`, ExternalId: `ccc_ext`})
+			Expect(errors.Is(err, state.ErrKeyAlreadyExists)).To(BeTrue())
+
+			err = s.Insert(&schema.EntityWithIndexes{Id: `ccc`, ExternalId: `aaa_ext`})
+			Expect(err).To(MatchError(ContainSubstring(mapping.ErrMappingUniqKeyExists.Error())))
+		})
+	})
+
+	It("Allow to get entry and entry by uniq key from private collection", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			e, err := s.Get(&schema.EntityWithIndexes{Id: `aaa`})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(e.(proto.Message), entity)).To(BeTrue())
+
+			e, err = s.GetByKey(&schema.EntityWithIndexes{}, `ExternalId`, []string{`bbb_ext`}, &schema.EntityWithIndexes{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(e.(*schema.EntityWithIndexes).Id).To(Equal(`bbb`))
+
+			exists, err := s.Exists(&schema.EntityWithIndexes{Id: `aaa`})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeTrue())
+		})
+	})
+
+	It("Allow to check public hash of private entry", func() {
+		cc.Tx(func() {
+			// private entry is not stored in public state, only hash
+			hash, err := ctx.State().Get(state.Key{`EntityWithIndexes`, `aaa`})
+			Expect(err).NotTo(HaveOccurred())
+
+			bb, err := ctx.State().Serializer().ToBytesFrom(entity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hash).To(Equal(state.PrivateDataHash(bb)))
+
+			// uniq key refs are stored in private collection
+			exists, err := ctx.State().Exists(mapping.NewKeyRefIDInstance(
+				&schema.EntityWithIndexes{}, `ExternalId`, []string{`aaa_ext`}, nil))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+		})
+	})
+
+	It("Allow to list entries from private collection", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			list, err := s.List(&schema.EntityWithIndexes{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.(*schema.EntityWithIndexesList).Items).To(HaveLen(2))
+
+			_, _, err = s.ListPaginated(&schema.EntityWithIndexes{}, 1, ``)
+			Expect(errors.Is(err, mapping.ErrCollectionPaginationNotSupported)).To(BeTrue())
+		})
+	})
+
+	It("Disallow to get history of entry from private collection", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			_, err := s.GetHistory(&schema.EntityWithIndexes{Id: `aaa`}, nil)
+			Expect(errors.Is(err, mapping.ErrCollectionHistoryNotSupported)).To(BeTrue())
+
+			_, _, err = s.GetHistoryList(&schema.EntityWithIndexes{Id: `aaa`}, 0, ``)
+			Expect(errors.Is(err, mapping.ErrCollectionHistoryNotSupported)).To(BeTrue())
+		})
+	})
+
+	It("Allow to delete entry from private collection", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(s.Delete(&schema.EntityWithIndexes{Id: `aaa`})).To(Succeed())
+		})
+
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			exists, err := s.Exists(&schema.EntityWithIndexes{Id: `aaa`})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+
+			exists, err = ctx.State().ExistsPrivate(collection, state.Key{`EntityWithIndexes`, `aaa`})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+
+			list, err := s.List(&schema.EntityWithIndexes{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.(*schema.EntityWithIndexesList).Items).To(HaveLen(1))
+		})
+	})
+})
+
+var _ = Describe(`State mapping with private collection, refs and counters`, func() {
+
+	const collection = `private`
+
+	var (
+		mappings = mapping.StateMappings{}.
+				Add(&schema.Holder{}, mapping.PKeyId()).
+				Add(&schema.Policy{},
+				mapping.PKeyId(),
+				mapping.List(&schema.PolicyList{}),
+				mapping.Ref(&schema.Holder{}, `HolderId`),
+				mapping.Counter(`total`),
+				mapping.WithCollection(collection))
+
+		cc, ctx = testcc.NewTxHandler(`collection_refs`)
+
+		holder = &schema.Holder{Id: `holder`}
+		policy = &schema.Policy{Id: `policy`, HolderId: holder.Id}
+	)
+
+	counterValue := func(s mapping.MappedState) int64 {
+		counter, err := s.Counter(&schema.Policy{}, `total`)
+		Expect(err).NotTo(HaveOccurred())
+		value, err := counter.Value()
+		Expect(err).NotTo(HaveOccurred())
+		return value
+	}
+
+	It("Allow to insert private entry, referencing public entry", func() {
+		cc.Tx(func() {
+			Expect(mapping.WrapState(ctx.State(), mappings).Insert(holder)).To(Succeed())
+		})
+
+		cc.Tx(func() {
+			Expect(mapping.WrapState(ctx.State(), mappings).Insert(policy)).To(Succeed())
+		})
+	})
+
+	It("Allow to store back refs and counters of private entries in private collection", func() {
+		cc.Tx(func() {
+			for _, namespace := range []string{mapping.RefNamespace, state.CounterNamespace} {
+				keys, err := ctx.State().Keys(state.Key{namespace})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keys).To(BeEmpty())
+
+				keys, err = ctx.State().KeysPrivate(collection, state.Key{namespace})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keys).To(HaveLen(1))
+			}
+
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(counterValue(s)).To(BeNumerically("==", 1))
+
+			list, err := s.ListReferencing(holder, &schema.Policy{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.(*schema.PolicyList).Items).To(HaveLen(1))
+		})
+	})
+
+	It("Allow to delete back refs of private entry from private collection", func() {
+		cc.Tx(func() {
+			Expect(mapping.WrapState(ctx.State(), mappings).Delete(policy)).To(Succeed())
+		})
+
+		cc.Tx(func() {
+			keys, err := ctx.State().KeysPrivate(collection, state.Key{mapping.RefNamespace})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(BeEmpty())
+
+			Expect(counterValue(mapping.WrapState(ctx.State(), mappings))).To(BeNumerically("==", 0))
+		})
+	})
+})
@@ -0,0 +1,249 @@
+package mapping
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/hyperledger-labs/cckit/state"
+)
+
+type (
+	// StateCounter conflict-free counter of mapped entries, optionally grouped by field values,
+	// for example count of entries per status
+	StateCounter struct {
+		Name string
+		// Fields entry fields for grouping, can be empty
+		Fields []string
+	}
+
+	// StateSequence sequence for filling string primary key field of inserted entries with human-readable ids
+	StateSequence struct {
+		Field  string
+		Format string
+	}
+)
+
+// Counter defines conflict-free counter of mapped entries, counter is incremented on insert and decremented on delete.
+// If fields are defined, counter is grouped by fields values
+func Counter(name string, fields ...string) StateMappingOpt {
+	return func(sm *StateMapping, smm StateMappings) {
+		sm.counters = append(sm.counters, &StateCounter{Name: name, Fields: fields})
+	}
+}
+
+// Sequence fills empty string field of inserted entry with next sequence value, formatted with format,
+// for example `INV-%06d`
+func Sequence(field string, format string) StateMappingOpt {
+	return func(sm *StateMapping, smm StateMappings) {
+		sm.sequence = &StateSequence{Field: field, Format: format}
+	}
+}
+
+func (sm *StateMapping) Counters() []*StateCounter {
+	return sm.counters
+}
+
+func (sm *StateMapping) Counter(name string) *StateCounter {
+	for _, c := range sm.counters {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func (sm *StateMapping) Sequence() *StateSequence {
+	return sm.sequence
+}
+
+// CounterKey returns counter key for group values
+func (sm *StateMapping) CounterKey(name string, groupValues ...string) state.Key {
+	return append(append(state.Key{}, sm.namespace...), name).Append(groupValues)
+}
+
+// SequenceKey returns key of mapping sequence
+func (sm *StateMapping) SequenceKey() state.Key {
+	return append(state.Key{}, sm.namespace...)
+}
+
+// Counter returns counter of mapped entries, defined in mapping
+func (s *Impl) Counter(schema interface{}, name string, groupValues ...string) (*state.Counter, error) {
+	m, err := s.mappings.Get(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.Counter(name) == nil {
+		return nil, fmt.Errorf(`%w: %s`, ErrCounterNotFound, name)
+	}
+
+	return s.counter(m, m.CounterKey(name, groupValues...)), nil
+}
+
+// counter returns counter instance, stored with mapped entries in public state or private data collection.
+// Tx delta is shared by counter instances with same key
+func (s *Impl) counter(m StateMapper, key state.Key) *state.Counter {
+	return state.NewCounter(s.stateFor(m), key)
+}
+
+// addCounters adds delta to all counters defined in entry mapping
+func (s *Impl) addCounters(mapped *StateInstance, delta int64) error {
+	for _, c := range mapped.Mapper().Counters() {
+		groupValues, err := attrsKeyer(c.Fields)(mapped.instance)
+		if err != nil {
+			return fmt.Errorf(`counter %s: %w`, c.Name, err)
+		}
+
+		if err = s.counter(mapped.Mapper(), mapped.Mapper().CounterKey(c.Name, groupValues...)).Add(delta); err != nil {
+			return fmt.Errorf(`counter %s: %w`, c.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// updateCounters moves entry between counter groups if group values changed
+func (s *Impl) updateCounters(prevMapped, mapped *StateInstance) error {
+	for _, c := range mapped.Mapper().Counters() {
+		keyer := attrsKeyer(c.Fields)
+		prevValues, err := keyer(prevMapped.instance)
+		if err != nil {
+			return fmt.Errorf(`counter %s: %w`, c.Name, err)
+		}
+
+		values, err := keyer(mapped.instance)
+		if err != nil {
+			return fmt.Errorf(`counter %s: %w`, c.Name, err)
+		}
+
+		if prevValues.String() == values.String() {
+			continue
+		}
+
+		if err = s.counter(mapped.Mapper(), mapped.Mapper().CounterKey(c.Name, prevValues...)).Dec(); err != nil {
+			return err
+		}
+		if err = s.counter(mapped.Mapper(), mapped.Mapper().CounterKey(c.Name, values...)).Inc(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fillSequence sets empty sequence field of entry with next sequence value
+func (s *Impl) fillSequence(entry interface{}) error {
+	seq, field, err := s.sequenceField(entry)
+	if err != nil || seq == nil {
+		return err
+	}
+
+	// sequence value is shared by sequence instances with same key in tx
+	id, err := seq.NextID()
+	if err != nil {
+		return err
+	}
+
+	field.SetString(id)
+	return nil
+}
+
+// sequenceField returns sequence and empty sequence field of entry, nil sequence if entry
+// has no sequence defined in mapping or sequence field is already set
+func (s *Impl) sequenceField(entry interface{}) (*state.Sequence, reflect.Value, error) {
+	if !s.mappings.Exists(entry) {
+		return nil, reflect.Value{}, nil
+	}
+
+	m, err := s.mappings.Get(entry)
+	if err != nil {
+		return nil, reflect.Value{}, err
+	}
+
+	seq := m.Sequence()
+	if seq == nil {
+		return nil, reflect.Value{}, nil
+	}
+
+	v := reflect.Indirect(reflect.ValueOf(entry))
+	if v.Kind() != reflect.Struct {
+		return nil, reflect.Value{}, nil
+	}
+
+	field := v.FieldByName(seq.Field)
+	if !field.IsValid() {
+		return nil, reflect.Value{}, fmt.Errorf(`%w: %s`, ErrFieldNotExists, seq.Field)
+	}
+
+	if field.Kind() != reflect.String || !field.CanSet() {
+		return nil, reflect.Value{}, fmt.Errorf(`sequence field %s: %w`, seq.Field, ErrFieldTypeNotSupportedForSequence)
+	}
+
+	// field is already set
+	if field.String() != `` {
+		return nil, reflect.Value{}, nil
+	}
+
+	return state.NewSequence(s.stateFor(m), m.SequenceKey(), state.SequenceFormat(seq.Format)), field, nil
+}
+
+type (
+	// batchSequence sequence field of batch entry, filled with sequence value before batch validation
+	batchSequence struct {
+		seq   *state.Sequence
+		field reflect.Value
+	}
+
+	batchSequences []*batchSequence
+)
+
+// peekSequences fills empty sequence fields of entries in batch order with next sequence values,
+// sequences are not incremented
+func (s *Impl) peekSequences(entries []interface{}) (batchSequences, error) {
+	var (
+		filled  batchSequences
+		offsets = make(map[string]uint64)
+	)
+	for _, entry := range entries {
+		seq, field, err := s.sequenceField(entry)
+		if err != nil {
+			filled.reset()
+			return nil, err
+		}
+		if seq == nil {
+			continue
+		}
+
+		current, err := seq.Current()
+		if err != nil {
+			filled.reset()
+			return nil, err
+		}
+		m, _ := s.mappings.Get(entry)
+		seqKey := m.SequenceKey().String()
+		offsets[seqKey]++
+		field.SetString(seq.FormatID(current + offsets[seqKey]))
+
+		filled = append(filled, &batchSequence{seq: seq, field: field})
+	}
+	return filled, nil
+}
+
+// consume increments sequences, sequence fields are set with consumed values
+func (bs batchSequences) consume() error {
+	for _, filled := range bs {
+		id, err := filled.seq.NextID()
+		if err != nil {
+			return err
+		}
+		filled.field.SetString(id)
+	}
+	return nil
+}
+
+// reset clears sequence fields, filled with not consumed sequence values
+func (bs batchSequences) reset() {
+	for _, filled := range bs {
+		filled.field.SetString(``)
+	}
+}
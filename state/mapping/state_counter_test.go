@@ -0,0 +1,154 @@
+package mapping_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/cckit/state/mapping"
+	"github.com/hyperledger-labs/cckit/state/mapping/testdata/schema"
+	testcc "github.com/hyperledger-labs/cckit/testing"
+)
+
+var _ = Describe(`State mapping counters and sequences`, func() {
+
+	var (
+		mappings = mapping.StateMappings{}.Add(&schema.EntityWithIndexes{},
+			mapping.PKeyId(),
+			mapping.Sequence(`Id`, `ENT-%03d`),
+			mapping.Counter(`total`),
+			mapping.Counter(`byValue`, `Value`))
+
+		cc, ctx = testcc.NewTxHandler(`counters`)
+	)
+
+	counterValue := func(s mapping.MappedState, name string, groupValues ...string) int64 {
+		counter, err := s.Counter(&schema.EntityWithIndexes{}, name, groupValues...)
+		Expect(err).NotTo(HaveOccurred())
+		value, err := counter.Value()
+		Expect(err).NotTo(HaveOccurred())
+		return value
+	}
+
+	It("Allow to fill primary key from sequence on insert", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			e1 := &schema.EntityWithIndexes{Value: 1}
+			e2 := &schema.EntityWithIndexes{Value: 2}
+			Expect(s.Insert(e1)).To(Succeed())
+			Expect(s.Insert(e2)).To(Succeed())
+
+			Expect(e1.Id).To(Equal(`ENT-001`))
+			Expect(e2.Id).To(Equal(`ENT-002`))
+		})
+
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			_, err := s.InsertMany(&schema.EntityWithIndexes{Value: 1}, &schema.EntityWithIndexes{Id: `custom`, Value: 1})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		cc.Tx(func() {
+			_, err := mapping.WrapState(ctx.State(), mappings).Get(&schema.EntityWithIndexes{Id: `ENT-003`})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	It("Allow to count mapped entries, grouped by field values", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(counterValue(s, `total`)).To(BeNumerically("==", 4))
+			Expect(counterValue(s, `byValue`, `1`)).To(BeNumerically("==", 3))
+			Expect(counterValue(s, `byValue`, `2`)).To(BeNumerically("==", 1))
+		})
+	})
+
+	It("Allow to move entry between counter groups on update", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(s.Put(&schema.EntityWithIndexes{Id: `ENT-001`, Value: 2})).To(Succeed())
+		})
+
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(counterValue(s, `total`)).To(BeNumerically("==", 4))
+			Expect(counterValue(s, `byValue`, `1`)).To(BeNumerically("==", 2))
+			Expect(counterValue(s, `byValue`, `2`)).To(BeNumerically("==", 2))
+		})
+	})
+
+	It("Allow to decrement counters on delete", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(s.Delete(&schema.EntityWithIndexes{Id: `custom`})).To(Succeed())
+			// counter value in same tx includes tx delta
+			Expect(counterValue(s, `total`)).To(BeNumerically("==", 3))
+		})
+
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(counterValue(s, `total`)).To(BeNumerically("==", 3))
+			Expect(counterValue(s, `byValue`, `1`)).To(BeNumerically("==", 1))
+		})
+	})
+
+	It("Allow to use counters and sequences with several wrapped states in one tx", func() {
+		cc.Tx(func() {
+			e1 := &schema.EntityWithIndexes{Value: 1}
+			e2 := &schema.EntityWithIndexes{Value: 1}
+			Expect(mapping.WrapState(ctx.State(), mappings).Insert(e1)).To(Succeed())
+			Expect(mapping.WrapState(ctx.State(), mappings).Insert(e2)).To(Succeed())
+
+			Expect(e1.Id).To(Equal(`ENT-004`))
+			Expect(e2.Id).To(Equal(`ENT-005`))
+			Expect(counterValue(mapping.WrapState(ctx.State(), mappings), `total`)).To(BeNumerically("==", 5))
+		})
+
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(counterValue(s, `total`)).To(BeNumerically("==", 5))
+			Expect(counterValue(s, `byValue`, `1`)).To(BeNumerically("==", 3))
+		})
+	})
+
+	It("Allow to use wrapped state in several txs", func() {
+		s := mapping.WrapState(ctx.State(), mappings)
+		cc.Tx(func() {
+			Expect(s.Delete(&schema.EntityWithIndexes{Id: `ENT-004`})).To(Succeed())
+		})
+
+		cc.Tx(func() {
+			e := &schema.EntityWithIndexes{Value: 2}
+			Expect(s.Insert(e)).To(Succeed())
+			Expect(e.Id).To(Equal(`ENT-006`))
+			// delta of previous tx is not carried to current tx
+			Expect(counterValue(s, `total`)).To(BeNumerically("==", 5))
+		})
+
+		cc.Tx(func() {
+			Expect(counterValue(s, `total`)).To(BeNumerically("==", 5))
+			Expect(counterValue(s, `byValue`, `2`)).To(BeNumerically("==", 3))
+		})
+	})
+
+	It("Allow to keep sequence values, if batch validation failed", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			e := &schema.EntityWithIndexes{Value: 1}
+			// ENT-001 already exists
+			_, err := s.InsertMany(e, &schema.EntityWithIndexes{Id: `ENT-001`, Value: 1})
+			Expect(err).To(HaveOccurred())
+			Expect(e.Id).To(BeEmpty())
+
+			_, err = s.InsertMany(e)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(e.Id).To(Equal(`ENT-007`))
+		})
+	})
+
+	It("Disallow to get counter not defined in mapping", func() {
+		cc.Tx(func() {
+			_, err := mapping.WrapState(ctx.State(), mappings).Counter(&schema.EntityWithIndexes{}, `unknown`)
+			Expect(err).To(MatchError(mapping.ErrCounterNotFound))
+		})
+	})
+})
@@ -0,0 +1,346 @@
+package mapping
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/hyperledger-labs/cckit/serialize"
+	"github.com/hyperledger-labs/cckit/state"
+)
+
+const (
+	// EntryAdded entry exists only in second snapshot
+	EntryAdded DiffChange = `added`
+	// EntryRemoved entry exists only in first snapshot
+	EntryRemoved DiffChange = `removed`
+	// EntryChanged entry exists in both snapshots with different values
+	EntryChanged DiffChange = `changed`
+)
+
+type (
+	// Snapshot state entries, map key is state key joined with StringsIdToStr, value is raw state value
+	Snapshot map[string][]byte
+
+	// DiffChange type of entry change between snapshots
+	DiffChange string
+
+	// DiffOpts options of snapshots diff
+	DiffOpts struct {
+		mappings   StateMappings
+		serializer serialize.FromBytesConverter
+		ignored    []state.Key
+	}
+
+	DiffOpt func(*DiffOpts)
+
+	// FieldDiff changed field of mapped entry, path is proto field names separated with dot,
+	// values are decoded from JSON representation of entry, nil if field is absent
+	FieldDiff struct {
+		Path   string
+		Before interface{}
+		After  interface{}
+	}
+
+	// EntryDiff added, removed or changed state entry
+	EntryDiff struct {
+		Key    state.Key
+		Change DiffChange
+		Before []byte
+		After  []byte
+		// Fields of changed entry, if entry is mapped and values are decoded
+		Fields []*FieldDiff
+	}
+
+	// StateDiff entries diff, ordered by key
+	StateDiff struct {
+		Entries []*EntryDiff
+	}
+)
+
+// NewSnapshot returns snapshot of state entries with keys in namespaces, or whole key range if namespaces
+// are not set. Stub can be testing.MockStub. On peer range query doesn't return composite keys,
+// so namespaces must be set
+func NewSnapshot(stub shim.ChaincodeStubInterface, namespaces ...state.Key) (Snapshot, error) {
+	snapshot := make(Snapshot)
+	if len(namespaces) == 0 {
+		iter, err := stub.GetStateByRange(``, ``)
+		if err != nil {
+			return nil, fmt.Errorf(`snapshot: %w`, err)
+		}
+		return snapshot, snapshot.read(stub, iter)
+	}
+
+	for _, namespace := range namespaces {
+		objectType, attrs := namespace.Parts()
+		iter, err := stub.GetStateByPartialCompositeKey(objectType, attrs)
+		if err != nil {
+			return nil, fmt.Errorf(`snapshot namespace=%s: %w`, namespace, err)
+		}
+		if err = snapshot.read(stub, iter); err != nil {
+			return nil, err
+		}
+	}
+	return snapshot, nil
+}
+
+// NewFixturesSnapshot returns snapshot with expected state of mapped entries (without indexes),
+// values are serialized with toBytesConverter
+func NewFixturesSnapshot(mappings StateMappings, toBytesConverter serialize.ToBytesConverter,
+	entries ...interface{}) (Snapshot, error) {
+	snapshot := make(Snapshot)
+	for _, entry := range entries {
+		key, err := mappings.PrimaryKey(entry)
+		if err != nil {
+			return nil, err
+		}
+		value, err := toBytesConverter.ToBytesFrom(entry)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Put(key, value)
+	}
+	return snapshot, nil
+}
+
+func (s Snapshot) read(stub shim.ChaincodeStubInterface, iter shim.StateQueryIteratorInterface) error {
+	defer func() { _ = iter.Close() }()
+
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		if err != nil {
+			return err
+		}
+		key, err := state.KeyFromComposite(stub, kv.Key)
+		if err != nil {
+			return err
+		}
+		s.Put(key, kv.Value)
+	}
+	return nil
+}
+
+// Put sets entry value in snapshot
+func (s Snapshot) Put(key state.Key, value []byte) {
+	s[state.StringsIdToStr(key)] = value
+}
+
+// Get returns entry value from snapshot
+func (s Snapshot) Get(key state.Key) ([]byte, bool) {
+	value, ok := s[state.StringsIdToStr(key)]
+	return value, ok
+}
+
+// DiffMappings sets mappings for decoding values of entries, values are decoded with serializer,
+// by default with serialize.DefaultSerializer
+func DiffMappings(mappings StateMappings, serializer ...serialize.FromBytesConverter) DiffOpt {
+	return func(opts *DiffOpts) {
+		opts.mappings = mappings
+		if len(serializer) > 0 {
+			opts.serializer = serializer[0]
+		}
+	}
+}
+
+// DiffIgnore sets namespaces of entries, excluded from diff. By default, uniq key refs namespace is ignored
+func DiffIgnore(namespaces ...state.Key) DiffOpt {
+	return func(opts *DiffOpts) {
+		opts.ignored = namespaces
+	}
+}
+
+// Diff returns added, removed and changed entries of after snapshot, compared with before snapshot.
+// Changed mapped entries contain field level diff
+func Diff(before, after Snapshot, opts ...DiffOpt) (*StateDiff, error) {
+	diffOpts := &DiffOpts{
+		serializer: serialize.DefaultSerializer,
+		ignored:    []state.Key{{KeyRefNamespace}},
+	}
+	for _, opt := range opts {
+		opt(diffOpts)
+	}
+
+	keys := make(map[string]bool)
+	for k := range before {
+		keys[k] = true
+	}
+	for k := range after {
+		keys[k] = true
+	}
+
+	sortedKeys := make([]string, 0, len(keys))
+	for k := range keys {
+		sortedKeys = append(sortedKeys, k)
+	}
+	sort.Strings(sortedKeys)
+
+	diff := &StateDiff{}
+	for _, k := range sortedKeys {
+		key := state.Key(state.StringsIdFromStr(k))
+		if diffOpts.isIgnored(key) {
+			continue
+		}
+
+		beforeValue, inBefore := before[k]
+		afterValue, inAfter := after[k]
+
+		entry := &EntryDiff{Key: key, Before: beforeValue, After: afterValue}
+		switch {
+		case !inBefore:
+			entry.Change = EntryAdded
+		case !inAfter:
+			entry.Change = EntryRemoved
+		case bytes.Equal(beforeValue, afterValue):
+			continue
+		default:
+			entry.Change = EntryChanged
+			fields, err := diffOpts.fields(key, beforeValue, afterValue)
+			if err != nil {
+				return nil, fmt.Errorf(`diff key=%s: %w`, key, err)
+			}
+			entry.Fields = fields
+		}
+		diff.Entries = append(diff.Entries, entry)
+	}
+	return diff, nil
+}
+
+func (opts *DiffOpts) isIgnored(key state.Key) bool {
+	for _, namespace := range opts.ignored {
+		if len(key) >= len(namespace) && reflect.DeepEqual(key[:len(namespace)], namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+// fields returns field level diff of mapped entry, nil if entry is not mapped
+func (opts *DiffOpts) fields(key state.Key, before, after []byte) ([]*FieldDiff, error) {
+	if opts.mappings == nil || !opts.mappings.Exists([]string(key)) {
+		return nil, nil
+	}
+
+	beforeFields, err := opts.decodeFields(key, before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := opts.decodeFields(key, after)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []*FieldDiff
+	diffFields(``, beforeFields, afterFields, &fields)
+	return fields, nil
+}
+
+func (opts *DiffOpts) decodeFields(key state.Key, value []byte) (interface{}, error) {
+	entry, err := opts.mappings.Resolve(key[0], value, opts.serializer)
+	if err != nil {
+		return nil, err
+	}
+	msg, ok := entry.(proto.Message)
+	if !ok {
+		return nil, ErrEntryTypeNotSupported
+	}
+
+	bb, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(proto.MessageV2(msg))
+	if err != nil {
+		return nil, err
+	}
+
+	var fields interface{}
+	dec := json.NewDecoder(bytes.NewReader(bb))
+	dec.UseNumber()
+	if err = dec.Decode(&fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// diffFields compares JSON values recursively, objects by fields, arrays by items
+func diffFields(path string, before, after interface{}, fields *[]*FieldDiff) {
+	beforeMap, beforeIsMap := before.(map[string]interface{})
+	afterMap, afterIsMap := after.(map[string]interface{})
+	if beforeIsMap && afterIsMap {
+		names := make(map[string]bool)
+		for name := range beforeMap {
+			names[name] = true
+		}
+		for name := range afterMap {
+			names[name] = true
+		}
+		sortedNames := make([]string, 0, len(names))
+		for name := range names {
+			sortedNames = append(sortedNames, name)
+		}
+		sort.Strings(sortedNames)
+
+		for _, name := range sortedNames {
+			fieldPath := name
+			if path != `` {
+				fieldPath = path + `.` + name
+			}
+			diffFields(fieldPath, beforeMap[name], afterMap[name], fields)
+		}
+		return
+	}
+
+	beforeList, beforeIsList := before.([]interface{})
+	afterList, afterIsList := after.([]interface{})
+	if beforeIsList && afterIsList {
+		for i := 0; i < len(beforeList) || i < len(afterList); i++ {
+			var beforeItem, afterItem interface{}
+			if i < len(beforeList) {
+				beforeItem = beforeList[i]
+			}
+			if i < len(afterList) {
+				afterItem = afterList[i]
+			}
+			diffFields(fmt.Sprintf(`%s[%d]`, path, i), beforeItem, afterItem, fields)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(before, after) {
+		*fields = append(*fields, &FieldDiff{Path: path, Before: before, After: after})
+	}
+}
+
+// IsEmpty returns true if snapshots are equal
+func (d *StateDiff) IsEmpty() bool {
+	return len(d.Entries) == 0
+}
+
+// Filter returns entries with change type
+func (d *StateDiff) Filter(change DiffChange) []*EntryDiff {
+	var entries []*EntryDiff
+	for _, entry := range d.Entries {
+		if entry.Change == change {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// String human readable diff: added entries are marked with +, removed with -, changed with ~
+// and followed by changed fields
+func (d *StateDiff) String() string {
+	marks := map[DiffChange]string{EntryAdded: `+`, EntryRemoved: `-`, EntryChanged: `~`}
+
+	var sb strings.Builder
+	for _, entry := range d.Entries {
+		sb.WriteString(fmt.Sprintf("%s %s\n", marks[entry.Change], entry.Key))
+		for _, field := range entry.Fields {
+			sb.WriteString(fmt.Sprintf("    %s: %v -> %v\n", field.Path, field.Before, field.After))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,126 @@
+package mapping_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/cckit/serialize"
+	"github.com/hyperledger-labs/cckit/state"
+	"github.com/hyperledger-labs/cckit/state/mapping"
+	"github.com/hyperledger-labs/cckit/state/mapping/testdata/schema"
+	testcc "github.com/hyperledger-labs/cckit/testing"
+)
+
+var _ = Describe(`State diff`, func() {
+
+	var (
+		mappings = mapping.StateMappings{}.Add(&schema.EntityWithIndexes{},
+			mapping.PKeyId(),
+			mapping.List(&schema.EntityWithIndexesList{}),
+			mapping.UniqKey(`ExternalId`))
+
+		cc, ctx = testcc.NewTxHandler(`diff`)
+
+		before mapping.Snapshot
+	)
+
+	It("Allow to take snapshot of mock stub state", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(s.Insert(&schema.EntityWithIndexes{
+				Id: `aaa`, ExternalId: `aaa_ext`, OptionalExternalIds: []string{`aaa_opt`}, Value: 1})).To(Succeed())
+			Expect(s.Insert(&schema.EntityWithIndexes{Id: `bbb`, ExternalId: `bbb_ext`, Value: 2})).To(Succeed())
+			Expect(ctx.State().Put(`raw`, []byte(`raw value`))).To(Succeed())
+		})
+
+		var err error
+		before, err = mapping.NewSnapshot(cc.MockStub)
+		Expect(err).NotTo(HaveOccurred())
+		// 2 entities, 2 uniq key refs and raw entry
+		Expect(before).To(HaveLen(5))
+
+		value, ok := before.Get(state.Key{`raw`})
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal([]byte(`raw value`)))
+
+		diff, err := mapping.Diff(before, before)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff.IsEmpty()).To(BeTrue())
+	})
+
+	It("Allow to get added, removed and changed entries with field diff", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(s.Put(&schema.EntityWithIndexes{
+				Id: `aaa`, ExternalId: `aaa_ext2`, OptionalExternalIds: []string{`aaa_opt`, `aaa_opt2`}, Value: 1,
+			})).To(Succeed())
+			Expect(s.Delete(&schema.EntityWithIndexes{Id: `bbb`})).To(Succeed())
+			Expect(s.Insert(&schema.EntityWithIndexes{Id: `ccc`, ExternalId: `ccc_ext`, Value: 3})).To(Succeed())
+			Expect(ctx.State().Put(`raw`, []byte(`new raw value`))).To(Succeed())
+		})
+
+		after, err := mapping.NewSnapshot(cc.MockStub)
+		Expect(err).NotTo(HaveOccurred())
+
+		diff, err := mapping.Diff(before, after, mapping.DiffMappings(mappings))
+		Expect(err).NotTo(HaveOccurred())
+
+		// uniq key refs are ignored by default
+		Expect(diff.Entries).To(HaveLen(4))
+
+		Expect(diff.Filter(mapping.EntryAdded)).To(HaveLen(1))
+		Expect(diff.Filter(mapping.EntryAdded)[0].Key).To(Equal(state.Key{`EntityWithIndexes`, `ccc`}))
+		Expect(diff.Filter(mapping.EntryRemoved)).To(HaveLen(1))
+		Expect(diff.Filter(mapping.EntryRemoved)[0].Key).To(Equal(state.Key{`EntityWithIndexes`, `bbb`}))
+
+		changed := diff.Filter(mapping.EntryChanged)
+		Expect(changed).To(HaveLen(2))
+		Expect(changed[0].Key).To(Equal(state.Key{`EntityWithIndexes`, `aaa`}))
+		Expect(changed[0].Fields).To(Equal([]*mapping.FieldDiff{
+			{Path: `external_id`, Before: `aaa_ext`, After: `aaa_ext2`},
+			{Path: `optional_external_ids[1]`, Before: nil, After: `aaa_opt2`},
+		}))
+
+		// not mapped entry has no field diff
+		Expect(changed[1].Key).To(Equal(state.Key{`raw`}))
+		Expect(changed[1].Fields).To(BeNil())
+		Expect(changed[1].After).To(Equal([]byte(`new raw value`)))
+
+		Expect(diff.String()).To(Equal(`~ EntityWithIndexes | aaa
+    external_id: aaa_ext -> aaa_ext2
+    optional_external_ids[1]: <nil> -> aaa_opt2
+- EntityWithIndexes | bbb
++ EntityWithIndexes | ccc
+~ raw
+`))
+
+		withRefs, err := mapping.Diff(before, after, mapping.DiffIgnore())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(withRefs.Entries).To(HaveLen(8))
+
+		onlyEntities, err := mapping.Diff(before, after, mapping.DiffIgnore(state.Key{`raw`}, state.Key{`_idx`}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(onlyEntities.Entries).To(HaveLen(3))
+	})
+
+	It("Allow to compare ledger state with expected fixtures", func() {
+		actual, err := mapping.NewSnapshot(cc.MockStub, state.Key{`EntityWithIndexes`})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual).To(HaveLen(2))
+
+		expected, err := mapping.NewFixturesSnapshot(mappings, serialize.DefaultSerializer,
+			&schema.EntityWithIndexes{
+				Id: `aaa`, ExternalId: `aaa_ext2`, OptionalExternalIds: []string{`aaa_opt`, `aaa_opt2`}, Value: 1},
+			&schema.EntityWithIndexes{Id: `ccc`, ExternalId: `ccc_ext`, Value: 4},
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		diff, err := mapping.Diff(expected, actual, mapping.DiffMappings(mappings))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff.Entries).To(HaveLen(1))
+		Expect(diff.Entries[0].Fields).To(HaveLen(1))
+		Expect(diff.Entries[0].Fields[0].Path).To(Equal(`value`))
+		Expect(diff.Entries[0].Fields[0].Before).To(BeEquivalentTo(`4`))
+		Expect(diff.Entries[0].Fields[0].After).To(BeEquivalentTo(`3`))
+	})
+})
@@ -0,0 +1,417 @@
+package mapping
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+
+	"github.com/hyperledger-labs/cckit/state"
+)
+
+type (
+	// FieldKeyResolver returns AES key (16, 24 or 32 bytes) for field encryption in current transaction
+	FieldKeyResolver func(s state.State) ([]byte, error)
+
+	// StateFieldEncryption encrypted fields of mapped entry, supported field types are string, bytes and
+	// repeated string. Primary key fields can't be encrypted
+	StateFieldEncryption struct {
+		Fields []string
+		// Deterministic encryption produces same ciphertext for same field value,
+		// so field can be part of index and entries can be found by index with clear value
+		Deterministic bool
+		KeyResolver   FieldKeyResolver
+
+		// err result of encrypted fields check, returned on putting entry
+		err error
+	}
+)
+
+const (
+	// fieldCipherKeyLabel label of subkey for field AES-GCM encryption
+	fieldCipherKeyLabel = `cckit field cipher key`
+	// fieldNonceKeyLabel label of subkey for HMAC nonce derivation
+	fieldNonceKeyLabel = `cckit field nonce key`
+)
+
+// EncryptedFields encrypts fields of mapped entry with AES-GCM before putting to state
+// and decrypts fields after getting from state. Encrypted fields can't be part of primary key or index
+func EncryptedFields(keyResolver FieldKeyResolver, fields ...string) StateMappingOpt {
+	return WithFieldEncryption(&StateFieldEncryption{
+		Fields:      fields,
+		KeyResolver: keyResolver,
+	})
+}
+
+// DeterministicEncryptedFields encrypts fields of mapped entry with deterministic AES-GCM,
+// encrypted fields can be part of index
+func DeterministicEncryptedFields(keyResolver FieldKeyResolver, fields ...string) StateMappingOpt {
+	return WithFieldEncryption(&StateFieldEncryption{
+		Fields:        fields,
+		Deterministic: true,
+		KeyResolver:   keyResolver,
+	})
+}
+
+// WithFieldEncryption adds field encryption to mapping. Encrypted fields are checked once, after all mapping options,
+// including primary key and index options, are applied
+func WithFieldEncryption(encryption *StateFieldEncryption) StateMappingOpt {
+	return func(sm *StateMapping, smm StateMappings) {
+		// copy, so check result of encryption, shared by mappings, is not overwritten
+		fe := *encryption
+		sm.fieldEncryption = append(sm.fieldEncryption, &fe)
+	}
+}
+
+// FieldKey returns resolver with static key
+func FieldKey(key []byte) FieldKeyResolver {
+	return func(state.State) ([]byte, error) {
+		return key, nil
+	}
+}
+
+// TransientFieldKey returns resolver, getting key from transaction transient map
+func TransientFieldKey(name string) FieldKeyResolver {
+	return func(s state.State) ([]byte, error) {
+		tm, err := s.Transient()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := tm[name]
+		if !ok {
+			return nil, fmt.Errorf(`%w: transient map key %s`, ErrFieldKeyNotDefined, name)
+		}
+		return key, nil
+	}
+}
+
+func (sm *StateMapping) FieldEncryption() []*StateFieldEncryption {
+	return sm.fieldEncryption
+}
+
+// fieldEncryption returns encryption of field, nil if field is not encrypted
+func fieldEncryption(m StateMapper, field string) *StateFieldEncryption {
+	for _, fe := range m.FieldEncryption() {
+		for _, f := range fe.Fields {
+			if f == field {
+				return fe
+			}
+		}
+	}
+	return nil
+}
+
+// checkFieldEncryption checks encrypted fields of mapping with applied options
+func checkFieldEncryption(sm *StateMapping) {
+	for _, fe := range sm.fieldEncryption {
+		fe.err = checkEncryptedFields(sm, fe)
+	}
+}
+
+// checkEncryptedFields checks encrypted fields are not part of primary key,
+// and only deterministically encrypted fields are part of indexes
+func checkEncryptedFields(m StateMapper, fe *StateFieldEncryption) error {
+	for _, field := range fe.Fields {
+		for _, attr := range m.PrimaryKeyAttrs() {
+			if attr == field {
+				return fmt.Errorf(`%w: %s is primary key field`, ErrFieldEncryptionNotSupported, field)
+			}
+		}
+		if fe.Deterministic {
+			continue
+		}
+		for _, idx := range m.Indexes() {
+			for _, f := range idx.Fields {
+				if f == field {
+					return fmt.Errorf(`%w: %s is field of index %s, deterministic encryption required`,
+						ErrFieldEncryptionNotSupported, field, idx.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// encryptEntry returns copy of entry with encrypted fields or entry as is, if mapping has no encrypted fields
+func (s *Impl) encryptEntry(entry interface{}) (interface{}, error) {
+	msg, ok := entry.(proto.Message)
+	if !ok {
+		return entry, nil
+	}
+	m, err := s.mappings.Get(entry)
+	if err != nil || len(m.FieldEncryption()) == 0 || m.KeyerFor() != nil {
+		return entry, nil
+	}
+
+	encrypted := proto.Clone(msg)
+	inst := reflect.Indirect(reflect.ValueOf(encrypted))
+	for _, fe := range m.FieldEncryption() {
+		if fe.err != nil {
+			return nil, fe.err
+		}
+		key, err := fe.KeyResolver(s)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, field := range fe.Fields {
+			v := inst.FieldByName(field)
+			if !v.IsValid() {
+				return nil, fmt.Errorf(`%w: %s`, ErrFieldNotExists, field)
+			}
+			if err = cryptFieldValue(v, field, key, fe.Deterministic, s.TxID(), true); err != nil {
+				return nil, fmt.Errorf(`encrypt field %s: %w`, field, err)
+			}
+		}
+	}
+
+	return encrypted, nil
+}
+
+// decryptEntry decrypts fields of entry, got from state
+func (s *Impl) decryptEntry(entry interface{}) error {
+	if _, ok := entry.(proto.Message); !ok {
+		return nil
+	}
+	m, err := s.mappings.Get(entry)
+	if err != nil || len(m.FieldEncryption()) == 0 {
+		return nil
+	}
+
+	return decryptFields(m, entry, func(fe *StateFieldEncryption) ([]byte, error) {
+		return fe.KeyResolver(s)
+	})
+}
+
+// decryptFields decrypts encrypted fields of mapped entry in place with keys, returned by key func
+func decryptFields(m StateMapper, entry interface{}, key func(*StateFieldEncryption) ([]byte, error)) error {
+	inst := reflect.Indirect(reflect.ValueOf(entry))
+	for _, fe := range m.FieldEncryption() {
+		feKey, err := key(fe)
+		if err != nil {
+			return err
+		}
+
+		for _, field := range fe.Fields {
+			v := inst.FieldByName(field)
+			if !v.IsValid() {
+				return fmt.Errorf(`%w: %s`, ErrFieldNotExists, field)
+			}
+			if err = cryptFieldValue(v, field, feKey, fe.Deterministic, ``, false); err != nil {
+				return fmt.Errorf(`decrypt field %s: %w`, field, err)
+			}
+		}
+	}
+	return nil
+}
+
+// mapEncrypted maps entry with encrypted fields
+func (s *Impl) mapEncrypted(entry interface{}) (*StateInstance, error) {
+	encrypted, err := s.encryptEntry(entry)
+	if err != nil {
+		return nil, err
+	}
+	return s.mappings.Map(encrypted)
+}
+
+// decryptedList decrypts fields of list items, got from state
+func (s *Impl) decryptedList(list interface{}, err error) (interface{}, error) {
+	if err != nil {
+		return nil, err
+	}
+	return list, s.decryptList(list)
+}
+
+// decryptedListPaginated decrypts fields of list items, got from state with pagination
+func (s *Impl) decryptedListPaginated(list interface{}, md *pb.QueryResponseMetadata, err error) (
+	interface{}, *pb.QueryResponseMetadata, error) {
+	if err != nil {
+		return nil, nil, err
+	}
+	return list, md, s.decryptList(list)
+}
+
+// decryptHistory decrypts fields of history entries values, got from state
+func (s *Impl) decryptHistory(list state.HistoryEntryList) error {
+	for _, entry := range list {
+		if err := s.decryptEntry(entry.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decryptList decrypts fields of list items, got from state
+func (s *Impl) decryptList(list interface{}) error {
+	items := reflect.Indirect(reflect.ValueOf(list))
+	if items.Kind() != reflect.Struct {
+		return nil
+	}
+	if items = items.FieldByName(`Items`); !items.IsValid() || items.Kind() != reflect.Slice {
+		return nil
+	}
+
+	for i := 0; i < items.Len(); i++ {
+		if err := s.decryptEntry(items.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encryptIndexValue encrypts index value parts of deterministically encrypted fields, so entries can be found
+// by index with clear value
+func (s *Impl) encryptIndexValue(m StateMapper, idx string, idxVal []string) ([]string, error) {
+	index := m.Index(idx)
+	if index == nil || len(m.FieldEncryption()) == 0 {
+		return idxVal, nil
+	}
+
+	encrypted := append([]string{}, idxVal...)
+	for i, field := range index.Fields {
+		fe := fieldEncryption(m, field)
+		if fe == nil || !fe.Deterministic || i >= len(encrypted) {
+			continue
+		}
+		key, err := fe.KeyResolver(s)
+		if err != nil {
+			return nil, err
+		}
+		if err = cryptFieldValue(reflect.ValueOf(&encrypted[i]).Elem(), field, key, true, s.TxID(), true); err != nil {
+			return nil, fmt.Errorf(`encrypt index %s value: %w`, idx, err)
+		}
+	}
+	return encrypted, nil
+}
+
+// cryptFieldValue encrypts or decrypts string, bytes or repeated string field value in place, empty values are skipped.
+// Transaction id is used only for non-deterministic encryption
+func cryptFieldValue(v reflect.Value, field string, key []byte, deterministic bool, txID string, encrypt bool) error {
+	switch {
+	case v.Kind() == reflect.String:
+		if v.Len() == 0 {
+			return nil
+		}
+		if encrypt {
+			ct, err := encryptField(key, field, []byte(v.String()), deterministic, txID)
+			if err != nil {
+				return err
+			}
+			v.SetString(base64.StdEncoding.EncodeToString(ct))
+			return nil
+		}
+
+		ct, err := base64.StdEncoding.DecodeString(v.String())
+		if err != nil {
+			return err
+		}
+		plain, err := decryptField(key, field, ct)
+		if err != nil {
+			return err
+		}
+		v.SetString(string(plain))
+
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		if v.Len() == 0 {
+			return nil
+		}
+		var (
+			bb  []byte
+			err error
+		)
+		if encrypt {
+			bb, err = encryptField(key, field, v.Bytes(), deterministic, txID)
+		} else {
+			bb, err = decryptField(key, field, v.Bytes())
+		}
+		if err != nil {
+			return err
+		}
+		v.SetBytes(bb)
+
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String:
+		values := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			values.Index(i).SetString(v.Index(i).String())
+			if err := cryptFieldValue(values.Index(i), field, key, deterministic, txID, encrypt); err != nil {
+				return err
+			}
+		}
+		v.Set(values)
+
+	default:
+		return fmt.Errorf(`%w: %s`, ErrFieldEncryptionNotSupported, v.Type())
+	}
+
+	return nil
+}
+
+// encryptField encrypts field value with AES-GCM. Nonce is derived from field value with HMAC,
+// so all endorsing peers produce the same ciphertext. For non-deterministic encryption
+// transaction id is part of nonce, so same value in different transactions produces different ciphertext.
+// AES and HMAC use separate subkeys of field key
+func encryptField(key []byte, field string, plain []byte, deterministic bool, txID string) ([]byte, error) {
+	gcm, err := fieldCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(sha256.New, fieldSubKey(key, fieldNonceKeyLabel))
+	mac.Write([]byte(field))
+	mac.Write([]byte{0})
+	if !deterministic {
+		mac.Write([]byte(txID))
+		mac.Write([]byte{0})
+	}
+	mac.Write(plain)
+	nonce := mac.Sum(nil)[:gcm.NonceSize()]
+
+	return gcm.Seal(nonce, nonce, plain, []byte(field)), nil
+}
+
+func decryptField(key []byte, field string, ciphertext []byte) ([]byte, error) {
+	gcm, err := fieldCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, ErrFieldCiphertextInvalid
+	}
+
+	plain, err := gcm.Open(nil, ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():], []byte(field))
+	if err != nil {
+		return nil, fmt.Errorf(`%w: %s`, ErrFieldCiphertextInvalid, err)
+	}
+	return plain, nil
+}
+
+func fieldCipher(key []byte) (cipher.AEAD, error) {
+	// subkey has size of key, so key size is checked before derivation
+	switch len(key) {
+	case 16, 24, 32:
+	default:
+		return nil, aes.KeySizeError(len(key))
+	}
+	block, err := aes.NewCipher(fieldSubKey(key, fieldCipherKeyLabel))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// fieldSubKey derives subkey with size of key from field key with HMAC of label
+func fieldSubKey(key []byte, label string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(label))
+	sub := mac.Sum(nil)
+	if len(key) < len(sub) {
+		return sub[:len(key)]
+	}
+	return sub
+}
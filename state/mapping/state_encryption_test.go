@@ -0,0 +1,172 @@
+package mapping_test
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/cckit/state"
+	"github.com/hyperledger-labs/cckit/state/mapping"
+	"github.com/hyperledger-labs/cckit/state/mapping/testdata/schema"
+	testcc "github.com/hyperledger-labs/cckit/testing"
+)
+
+var _ = Describe(`State mapping with encrypted fields`, func() {
+
+	const transientKey = `ENCODE_KEY`
+
+	var (
+		encKey   = []byte(`0123456789abcdef0123456789abcdef`)
+		resolver = mapping.TransientFieldKey(transientKey)
+
+		mappings = mapping.StateMappings{}.Add(&schema.EntityWithIndexes{},
+			mapping.PKeyId(),
+			mapping.List(&schema.EntityWithIndexesList{}),
+			mapping.UniqKey(`ExternalId`),
+			mapping.DeterministicEncryptedFields(resolver, `ExternalId`),
+			mapping.EncryptedFields(resolver, `OptionalExternalIds`))
+
+		cc, ctx = testcc.NewTxHandler(`encryption`)
+
+		entity = &schema.EntityWithIndexes{
+			Id:                  `aaa`,
+			ExternalId:          `aaa_ext`,
+			OptionalExternalIds: []string{`aaa_opt1`, `aaa_opt2`},
+			Value:               1,
+		}
+	)
+
+	// encryption key is passed in transient map, transient map is cleared after each tx
+	txWithKey := func(tx func()) {
+		cc.MockStub.WithTransient(map[string][]byte{transientKey: encKey})
+		cc.Tx(tx)
+	}
+
+	It("Allow to put entry with encrypted fields", func() {
+		txWithKey(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(s.Insert(entity)).To(Succeed())
+			// entry passed to insert is not changed
+			Expect(entity.ExternalId).To(Equal(`aaa_ext`))
+		})
+	})
+
+	It("Allow to get entry with decrypted fields", func() {
+		txWithKey(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			e, err := s.Get(&schema.EntityWithIndexes{Id: `aaa`})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(e.(*schema.EntityWithIndexes).ExternalId).To(Equal(`aaa_ext`))
+			Expect(e.(*schema.EntityWithIndexes).OptionalExternalIds).To(Equal([]string{`aaa_opt1`, `aaa_opt2`}))
+
+			list, err := s.List(&schema.EntityWithIndexes{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.(*schema.EntityWithIndexesList).Items[0].ExternalId).To(Equal(`aaa_ext`))
+		})
+	})
+
+	It("Allow to get entry history with decrypted fields", func() {
+		txWithKey(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			history, err := s.GetHistory(&schema.EntityWithIndexes{Id: `aaa`}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history).To(HaveLen(1))
+			Expect(history[0].Value.(*schema.EntityWithIndexes).ExternalId).To(Equal(`aaa_ext`))
+			Expect(history[0].Value.(*schema.EntityWithIndexes).OptionalExternalIds).To(
+				Equal([]string{`aaa_opt1`, `aaa_opt2`}))
+
+			e, err := s.GetAt(&schema.EntityWithIndexes{Id: `aaa`}, time.Now())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(e.(*schema.EntityWithIndexes).ExternalId).To(Equal(`aaa_ext`))
+		})
+	})
+
+	It("Allow to get entry by deterministically encrypted index field", func() {
+		txWithKey(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			e, err := s.GetByKey(&schema.EntityWithIndexes{}, `ExternalId`, []string{`aaa_ext`}, &schema.EntityWithIndexes{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(e.(*schema.EntityWithIndexes).Id).To(Equal(`aaa`))
+			Expect(e.(*schema.EntityWithIndexes).ExternalId).To(Equal(`aaa_ext`))
+		})
+	})
+
+	It("Store encrypted fields in state", func() {
+		txWithKey(func() {
+			e, err := ctx.State().Get(state.Key{`EntityWithIndexes`, `aaa`}, &schema.EntityWithIndexes{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(e.(*schema.EntityWithIndexes).Id).To(Equal(`aaa`))
+			Expect(e.(*schema.EntityWithIndexes).Value).To(Equal(int32(1)))
+			Expect(e.(*schema.EntityWithIndexes).ExternalId).NotTo(Equal(`aaa_ext`))
+			Expect(e.(*schema.EntityWithIndexes).OptionalExternalIds[0]).NotTo(Equal(`aaa_opt1`))
+
+			// field is encrypted with subkey of field key, not with field key itself
+			ct, err := base64.StdEncoding.DecodeString(e.(*schema.EntityWithIndexes).ExternalId)
+			Expect(err).NotTo(HaveOccurred())
+			block, err := aes.NewCipher(encKey)
+			Expect(err).NotTo(HaveOccurred())
+			gcm, err := cipher.NewGCM(block)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = gcm.Open(nil, ct[:gcm.NonceSize()], ct[gcm.NonceSize():], []byte(`ExternalId`))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	It("Allow to update and delete entry with encrypted index field", func() {
+		txWithKey(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(s.Put(&schema.EntityWithIndexes{Id: `aaa`, ExternalId: `aaa_ext2`})).To(Succeed())
+		})
+
+		txWithKey(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			_, err := s.GetByKey(&schema.EntityWithIndexes{}, `ExternalId`, []string{`aaa_ext`})
+			Expect(err).To(HaveOccurred())
+
+			e, err := s.GetByKey(&schema.EntityWithIndexes{}, `ExternalId`, []string{`aaa_ext2`}, &schema.EntityWithIndexes{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(e.(*schema.EntityWithIndexes).Id).To(Equal(`aaa`))
+
+			Expect(s.Delete(&schema.EntityWithIndexes{Id: `aaa`})).To(Succeed())
+		})
+
+		txWithKey(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			_, err := s.GetByKey(&schema.EntityWithIndexes{}, `ExternalId`, []string{`aaa_ext2`})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	It("Disallow to put entry without encryption key or with not deterministically encrypted index field", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			err := s.Put(entity)
+			Expect(errors.Is(err, mapping.ErrFieldKeyNotDefined)).To(BeTrue())
+		})
+
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mapping.StateMappings{}.Add(&schema.EntityWithIndexes{},
+				mapping.PKeyId(),
+				mapping.UniqKey(`ExternalId`),
+				mapping.EncryptedFields(mapping.FieldKey(encKey), `ExternalId`)))
+			err := s.Put(entity)
+			Expect(errors.Is(err, mapping.ErrFieldEncryptionNotSupported)).To(BeTrue())
+		})
+
+		cc.Tx(func() {
+			// encrypted fields are checked after all mapping options are applied
+			s := mapping.WrapState(ctx.State(), mapping.StateMappings{}.Add(&schema.EntityWithIndexes{},
+				mapping.EncryptedFields(mapping.FieldKey(encKey), `ExternalId`),
+				mapping.PKeyId(),
+				mapping.UniqKey(`ExternalId`)))
+			err := s.Put(entity)
+			Expect(errors.Is(err, mapping.ErrFieldEncryptionNotSupported)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring(`field of index ExternalId`))
+		})
+	})
+})
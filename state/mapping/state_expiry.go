@@ -0,0 +1,263 @@
+package mapping
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+
+	"github.com/hyperledger-labs/cckit/state"
+	"github.com/hyperledger-labs/cckit/state/schema"
+)
+
+const (
+	// ExpiryIndex name of index by expiry field, used for purging expired entries
+	ExpiryIndex = `_expiry`
+)
+
+type (
+	// StateExpiry defines expiry of mapped entries: timestamp field with expiry time
+	// and optional time-to-live, used for filling expiry field on put
+	StateExpiry struct {
+		Field string
+		TTL   time.Duration
+	}
+)
+
+// WithExpiry defines timestamp field with entry expiry time. Entries with expiry time before or equal
+// to tx timestamp are treated as not existing, entries with empty field never expire
+func WithExpiry(field string) StateMappingOpt {
+	return withExpiry(&StateExpiry{Field: field})
+}
+
+// WithTTL defines time-to-live of mapped entries, expiry field is filled on put with tx timestamp plus ttl,
+// if it is empty
+func WithTTL(field string, ttl time.Duration) StateMappingOpt {
+	return withExpiry(&StateExpiry{Field: field, TTL: ttl})
+}
+
+func withExpiry(expiry *StateExpiry) StateMappingOpt {
+	return func(sm *StateMapping, smm StateMappings) {
+		sm.expiry = expiry
+		// order preserving encoding of expiry field is used only in expiry index keys
+		encoders := sm.keyEncoders.withDefault(KeyEncodingOrdered, []string{expiry.Field})
+
+		// entries without expiry are not indexed
+		keyer := attrsKeyerWith([]string{expiry.Field}, encoders)
+		WithIndex(&StateIndexDef{
+			Name:    ExpiryIndex,
+			NonUniq: true,
+			Keyer: func(instance interface{}) ([]state.Key, error) {
+				if expiryOf(instance, expiry.Field) == nil {
+					return []state.Key{{``}}, nil
+				}
+				return keyerAsMulti(keyer)(instance)
+			},
+			keyEncoders: encoders,
+		})(sm, smm)
+	}
+}
+
+// Expiry returns expiry of mapped entries, can be nil
+func (sm *StateMapping) Expiry() *StateExpiry {
+	return sm.expiry
+}
+
+// expiryOf returns value of expiry field, nil if field is empty or not timestamp
+func expiryOf(entry interface{}, field string) *timestamp.Timestamp {
+	v := reflect.Indirect(reflect.ValueOf(entry))
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	f := v.FieldByName(field)
+	if !f.IsValid() {
+		return nil
+	}
+	ts, _ := f.Interface().(*timestamp.Timestamp)
+	return ts
+}
+
+// fillExpiry sets expiry field to tx timestamp plus ttl, if ttl is defined in mapping and field is empty
+func (s *Impl) fillExpiry(entry interface{}) error {
+	m, err := s.mappings.Get(entry)
+	if err != nil || m.Expiry() == nil || m.Expiry().TTL == 0 || m.KeyerFor() != nil {
+		return nil
+	}
+
+	f := reflect.Indirect(reflect.ValueOf(entry)).FieldByName(m.Expiry().Field)
+	if !f.IsValid() || f.Type() != reflect.TypeOf(&timestamp.Timestamp{}) {
+		return fmt.Errorf(`%w: %s`, ErrExpiryFieldNotSupported, m.Expiry().Field)
+	}
+	if !f.IsNil() {
+		return nil
+	}
+
+	now, err := s.txTime()
+	if err != nil {
+		return err
+	}
+	expiry, err := ptypes.TimestampProto(now.Add(m.Expiry().TTL))
+	if err != nil {
+		return err
+	}
+	f.Set(reflect.ValueOf(expiry))
+	return nil
+}
+
+// isExpired checks entry expiry time is before or equal to tx timestamp,
+// entries of mappings without expiry never expire
+func (s *Impl) isExpired(entry interface{}) (bool, error) {
+	m, err := s.mappings.Get(entry)
+	if err != nil || m.Expiry() == nil {
+		return false, nil
+	}
+
+	ts := expiryOf(entry, m.Expiry().Field)
+	if ts == nil {
+		return false, nil
+	}
+	expiry, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return false, err
+	}
+
+	now, err := s.txTime()
+	if err != nil {
+		return false, err
+	}
+	return !now.Before(expiry), nil
+}
+
+// notExpired returns error if got entry is expired
+func (s *Impl) notExpired(entry interface{}, err error) (interface{}, error) {
+	if err != nil {
+		return nil, err
+	}
+	if expired, err := s.isExpired(entry); err != nil {
+		return nil, err
+	} else if expired {
+		key, _ := s.mappings.PrimaryKey(entry)
+		return nil, fmt.Errorf(`%w: %s is expired`, state.ErrKeyNotFound, key)
+	}
+	return entry, nil
+}
+
+// unexpiredList removes expired items from list, got from state
+func (s *Impl) unexpiredList(list interface{}, err error) (interface{}, error) {
+	if err != nil {
+		return nil, err
+	}
+	return list, s.filterExpired(list)
+}
+
+// unexpiredListPaginated removes expired items from list, got from state with pagination.
+// Page can contain less items than page size
+func (s *Impl) unexpiredListPaginated(list interface{}, md *pb.QueryResponseMetadata, err error) (
+	interface{}, *pb.QueryResponseMetadata, error) {
+	if err != nil {
+		return nil, nil, err
+	}
+	return list, md, s.filterExpired(list)
+}
+
+func (s *Impl) filterExpired(list interface{}) error {
+	items := reflect.Indirect(reflect.ValueOf(list))
+	if items.Kind() != reflect.Struct {
+		return nil
+	}
+	if items = items.FieldByName(`Items`); !items.IsValid() || items.Kind() != reflect.Slice {
+		return nil
+	}
+
+	unexpired := reflect.MakeSlice(items.Type(), 0, items.Len())
+	for i := 0; i < items.Len(); i++ {
+		entry := items.Index(i).Interface()
+		// default list contains items as any
+		if a, ok := entry.(*any.Any); ok {
+			msg := &ptypes.DynamicAny{}
+			if err := ptypes.UnmarshalAny(a, msg); err != nil {
+				return err
+			}
+			entry = msg.Message
+		}
+
+		expired, err := s.isExpired(entry)
+		if err != nil {
+			return err
+		}
+		if !expired {
+			unexpired = reflect.Append(unexpired, items.Index(i))
+		}
+	}
+	items.Set(unexpired)
+	return nil
+}
+
+// PurgeExpired deletes expired entries with its key refs, at most limit entries are deleted in one call
+// (all expired entries if limit is 0), returns primary keys of deleted entries.
+// Expiry index refs are listed up to tx timestamp without peer pagination, so purge can be invoked in transaction
+func (s *Impl) PurgeExpired(entry interface{}, limit int32) ([]state.Key, error) {
+	m, err := s.mappings.Get(entry)
+	if err != nil {
+		return nil, err
+	}
+	if m.Expiry() == nil {
+		return nil, fmt.Errorf(`%w: %s`, ErrExpiryNotDefined, mapKey(entry))
+	}
+
+	now, err := s.TxTimestamp()
+	if err != nil {
+		return nil, err
+	}
+	nowKey, err := m.Index(ExpiryIndex).keyEncoders.encode(m.Expiry().Field, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// range is listed without pagination, Fabric peer rejects writes in transaction with paginated queries
+	refs, _, err := s.stateFor(m).ListRange(
+		KeyRefPrefix(m.Schema(), ExpiryIndex, nil), nil, nowKey, 0, ``, &schema.KeyRef{})
+	if err != nil {
+		return nil, fmt.Errorf(`expiry index refs: %w`, err)
+	}
+
+	var (
+		purged   []state.Key
+		deleting = make(map[string]bool)
+	)
+	for _, item := range refs.(*schema.List).Items {
+		if limit > 0 && int32(len(purged)) == limit {
+			break
+		}
+		keyRef := &schema.KeyRef{}
+		if err = ptypes.UnmarshalAny(item, keyRef); err != nil {
+			return nil, err
+		}
+		// entry can be already deleted by cascade
+		if deleting[state.Key(keyRef.PKey).String()] {
+			continue
+		}
+		if err = s.delete(keyRef.PKey, deleting); err != nil {
+			if errors.Is(err, state.ErrKeyNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		purged = append(purged, keyRef.PKey)
+	}
+
+	return purged, nil
+}
+
+func (s *Impl) txTime() (time.Time, error) {
+	ts, err := s.TxTimestamp()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return ptypes.Timestamp(ts)
+}
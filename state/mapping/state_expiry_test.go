@@ -0,0 +1,161 @@
+package mapping_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/cckit/state"
+	"github.com/hyperledger-labs/cckit/state/mapping"
+	"github.com/hyperledger-labs/cckit/state/mapping/testdata/schema"
+	testcc "github.com/hyperledger-labs/cckit/testing"
+)
+
+var _ = Describe(`State mapping with expiry`, func() {
+
+	var (
+		mappings = mapping.StateMappings{}.
+				Add(&schema.Lock{},
+				mapping.PKeyId(),
+				mapping.List(&schema.LockList{}),
+				mapping.UniqKey(`Holder`),
+				mapping.WithTTL(`ExpiresAt`, time.Hour)).
+			Add(&schema.EntityWithIndexes{},
+				mapping.PKeyId())
+
+		cc, ctx = testcc.NewTxHandler(`expiry`)
+
+		t0 = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		// txAt runs tx with tx timestamp
+		txAt = func(t time.Time, tx func()) {
+			ts, _ := ptypes.TimestampProto(t)
+			cc.MockStub.At(ts)
+			cc.Tx(tx)
+		}
+	)
+
+	It("Allow to use ordered key encoding of expiry field only in expiry index keys", func() {
+		expiresAt, _ := ptypes.TimestampProto(t0)
+
+		m, err := mappings.Get(&schema.Lock{})
+		Expect(err).NotTo(HaveOccurred())
+		key, err := m.EncodeKey(`ExpiresAt`, expiresAt)
+		Expect(err).NotTo(HaveOccurred())
+
+		withoutExpiry, err := mapping.StateMappings{}.Add(&schema.Lock{}, mapping.PKeyId()).Get(&schema.Lock{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(withoutExpiry.EncodeKey(`ExpiresAt`, expiresAt)).To(Equal(key))
+	})
+
+	It("Allow to insert entries with ttl and explicit expiry", func() {
+		txAt(t0, func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			lock := &schema.Lock{Id: `l1`, Holder: `h1`}
+			Expect(s.Insert(lock)).To(Succeed())
+			Expect(lock.ExpiresAt.AsTime()).To(Equal(t0.Add(time.Hour)))
+
+			expiresAt, _ := ptypes.TimestampProto(t0.Add(3 * time.Hour))
+			Expect(s.Insert(&schema.Lock{Id: `l2`, Holder: `h2`, ExpiresAt: expiresAt})).To(Succeed())
+			Expect(s.Insert(&schema.Lock{Id: `l3`, Holder: `h3`})).To(Succeed())
+		})
+	})
+
+	It("Allow to get not expired entries", func() {
+		txAt(t0.Add(30*time.Minute), func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			_, err := s.Get(&schema.Lock{Id: `l1`})
+			Expect(err).NotTo(HaveOccurred())
+
+			exists, err := s.Exists(&schema.Lock{Id: `l1`})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeTrue())
+
+			list, err := s.List(&schema.Lock{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.(*schema.LockList).Items).To(HaveLen(3))
+		})
+	})
+
+	It("Disallow to get expired entries", func() {
+		txAt(t0.Add(2*time.Hour), func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			_, err := s.Get(&schema.Lock{Id: `l1`})
+			Expect(errors.Is(err, state.ErrKeyNotFound)).To(BeTrue())
+
+			exists, err := s.Exists(&schema.Lock{Id: `l1`})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+
+			_, err = s.GetByKey(&schema.Lock{}, `Holder`, []string{`h1`}, &schema.Lock{})
+			Expect(errors.Is(err, state.ErrKeyNotFound)).To(BeTrue())
+
+			list, err := s.List(&schema.Lock{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.(*schema.LockList).Items).To(HaveLen(1))
+			Expect(list.(*schema.LockList).Items[0].Id).To(Equal(`l2`))
+		})
+	})
+
+	It("Allow to insert entry with key of expired entry", func() {
+		txAt(t0.Add(2*time.Hour), func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(s.Insert(&schema.Lock{Id: `l1`, Holder: `h1_new`})).To(Succeed())
+		})
+
+		txAt(t0.Add(2*time.Hour), func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			lock, err := s.Get(&schema.Lock{Id: `l1`})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lock.(*schema.Lock).Holder).To(Equal(`h1_new`))
+			Expect(lock.(*schema.Lock).ExpiresAt.AsTime()).To(Equal(t0.Add(3 * time.Hour)))
+
+			_, err = s.GetByKey(&schema.Lock{}, `Holder`, []string{`h1_new`}, &schema.Lock{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	It("Allow to purge expired entries", func() {
+		txAt(t0.Add(2*time.Hour), func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			purged, err := s.PurgeExpired(&schema.Lock{}, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(purged).To(Equal([]state.Key{{`Lock`, `l3`}}))
+		})
+
+		txAt(t0.Add(2*time.Hour), func() {
+			exists, err := ctx.State().Exists(state.Key{`Lock`, `l3`})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+
+			// uniq key of purged entry is released
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(s.Insert(&schema.Lock{Id: `l4`, Holder: `h3`})).To(Succeed())
+		})
+
+		txAt(t0.Add(4*time.Hour), func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			purged, err := s.PurgeExpired(&schema.Lock{}, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(purged).To(HaveLen(2))
+		})
+
+		txAt(t0.Add(4*time.Hour), func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			purged, err := s.PurgeExpired(&schema.Lock{}, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(purged).To(HaveLen(1))
+		})
+	})
+
+	It("Disallow to purge entries without expiry", func() {
+		txAt(t0, func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			_, err := s.PurgeExpired(&schema.EntityWithIndexes{}, 10)
+			Expect(errors.Is(err, mapping.ErrExpiryNotDefined)).To(BeTrue())
+		})
+	})
+})
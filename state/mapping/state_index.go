@@ -6,7 +6,12 @@ type (
 		Name     string
 		Uniq     bool
 		Required bool
-		Keyer    InstanceMultiKeyer // index can have multiple keys
+		// Fields entry fields of index, empty if index is based on custom keyer
+		Fields []string
+		Keyer  InstanceMultiKeyer // index can have multiple keys
+
+		// keyEncoders encoders of index fields, mapping key encoders if index doesn't override encoding
+		keyEncoders keyEncoders
 	}
 
 	// StateIndexDef additional index definition
@@ -15,6 +20,13 @@ type (
 		Fields   []string
 		Required bool
 		Multi    bool
-		Keyer    InstanceMultiKeyer
+		// NonUniq index allows multiple entries with same index value, key ref includes primary key as suffix
+		NonUniq bool
+		Keyer   InstanceMultiKeyer
+
+		// defaultKeyEncoder encoder of index fields without field specific mapping key encoder
+		defaultKeyEncoder KeyEncoder
+		// keyEncoders encoders of fields, used by custom keyer
+		keyEncoders keyEncoders
 	}
 )
@@ -0,0 +1,283 @@
+package mapping
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/hyperledger-labs/cckit/state"
+	"github.com/hyperledger-labs/cckit/state/schema"
+)
+
+const (
+	// index check goes through entries (missing and mismatched key refs), then through key refs (orphaned key refs),
+	// bookmark of check batch is prefixed with current phase
+	indexCheckEntries = `entries`
+	indexCheckKeyRefs = `refs`
+
+	indexCheckBookmarkSep = `:`
+)
+
+// CheckIndexes checks key refs of mapped entries in batch of pageSize entries or key refs,
+// returns found issues and bookmark for next batch, empty bookmark means check is finished.
+// Entries and key refs are read from bookmark without pagination, reading stops after batch,
+// so batch can be checked in transaction, and mapped entries, stored in private data collection, are supported
+func (s *Impl) CheckIndexes(entry interface{}, pageSize int32, bookmark string) (*schema.IndexCheckResult, error) {
+	return s.checkIndexes(entry, pageSize, bookmark, false)
+}
+
+// RebuildIndexes checks key refs of mapped entries as CheckIndexes, missing key refs are created,
+// orphaned key refs are deleted, mismatched uniq key refs are fixed if referred entry doesn't have same uniq key.
+// Fabric doesn't allow paginated queries in transaction, which changes state, so batch entries are listed
+// without pagination and batch is rebuilt in invoke transaction
+func (s *Impl) RebuildIndexes(entry interface{}, pageSize int32, bookmark string) (*schema.IndexCheckResult, error) {
+	return s.checkIndexes(entry, pageSize, bookmark, true)
+}
+
+func (s *Impl) checkIndexes(entry interface{}, pageSize int32, bookmark string, rebuild bool) (
+	*schema.IndexCheckResult, error) {
+	m, err := s.mappings.Get(entry)
+	if err != nil {
+		return nil, err
+	}
+	if m.KeyerFor() != nil {
+		return nil, fmt.Errorf(`%w: %s is primary key schema`, ErrEntryTypeNotSupported, mapKey(entry))
+	}
+	if pageSize <= 0 {
+		return nil, fmt.Errorf(`%w: %d`, ErrIndexCheckPageSizeInvalid, pageSize)
+	}
+
+	phase, phaseBookmark := indexCheckEntries, ``
+	if bookmark != `` {
+		parts := strings.SplitN(bookmark, indexCheckBookmarkSep, 2)
+		if len(parts) != 2 || (parts[0] != indexCheckEntries && parts[0] != indexCheckKeyRefs) {
+			return nil, fmt.Errorf(`%w: %s`, ErrIndexCheckBookmarkInvalid, bookmark)
+		}
+		phase, phaseBookmark = parts[0], parts[1]
+	}
+
+	result := &schema.IndexCheckResult{Schema: schemaName(m.Schema())}
+	var finished bool
+	if phase == indexCheckEntries {
+		finished, phaseBookmark, err = s.checkEntriesKeyRefs(m, pageSize, phaseBookmark, rebuild, result)
+		if err != nil {
+			return nil, err
+		}
+		if finished {
+			result.Bookmark = indexCheckKeyRefs + indexCheckBookmarkSep
+		} else {
+			result.Bookmark = indexCheckEntries + indexCheckBookmarkSep + phaseBookmark
+		}
+		return result, nil
+	}
+
+	finished, phaseBookmark, err = s.checkOrphanedKeyRefs(m, pageSize, phaseBookmark, rebuild, result)
+	if err != nil {
+		return nil, err
+	}
+	if !finished {
+		result.Bookmark = indexCheckKeyRefs + indexCheckBookmarkSep + phaseBookmark
+	}
+	return result, nil
+}
+
+// checkEntriesKeyRefs checks key refs of each entry from batch exist and refer to entry
+func (s *Impl) checkEntriesKeyRefs(m StateMapper, pageSize int32, bookmark string, rebuild bool,
+	result *schema.IndexCheckResult) (finished bool, nextBookmark string, err error) {
+	st := s.stateFor(m)
+	keys, nextBookmark, err := indexCheckBatch(st, m.Namespace(), pageSize, bookmark)
+	if err != nil {
+		return false, ``, err
+	}
+
+	for _, k := range keys {
+		// entries are checked as stored, with encrypted fields, key refs are based on encrypted values
+		entry, err := st.Get(k, m.Schema())
+		if err != nil {
+			return false, ``, err
+		}
+		result.CheckedEntries++
+
+		mapped := NewStateInstance(entry, m)
+		pKey, err := mapped.Key()
+		if err != nil {
+			return false, ``, err
+		}
+		keyRefs, err := mapped.Keys()
+		if err != nil {
+			return false, ``, err
+		}
+
+		for _, kr := range keyRefs {
+			key, err := kr.Key()
+			if err != nil {
+				return false, ``, err
+			}
+			issue := &schema.IndexIssue{
+				Idx:  kr.(*StateInstance).instance.(*schema.KeyRef).Idx,
+				Key:  key,
+				PKey: pKey,
+			}
+
+			existing, err := st.Get(key, &schema.KeyRef{})
+			switch {
+			case errors.Is(err, state.ErrKeyNotFound):
+				issue.Kind = schema.IndexIssue_MISSING
+				issue.Fixed = rebuild
+
+			case err != nil:
+				return false, ``, err
+
+			case state.Key(existing.(*schema.KeyRef).PKey).String() == pKey.String():
+				continue
+
+			default:
+				issue.Kind = schema.IndexIssue_MISMATCHED
+				issue.RefPKey = existing.(*schema.KeyRef).PKey
+				// key ref can be fixed if entry, key ref refers to, doesn't have the same uniq key
+				if rebuild {
+					claimed, err := s.keyRefClaimed(m, issue.RefPKey, key)
+					if err != nil {
+						return false, ``, err
+					}
+					issue.Fixed = !claimed
+				}
+			}
+
+			if issue.Fixed {
+				if err = st.Put(kr); err != nil {
+					return false, ``, err
+				}
+			}
+			result.Issues = append(result.Issues, issue)
+		}
+	}
+
+	return nextBookmark == ``, nextBookmark, nil
+}
+
+// checkOrphanedKeyRefs checks each key ref from batch is produced by index of existing entry
+func (s *Impl) checkOrphanedKeyRefs(m StateMapper, pageSize int32, bookmark string, rebuild bool,
+	result *schema.IndexCheckResult) (finished bool, nextBookmark string, err error) {
+	st := s.stateFor(m)
+	prefix := state.Key{KeyRefNamespace, schemaName(m.Schema())}
+	keys, nextBookmark, err := indexCheckBatch(st, prefix, pageSize, bookmark)
+	if err != nil {
+		return false, ``, err
+	}
+
+	for _, k := range keys {
+		keyRef, err := st.Get(k, &schema.KeyRef{})
+		if err != nil {
+			return false, ``, err
+		}
+		result.CheckedKeyRefs++
+
+		key, err := s.keyRefKey(m, keyRef.(*schema.KeyRef))
+		if err != nil {
+			return false, ``, err
+		}
+		claimed, err := s.keyRefClaimed(m, keyRef.(*schema.KeyRef).PKey, key)
+		if err != nil {
+			return false, ``, err
+		}
+		if claimed {
+			continue
+		}
+
+		if rebuild {
+			if err = st.Delete(key); err != nil {
+				return false, ``, err
+			}
+		}
+		result.Issues = append(result.Issues, &schema.IndexIssue{
+			Kind:    schema.IndexIssue_ORPHANED,
+			Idx:     keyRef.(*schema.KeyRef).Idx,
+			Key:     key,
+			RefPKey: keyRef.(*schema.KeyRef).PKey,
+			Fixed:   rebuild,
+		})
+	}
+
+	return nextBookmark == ``, nextBookmark, nil
+}
+
+// indexCheckBatch returns at most pageSize keys with namespace, starting from bookmark state key, and state key
+// of first entry of next batch. Fabric doesn't allow paginated queries in transaction, which changes state,
+// so keys are read from bookmark without pagination and reading stops after batch
+func indexCheckBatch(st state.State, namespace state.Key, pageSize int32, bookmark string) (
+	keys []string, nextBookmark string, err error) {
+	return st.KeysFrom(namespace, bookmark, pageSize)
+}
+
+// keyRefKey returns state key of key ref. If index is not defined in mapping (for example, renamed),
+// key ref can be stored with uniq or non-uniq index key
+func (s *Impl) keyRefKey(m StateMapper, keyRef *schema.KeyRef) (state.Key, error) {
+	uniq := NewStateInstance(keyRef, KeyRefMapper)
+	if idx := m.Index(keyRef.Idx); idx != nil && idx.Uniq {
+		return uniq.Key()
+	}
+
+	if m.Index(keyRef.Idx) == nil {
+		key, err := uniq.Key()
+		if err != nil {
+			return nil, err
+		}
+		existing, err := s.stateFor(m).Get(key, &schema.KeyRef{})
+		if err == nil && proto.Equal(existing.(*schema.KeyRef), keyRef) {
+			return key, nil
+		}
+	}
+
+	return NewStateInstance(keyRef, KeyRefNonUniqMapper).Key()
+}
+
+// keyRefClaimed checks entry with primary key exists and has key ref with key
+func (s *Impl) keyRefClaimed(m StateMapper, pKey state.Key, key state.Key) (bool, error) {
+	entry, err := s.stateFor(m).Get(pKey, m.Schema())
+	if err != nil {
+		if errors.Is(err, state.ErrKeyNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	keyRefs, err := NewStateInstance(entry, m).Keys()
+	if err != nil {
+		return false, err
+	}
+	for _, kr := range keyRefs {
+		k, err := kr.Key()
+		if err != nil {
+			return false, err
+		}
+		if k.String() == key.String() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// schemaName returns schema name, used in key refs
+func schemaName(schema interface{}) string {
+	return strings.Join(SchemaNamespace(schema), `-`)
+}
+
+// getBySchemaName returns mapping by schema name, used in key refs
+func (smm StateMappings) getBySchemaName(name string) (StateMapper, error) {
+	keys := make([]string, 0, len(smm))
+	for k := range smm {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if smm[k].keyerForSchema == nil && schemaName(smm[k].schema) == name {
+			return smm[k], nil
+		}
+	}
+	return nil, fmt.Errorf(`schema=%s: %w`, name, ErrStateMappingNotFound)
+}
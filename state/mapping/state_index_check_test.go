@@ -0,0 +1,237 @@
+package mapping_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/cckit/extensions/owner"
+	identitytestdata "github.com/hyperledger-labs/cckit/identity/testdata"
+	"github.com/hyperledger-labs/cckit/router"
+	"github.com/hyperledger-labs/cckit/state"
+	"github.com/hyperledger-labs/cckit/state/mapping"
+	"github.com/hyperledger-labs/cckit/state/mapping/testdata/schema"
+	stateschema "github.com/hyperledger-labs/cckit/state/schema"
+	testcc "github.com/hyperledger-labs/cckit/testing"
+	expectcc "github.com/hyperledger-labs/cckit/testing/expect"
+)
+
+var _ = Describe(`State mapping index check`, func() {
+
+	var (
+		mappings = mapping.StateMappings{}.Add(&schema.EntityWithIndexes{},
+			mapping.PKeyId(),
+			mapping.List(&schema.EntityWithIndexesList{}),
+			mapping.UniqKey(`ExternalId`))
+
+		// mappings with new index, added after entries were put to state
+		mappingsWithNewIndex = mapping.StateMappings{}.Add(&schema.EntityWithIndexes{},
+			mapping.PKeyId(),
+			mapping.List(&schema.EntityWithIndexesList{}),
+			mapping.UniqKey(`ExternalId`),
+			mapping.Index(`Value`))
+
+		cc, ctx = testcc.NewTxHandler(`index_check`)
+
+		// checkAll runs index check or rebuild in batches, one batch per tx, until check is finished
+		checkAll = func(mappings mapping.StateMappings, rebuild bool) (issues []*stateschema.IndexIssue, batches int) {
+			bookmark := ``
+			for {
+				cc.Tx(func() {
+					s := mapping.WrapState(ctx.State(), mappings)
+					var (
+						res *stateschema.IndexCheckResult
+						err error
+					)
+					if rebuild {
+						res, err = s.RebuildIndexes(&schema.EntityWithIndexes{}, 2, bookmark)
+					} else {
+						res, err = s.CheckIndexes(&schema.EntityWithIndexes{}, 2, bookmark)
+					}
+					Expect(err).NotTo(HaveOccurred())
+					issues = append(issues, res.Issues...)
+					bookmark = res.Bookmark
+				})
+				batches++
+				if bookmark == `` {
+					return issues, batches
+				}
+			}
+		}
+
+		issuesOf = func(issues []*stateschema.IndexIssue, kind stateschema.IndexIssue_Kind, idx string) (
+			keys []state.Key) {
+			for _, i := range issues {
+				if i.Kind == kind && i.Idx == idx {
+					keys = append(keys, i.Key)
+				}
+			}
+			return keys
+		}
+	)
+
+	It("Allow to insert entries", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(s.Insert(&schema.EntityWithIndexes{Id: `aaa`, ExternalId: `aaa_ext`, Value: 1})).To(Succeed())
+			Expect(s.Insert(&schema.EntityWithIndexes{Id: `bbb`, ExternalId: `bbb_ext`, Value: 1})).To(Succeed())
+			Expect(s.Insert(&schema.EntityWithIndexes{Id: `ccc`, ExternalId: `ccc_ext`, Value: 2})).To(Succeed())
+		})
+	})
+
+	It("Allow to check consistent indexes", func() {
+		issues, batches := checkAll(mappings, false)
+		Expect(issues).To(BeEmpty())
+		// 2 batches of entries, 2 batches of key refs
+		Expect(batches).To(Equal(4))
+	})
+
+	It("Allow to find missing, orphaned and mismatched key refs", func() {
+		cc.Tx(func() {
+			// state is corrupted directly, without mapping
+			Expect(ctx.State().Delete(mapping.NewKeyRefInstance(
+				&schema.EntityWithIndexes{}, `ExternalId`, state.Key{`bbb_ext`}, nil))).To(Succeed())
+			Expect(ctx.State().Put(mapping.NewKeyRefInstance(
+				&schema.EntityWithIndexes{}, `ExternalId`, state.Key{`zzz_ext`},
+				state.Key{`EntityWithIndexes`, `zzz`}))).To(Succeed())
+			Expect(ctx.State().Put(mapping.NewKeyRefInstance(
+				&schema.EntityWithIndexes{}, `ExternalId`, state.Key{`ccc_ext`},
+				state.Key{`EntityWithIndexes`, `aaa`}))).To(Succeed())
+		})
+
+		issues, _ := checkAll(mappingsWithNewIndex, false)
+
+		Expect(issuesOf(issues, stateschema.IndexIssue_MISSING, `ExternalId`)).To(Equal([]state.Key{
+			{mapping.KeyRefNamespace, `EntityWithIndexes`, `ExternalId`, `bbb_ext`}}))
+		Expect(issuesOf(issues, stateschema.IndexIssue_MISSING, `Value`)).To(HaveLen(3))
+		Expect(issuesOf(issues, stateschema.IndexIssue_MISMATCHED, `ExternalId`)).To(Equal([]state.Key{
+			{mapping.KeyRefNamespace, `EntityWithIndexes`, `ExternalId`, `ccc_ext`}}))
+		// mismatched key ref is not claimed by entry it refers to, so it is orphaned too
+		Expect(issuesOf(issues, stateschema.IndexIssue_ORPHANED, `ExternalId`)).To(Equal([]state.Key{
+			{mapping.KeyRefNamespace, `EntityWithIndexes`, `ExternalId`, `ccc_ext`},
+			{mapping.KeyRefNamespace, `EntityWithIndexes`, `ExternalId`, `zzz_ext`}}))
+
+		for _, i := range issues {
+			Expect(i.Fixed).To(BeFalse())
+		}
+	})
+
+	It("Allow to rebuild indexes", func() {
+		issues, _ := checkAll(mappingsWithNewIndex, true)
+		// mismatched key ref is fixed on entries check, so it is not orphaned on key refs check
+		Expect(issues).To(HaveLen(6))
+		for _, i := range issues {
+			Expect(i.Fixed).To(BeTrue())
+		}
+
+		issues, _ = checkAll(mappingsWithNewIndex, false)
+		Expect(issues).To(BeEmpty())
+
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappingsWithNewIndex)
+			e, err := s.GetByKey(&schema.EntityWithIndexes{}, `ExternalId`, []string{`bbb_ext`}, &schema.EntityWithIndexes{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(e.(*schema.EntityWithIndexes).Id).To(Equal(`bbb`))
+
+			e, err = s.GetByKey(&schema.EntityWithIndexes{}, `ExternalId`, []string{`ccc_ext`}, &schema.EntityWithIndexes{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(e.(*schema.EntityWithIndexes).Id).To(Equal(`ccc`))
+
+			list, err := s.ListByIndex(&schema.EntityWithIndexes{}, `Value`, []string{`1`})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.(*schema.EntityWithIndexesList).Items).To(HaveLen(2))
+		})
+	})
+
+	It("Disallow to check with invalid bookmark", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			_, err := s.CheckIndexes(&schema.EntityWithIndexes{}, 2, `some`)
+			Expect(errors.Is(err, mapping.ErrIndexCheckBookmarkInvalid)).To(BeTrue())
+
+			_, err = s.CheckIndexes(&schema.EntityWithIndexes{}, 0, ``)
+			Expect(errors.Is(err, mapping.ErrIndexCheckPageSizeInvalid)).To(BeTrue())
+		})
+	})
+
+	It("Allow to check and rebuild indexes of entries in private collection", func() {
+		const collection = `private`
+		privateMappings := mapping.StateMappings{}.Add(&schema.EntityWithIndexes{},
+			mapping.PKeyId(),
+			mapping.List(&schema.EntityWithIndexesList{}),
+			mapping.UniqKey(`ExternalId`),
+			mapping.WithCollection(collection))
+		privateCC, privateCtx := testcc.NewTxHandler(`index_check_private`)
+
+		check := func(rebuild bool) []*stateschema.IndexIssue {
+			var issues []*stateschema.IndexIssue
+			privateCC.Tx(func() {
+				s := mapping.WrapState(privateCtx.State(), privateMappings)
+				res, err := s.CheckIndexes(&schema.EntityWithIndexes{}, 10, ``)
+				if rebuild {
+					res, err = s.RebuildIndexes(&schema.EntityWithIndexes{}, 10, ``)
+				}
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res.CheckedEntries).To(BeNumerically("==", 2))
+				issues = res.Issues
+			})
+			return issues
+		}
+
+		privateCC.Tx(func() {
+			s := mapping.WrapState(privateCtx.State(), privateMappings)
+			Expect(s.Insert(&schema.EntityWithIndexes{Id: `aaa`, ExternalId: `aaa_ext`})).To(Succeed())
+			Expect(s.Insert(&schema.EntityWithIndexes{Id: `bbb`, ExternalId: `bbb_ext`})).To(Succeed())
+		})
+		Expect(check(false)).To(BeEmpty())
+
+		privateCC.Tx(func() {
+			Expect(privateCtx.State().DeletePrivate(collection, mapping.NewKeyRefInstance(
+				&schema.EntityWithIndexes{}, `ExternalId`, state.Key{`bbb_ext`}, nil))).To(Succeed())
+		})
+		Expect(issuesOf(check(true), stateschema.IndexIssue_MISSING, `ExternalId`)).To(Equal([]state.Key{
+			{mapping.KeyRefNamespace, `EntityWithIndexes`, `ExternalId`, `bbb_ext`}}))
+		Expect(check(false)).To(BeEmpty())
+	})
+
+	Context(`Chaincode handlers`, func() {
+
+		var (
+			newChaincode = func() *router.Chaincode {
+				r := router.New(`index_check`).Init(owner.InvokeSetFromCreator)
+				mapping.AddIndexHandlers(r, `index`, mappings, owner.Only)
+				return router.NewChaincode(r)
+			}
+
+			indexCC  = testcc.NewMockStub(`index_check`, newChaincode())
+			nonOwner = identitytestdata.Certificates[1].MustIdentity(`SOME_MSP`)
+		)
+
+		It("Allow owner to check indexes", func() {
+			indexCC.From(Owner).Init()
+
+			req := &stateschema.IndexCheckRequest{Schema: `EntityWithIndexes`, PageSize: 10}
+			res := expectcc.PayloadIs(indexCC.From(Owner).Query(`index`+mapping.QueryIndexCheckFunc, req),
+				&stateschema.IndexCheckResult{}).(*stateschema.IndexCheckResult)
+			Expect(res.Schema).To(Equal(`EntityWithIndexes`))
+			Expect(res.Bookmark).NotTo(BeEmpty())
+
+			req.Bookmark = res.Bookmark
+			res = expectcc.PayloadIs(indexCC.From(Owner).Invoke(`index`+mapping.InvokeIndexRebuildFunc, req),
+				&stateschema.IndexCheckResult{}).(*stateschema.IndexCheckResult)
+			Expect(res.Bookmark).To(BeEmpty())
+		})
+
+		It("Disallow non owner to check indexes", func() {
+			expectcc.ResponseError(indexCC.From(nonOwner).Query(`index`+mapping.QueryIndexCheckFunc,
+				&stateschema.IndexCheckRequest{Schema: `EntityWithIndexes`, PageSize: 10}), owner.ErrOwnerOnly)
+		})
+
+		It("Disallow to add handlers without access middleware", func() {
+			Expect(func() {
+				mapping.AddIndexHandlers(router.New(`index_check`), `index`, mappings, nil)
+			}).To(Panic())
+		})
+	})
+})
@@ -0,0 +1,146 @@
+package mapping_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/cckit/state"
+	"github.com/hyperledger-labs/cckit/state/mapping"
+	"github.com/hyperledger-labs/cckit/state/mapping/testdata/schema"
+	"github.com/hyperledger-labs/cckit/state/testdata"
+	stateSchema "github.com/hyperledger-labs/cckit/state/testdata/schema"
+	testcc "github.com/hyperledger-labs/cckit/testing"
+)
+
+var _ = Describe(`State mapping non-unique indexes`, func() {
+
+	var (
+		mappings = mapping.StateMappings{}.Add(&schema.EntityWithIndexes{},
+			mapping.PKeyId(),
+			mapping.List(&schema.EntityWithIndexesList{}),
+			mapping.UniqKey(`ExternalId`),
+			mapping.Index(`Value`))
+
+		cc, ctx = testcc.NewTxHandler(`indexes`)
+	)
+
+	listByValue := func(s mapping.MappedState, value string) []*schema.EntityWithIndexes {
+		list, err := s.ListByIndex(&schema.EntityWithIndexes{}, `Value`, []string{value})
+		Expect(err).NotTo(HaveOccurred())
+		return list.(*schema.EntityWithIndexesList).Items
+	}
+
+	It("Allow to insert entries with same non-unique index value", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(s.Insert(&schema.EntityWithIndexes{Id: `aaa`, ExternalId: `aaa_ext`, Value: 1})).To(Succeed())
+			Expect(s.Insert(&schema.EntityWithIndexes{Id: `bbb`, ExternalId: `bbb_ext`, Value: 1})).To(Succeed())
+			Expect(s.Insert(&schema.EntityWithIndexes{Id: `ccc`, ExternalId: `ccc_ext`, Value: 2})).To(Succeed())
+		})
+
+		cc.Tx(func() {
+			_, err := mapping.WrapState(ctx.State(), mappings).PutMany(
+				&schema.EntityWithIndexes{Id: `ddd`, ExternalId: `ddd_ext`, Value: 1},
+				&schema.EntityWithIndexes{Id: `eee`, ExternalId: `eee_ext`, Value: 1})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	It("Allow to list entries by non-unique index", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			items := listByValue(s, `1`)
+			Expect(items).To(HaveLen(4))
+			Expect(items[0].Id).To(Equal(`aaa`))
+			Expect(items[1].Id).To(Equal(`bbb`))
+
+			Expect(listByValue(s, `2`)).To(HaveLen(1))
+			Expect(listByValue(s, `3`)).To(HaveLen(0))
+		})
+	})
+
+	It("Allow to list entries by non-unique index with pagination", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			list, md, err := s.ListByIndexPaginated(&schema.EntityWithIndexes{}, `Value`, []string{`1`}, 3, ``)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.(*schema.EntityWithIndexesList).Items).To(HaveLen(3))
+			Expect(md.FetchedRecordsCount).To(BeNumerically("==", 3))
+
+			list, _, err = s.ListByIndexPaginated(&schema.EntityWithIndexes{}, `Value`, []string{`1`}, 3, md.Bookmark)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.(*schema.EntityWithIndexesList).Items).To(HaveLen(1))
+			Expect(list.(*schema.EntityWithIndexesList).Items[0].Id).To(Equal(`eee`))
+		})
+	})
+
+	It("Allow to update non-unique index value", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(s.Put(&schema.EntityWithIndexes{Id: `aaa`, ExternalId: `aaa_ext`, Value: 2})).To(Succeed())
+		})
+
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(listByValue(s, `1`)).To(HaveLen(3))
+			Expect(listByValue(s, `2`)).To(HaveLen(2))
+		})
+	})
+
+	It("Allow to delete entries with non-unique index", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(s.Delete(&schema.EntityWithIndexes{Id: `ccc`})).To(Succeed())
+			_, err := s.DeleteMany(&schema.EntityWithIndexes{Id: `ddd`}, &schema.EntityWithIndexes{Id: `eee`})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			items := listByValue(s, `2`)
+			Expect(items).To(HaveLen(1))
+			Expect(items[0].Id).To(Equal(`aaa`))
+			Expect(listByValue(s, `1`)).To(HaveLen(1))
+		})
+	})
+
+	It("Allow to put many mapped and unmapped entries in one batch", func() {
+		book := testdata.Books[0]
+		cc.Tx(func() {
+			_, err := mapping.WrapState(ctx.State(), mappings).PutMany(
+				&book,
+				&schema.EntityWithIndexes{Id: `fff`, ExternalId: `fff_ext`, Value: 3})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(listByValue(s, `3`)).To(HaveLen(1))
+
+			exists, err := s.Exists(&book)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeTrue())
+		})
+	})
+
+	It("Disallow to put many entries if unmapped entry in batch is invalid", func() {
+		cc.Tx(func() {
+			_, err := mapping.WrapState(ctx.State(), mappings).PutMany(
+				&stateSchema.Book{Title: `without id`},
+				&schema.EntityWithIndexes{Id: `ggg`, ExternalId: `ggg_ext`, Value: 4})
+			Expect(err).To(MatchError(ContainSubstring(state.ErrKeyPartEmpty.Error())))
+		})
+
+		cc.Tx(func() {
+			Expect(listByValue(mapping.WrapState(ctx.State(), mappings), `4`)).To(HaveLen(0))
+		})
+	})
+
+	It("Disallow to list entries by index not defined in mapping", func() {
+		cc.Tx(func() {
+			_, err := mapping.WrapState(ctx.State(), mappings).ListByIndex(
+				&schema.EntityWithIndexes{}, `Unknown`, []string{`1`})
+			Expect(err).To(MatchError(ContainSubstring(mapping.ErrIndexNotExists.Error())))
+		})
+	})
+})
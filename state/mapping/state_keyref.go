@@ -20,6 +20,15 @@ var KeyRefMapper = &StateMapping{
 	primaryKeyer: KeyRefIDKeyer,
 }
 
+// KeyRefNonUniqKeyer keyer for KeyRef entity of non-unique index, primary key is part of key ref key
+var KeyRefNonUniqKeyer = attrsKeyer([]string{`Schema`, `Idx`, `RefKey`, `PKey`})
+
+var KeyRefNonUniqMapper = &StateMapping{
+	schema:       &schema.KeyRef{},
+	namespace:    state.Key{KeyRefNamespace},
+	primaryKeyer: KeyRefNonUniqKeyer,
+}
+
 var KeyRefIDMapper = &StateMapping{
 	schema:       &schema.KeyRefId{},
 	namespace:    state.Key{KeyRefNamespace},
@@ -47,6 +56,16 @@ func NewKeyRefInstance(target interface{}, idx string, refKey, pKey state.Key) *
 	return NewStateInstance(NewKeyRef(target, idx, refKey, pKey), KeyRefMapper)
 }
 
+func NewKeyRefNonUniqInstance(target interface{}, idx string, refKey, pKey state.Key) *StateInstance {
+	return NewStateInstance(NewKeyRef(target, idx, refKey, pKey), KeyRefNonUniqMapper)
+}
+
+// KeyRefPrefix returns prefix of key refs with index value,
+// index value can be partial for index with multiple fields
+func KeyRefPrefix(target interface{}, idx string, refKey state.Key) state.Key {
+	return state.Key{KeyRefNamespace, strings.Join(SchemaNamespace(target), `-`), idx}.Append(refKey)
+}
+
 func NewKeyRefIDInstance(target interface{},
 	idx string, refKey state.Key, toBytesConverter serialize.ToBytesConverter) *StateInstance {
 	return NewStateInstance(
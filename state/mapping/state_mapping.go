@@ -26,6 +26,8 @@ type (
 	StateMapper interface {
 		Schema() interface{}
 		List() interface{}
+		// HistoryList returns history list proto, can be nil
+		HistoryList() proto.Message
 		Namespace() state.Key
 		// PrimaryKey returns primary key for entry
 		PrimaryKey(instance interface{}) (state.Key, error)
@@ -34,6 +36,31 @@ type (
 		//KeyerFor returns target entity if mapper is key mapper
 		KeyerFor() (schema interface{})
 		Indexes() []*StateIndex
+		Index(name string) *StateIndex
+		// EncodeKey encodes field value to key part with mapping key encoders
+		EncodeKey(field string, value interface{}) (state.Key, error)
+		// DecodeKey decodes primary key to instance of schema with primary key fields filled
+		DecodeKey(key state.Key) (instance interface{}, err error)
+		// Counters returns counters of mapped entries
+		Counters() []*StateCounter
+		Counter(name string) *StateCounter
+		CounterKey(name string, groupValues ...string) state.Key
+		// PrimaryKeyAttrs returns fields of primary key, if primary key is based on fields
+		PrimaryKeyAttrs() []string
+		// Refs returns references to entries of another schemas
+		Refs() []*StateRef
+		Ref(name string) *StateRef
+		// Collection returns private data collection of mapped entries, can be nil
+		Collection() *StateCollection
+		// FieldEncryption returns encrypted fields of mapped entries
+		FieldEncryption() []*StateFieldEncryption
+		// Expiry returns expiry of mapped entries, can be nil
+		Expiry() *StateExpiry
+		// ChangeEvents returns true if change events are emitted for mapped entries
+		ChangeEvents() bool
+		// Sequence returns sequence for primary key field, can be nil
+		Sequence() *StateSequence
+		SequenceKey() state.Key
 	}
 
 	// InstanceKeyer returns key of an state entry instance
@@ -42,12 +69,22 @@ type (
 
 	// StateMapping defines metadata for mapping from schema to state keys/values
 	StateMapping struct {
-		schema         interface{}
-		namespace      state.Key     // prefix for primary key
-		keyerForSchema interface{}   // schema is keyer for another schema ( for example *schema.StaffId for *schema.Staff )
-		primaryKeyer   InstanceKeyer // primary key always one
-		list           interface{}   // list schema
-		indexes        []*StateIndex // additional keys
+		schema          interface{}
+		namespace       state.Key     // prefix for primary key
+		keyerForSchema  interface{}   // schema is keyer for another schema ( for example *schema.StaffId for *schema.Staff )
+		primaryKeyer    InstanceKeyer // primary key always one
+		primaryKeyAttrs []string      // fields of primary key, if primary key is based on fields
+		keyEncoders     keyEncoders   // encoders of key fields
+		list            interface{}   // list schema
+		historyList     proto.Message // history list schema
+		indexes         []*StateIndex // additional keys
+		counters        []*StateCounter
+		sequence        *StateSequence
+		refs            []*StateRef
+		collection      *StateCollection // private data collection, entries are stored in
+		fieldEncryption []*StateFieldEncryption
+		expiry          *StateExpiry
+		changeEvents    bool
 	}
 
 	StateMappings map[string]*StateMapping
@@ -62,7 +99,8 @@ func mapKey(schema interface{}) string {
 
 func (smm StateMappings) Add(schema interface{}, opts ...StateMappingOpt) StateMappings {
 	sm := &StateMapping{
-		schema: schema,
+		schema:      schema,
+		keyEncoders: make(keyEncoders),
 	}
 
 	for _, opt := range opts {
@@ -70,6 +108,8 @@ func (smm StateMappings) Add(schema interface{}, opts ...StateMappingOpt) StateM
 	}
 
 	applyStateMappingDefaults(sm)
+	// encrypted fields are checked against primary key and indexes of mapping
+	checkFieldEncryption(sm)
 	smm[mapKey(schema)] = sm
 	return smm
 }
@@ -169,6 +209,10 @@ func (sm *StateMapping) DefaultNamespace() state.Key {
 	return SchemaNamespace(sm.schema)
 }
 
+func (sm *StateMapping) PrimaryKeyAttrs() []string {
+	return sm.primaryKeyAttrs
+}
+
 func (sm *StateMapping) Indexes() []*StateIndex {
 	return sm.indexes
 }
@@ -181,6 +225,10 @@ func (sm *StateMapping) List() interface{} {
 	return sm.list
 }
 
+func (sm *StateMapping) HistoryList() proto.Message {
+	return sm.historyList
+}
+
 func (sm *StateMapping) PrimaryKey(entity interface{}) (state.Key, error) {
 	if sm.primaryKeyer == nil {
 		return nil, fmt.Errorf(`%s: schema "%s", namespace : "%s"`,
@@ -213,6 +261,15 @@ func (sm *StateMapping) Keys(entity interface{}) ([]state.KeyValue, error) {
 		}
 
 		for _, key := range idxKeys {
+			if !idx.Uniq {
+				// entries with empty value of non-unique index are not indexed
+				if !idx.Required && strings.Join(key, ``) == `` {
+					continue
+				}
+				// key will be <`_idx`,{SchemaName},{idxName}, {Key[1]},... {Key[n}}, {PKey[1]},... {PKey[n]}>
+				stateKeys = append(stateKeys, NewKeyRefNonUniqInstance(sm.schema, idx.Name, key, pk))
+				continue
+			}
 			// key will be <`_idx`,{SchemaName},{idxName}, {Key[1]},... {Key[n}}>s
 			stateKeys = append(stateKeys, NewKeyRefInstance(sm.schema, idx.Name, key, pk))
 		}
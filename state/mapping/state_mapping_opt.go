@@ -51,6 +51,14 @@ func List(list proto.Message) StateMappingOpt {
 	}
 }
 
+// HistoryList defined history list container, it must have `Items` attr,
+// items must have `TxId`, `Timestamp`, `IsDeleted` and `Value` attrs
+func HistoryList(list proto.Message) StateMappingOpt {
+	return func(sm *StateMapping, smm StateMappings) {
+		sm.historyList = list
+	}
+}
+
 // UniqKey defined uniq key in entity
 func UniqKey(name string, fields ...[]string) StateMappingOpt {
 	var ff []string
@@ -65,34 +73,77 @@ func UniqKey(name string, fields ...[]string) StateMappingOpt {
 	})
 }
 
+// Index defines non-unique index in entity, multiple entries can have same index value
+func Index(name string, fields ...[]string) StateMappingOpt {
+	var ff []string
+	if len(fields) > 0 {
+		ff = fields[0]
+	}
+	return WithIndex(&StateIndexDef{
+		Name:    name,
+		Fields:  ff,
+		NonUniq: true,
+	})
+}
+
+// RangeIndex defines non-unique index with ordered key refs, entries can be listed by index value range.
+// Order preserving key encoding is used for index fields in index keys only, if key encoding is not set
+// for field explicitly, primary key and other indexes are not affected
+func RangeIndex(name string, fields ...[]string) StateMappingOpt {
+	var ff []string
+	if len(fields) > 0 {
+		ff = fields[0]
+	}
+	return WithIndex(&StateIndexDef{
+		Name:              name,
+		Fields:            ff,
+		NonUniq:           true,
+		defaultKeyEncoder: KeyEncodingOrdered,
+	})
+}
+
 func WithIndex(idx *StateIndexDef) StateMappingOpt {
 	return func(sm *StateMapping, smm StateMappings) {
 		if idx.Name == `` {
 			return
 		}
 
-		var keyer InstanceMultiKeyer
+		var (
+			keyer    InstanceMultiKeyer
+			aa       []string
+			encoders = sm.keyEncoders
+		)
+		if idx.keyEncoders != nil {
+			encoders = idx.keyEncoders
+		}
 		if idx.Keyer != nil {
 			keyer = idx.Keyer
 		} else {
-			aa := []string{idx.Name}
+			aa = []string{idx.Name}
 			if len(idx.Fields) > 0 {
 				aa = idx.Fields
 			}
 
+			if idx.defaultKeyEncoder != nil {
+				encoders = sm.keyEncoders.withDefault(idx.defaultKeyEncoder, aa)
+			}
+
 			// multiple external ids refers to one entry
 			if idx.Multi {
 				keyer = attrMultiKeyer(aa[0])
 			} else {
-				keyer = keyerAsMulti(attrsKeyer(aa))
+				keyer = keyerAsMulti(attrsKeyerWith(aa, encoders))
 			}
 		}
 
 		_ = sm.AddIndex(&StateIndex{
 			Name:     idx.Name,
-			Uniq:     true,
+			Uniq:     !idx.NonUniq,
 			Required: idx.Required,
+			Fields:   aa,
 			Keyer:    keyer,
+
+			keyEncoders: encoders,
 		})
 	}
 }
@@ -104,7 +155,8 @@ func PKeySchema(pkeySchema interface{}) StateMappingOpt {
 	attrs := attrsFrom(pkeySchema)
 
 	return func(sm *StateMapping, smm StateMappings) {
-		sm.primaryKeyer = attrsKeyer(attrs)
+		sm.primaryKeyer = attrsKeyerWith(attrs, sm.keyEncoders)
+		sm.primaryKeyAttrs = attrs
 
 		// inherit namespace from "parent" mapping
 		namespace := sm.namespace
@@ -115,6 +167,7 @@ func PKeySchema(pkeySchema interface{}) StateMappingOpt {
 		//add mapping for schema identifier
 		smm.Add(
 			pkeySchema,
+			withKeyEncoders(sm.keyEncoders),
 			WithNamespace(namespace),
 			PKeyAttr(attrs...),
 			KeyerFor(sm.schema))
@@ -123,7 +176,8 @@ func PKeySchema(pkeySchema interface{}) StateMappingOpt {
 
 func PKeyAttr(attrs ...string) StateMappingOpt {
 	return func(sm *StateMapping, smm StateMappings) {
-		sm.primaryKeyer = attrsKeyer(attrs)
+		sm.primaryKeyer = attrsKeyerWith(attrs, sm.keyEncoders)
+		sm.primaryKeyAttrs = attrs
 	}
 }
 
@@ -136,14 +190,43 @@ func PKeyId() StateMappingOpt {
 // with namespace from mapping schema
 func PKeyComplexId(pkeySchema interface{}) StateMappingOpt {
 	return func(sm *StateMapping, smm StateMappings) {
-		sm.primaryKeyer = attrsKeyer([]string{`Id`})
+		sm.primaryKeyer = attrsKeyerWith([]string{`Id`}, sm.keyEncoders)
+		sm.primaryKeyAttrs = []string{`Id`}
 		smm.Add(pkeySchema,
+			withKeyEncoders(sm.keyEncoders),
 			WithNamespace(SchemaNamespace(sm.schema)),
 			PKeyAttr(attrsFrom(pkeySchema)...),
 			KeyerFor(sm.schema))
 	}
 }
 
+// WithKeyEncoding sets key encoder for mapping fields, used in primary key and indexes.
+// If fields are not set, encoder is used for all fields with supported types
+func WithKeyEncoding(encoder KeyEncoder, fields ...string) StateMappingOpt {
+	return func(sm *StateMapping, smm StateMappings) {
+		if len(fields) == 0 {
+			sm.keyEncoders[``] = encoder
+			return
+		}
+		for _, field := range fields {
+			sm.keyEncoders[field] = encoder
+		}
+	}
+}
+
+// OrderedKeys sets order preserving key encoding for all mapping fields,
+// so iteration over mapped entries returns entries in natural order of key fields
+func OrderedKeys() StateMappingOpt {
+	return WithKeyEncoding(KeyEncodingOrdered)
+}
+
+// withKeyEncoders shares key encoders with mapping of primary key schema
+func withKeyEncoders(encoders keyEncoders) StateMappingOpt {
+	return func(sm *StateMapping, smm StateMappings) {
+		sm.keyEncoders = encoders
+	}
+}
+
 func PKeyer(pkeyer InstanceKeyer) StateMappingOpt {
 	return func(sm *StateMapping, smm StateMappings) {
 		sm.primaryKeyer = pkeyer
@@ -174,6 +257,11 @@ func attrsFrom(schema interface{}) (attrs []string) {
 
 // attrsKeyer creates instance keyer
 func attrsKeyer(attrs []string) InstanceKeyer {
+	return attrsKeyerWith(attrs, nil)
+}
+
+// attrsKeyerWith creates instance keyer, field values are encoded with key encoders
+func attrsKeyerWith(attrs []string, encoders keyEncoders) InstanceKeyer {
 	return func(instance interface{}) (state.Key, error) {
 		var key = state.Key{}
 		inst := reflect.Indirect(reflect.ValueOf(instance))
@@ -185,7 +273,7 @@ func attrsKeyer(attrs []string) InstanceKeyer {
 				return nil, fmt.Errorf(`%s: %s`, ErrFieldNotExists, attr)
 			}
 
-			keyPart, err := keyFromValue(v)
+			keyPart, err := keyFromValueWith(encoders, attr, v)
 			if err != nil {
 				return nil, fmt.Errorf(`key from field %s.%s: %s`, mapKey(instance), attr, err)
 			}
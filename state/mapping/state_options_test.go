@@ -0,0 +1,110 @@
+package mapping_test
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/cckit/state"
+	"github.com/hyperledger-labs/cckit/state/mapping"
+	"github.com/hyperledger-labs/cckit/state/mapping/testdata/schema"
+	testcc "github.com/hyperledger-labs/cckit/testing"
+)
+
+var _ = Describe(`State mapping from proto options`, func() {
+
+	var (
+		mappings = schema.WithOptionsStateMappings
+		cc, ctx  = testcc.NewTxHandler(`options`)
+	)
+
+	ids := func(list interface{}) []string {
+		var ids []string
+		for _, item := range list.(*schema.EntityWithOptionsList).Items {
+			ids = append(ids, item.Id)
+		}
+		return ids
+	}
+
+	createdAt := func(day int) *timestamp.Timestamp {
+		ts, _ := ptypes.TimestampProto(time.Date(2021, 1, day, 0, 0, 0, 0, time.UTC))
+		return ts
+	}
+
+	entity := func(id string, tags []string, category string, value int32, day int) *schema.EntityWithOptions {
+		return &schema.EntityWithOptions{
+			Id:         id,
+			ExternalId: id + `_ext`,
+			Tags:       tags,
+			Category:   category,
+			Value:      value,
+			CreatedAt:  createdAt(day),
+		}
+	}
+
+	It("Allow to use generated state mappings", func() {
+		m, err := mappings.Get(&schema.EntityWithOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m.Namespace()).To(Equal(state.Key{`entity-with-options`}))
+		Expect(m.List()).To(BeAssignableToTypeOf(&schema.EntityWithOptionsList{}))
+
+		Expect(m.Index(`ExternalId`).Uniq).To(BeTrue())
+		Expect(m.Index(`ExternalId`).Required).To(BeTrue())
+		Expect(m.Index(`Tag`).Uniq).To(BeFalse())
+		Expect(m.Index(`CategoryValue`).Fields).To(Equal([]string{`Category`, `Value`}))
+		Expect(m.Index(`CreatedAt`)).NotTo(BeNil())
+
+		key, err := m.PrimaryKey(&schema.EntityWithOptions{Id: `aaa`})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(key).To(BeEquivalentTo([]string{`entity-with-options`, `aaa`}))
+	})
+
+	It("Allow to use generated event mappings", func() {
+		Expect(schema.WithOptionsEventMappings.Exists(&schema.EntityWithOptionsCreated{})).To(BeTrue())
+		Expect(schema.WithOptionsEventMappings.Exists(&schema.EntityWithOptions{})).To(BeFalse())
+	})
+
+	It("Allow to insert entries with indexes from proto options", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(s.Insert(entity(`aaa`, []string{`red`, `green`}, `x`, 1, 1))).To(Succeed())
+			Expect(s.Insert(entity(`bbb`, []string{`green`}, `x`, 2, 2))).To(Succeed())
+			Expect(s.Insert(entity(`ccc`, nil, `y`, 1, 3))).To(Succeed())
+		})
+
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(s.Insert(entity(`ddd`, nil, `y`, 1, 3))).To(Succeed())
+			// uniq index
+			err := s.Insert(&schema.EntityWithOptions{Id: `eee`, ExternalId: `aaa_ext`})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	It("Allow to get and list entries by indexes from proto options", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+
+			e, err := s.GetByKey(&schema.EntityWithOptions{}, `ExternalId`, []string{`bbb_ext`},
+				&schema.EntityWithOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(e.(*schema.EntityWithOptions).Id).To(Equal(`bbb`))
+
+			list, err := s.ListByIndex(&schema.EntityWithOptions{}, `Tag`, []string{`green`})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(list)).To(Equal([]string{`aaa`, `bbb`}))
+
+			list, err = s.ListByIndex(&schema.EntityWithOptions{}, `CategoryValue`, []string{`y`, `1`})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(list)).To(Equal([]string{`ccc`, `ddd`}))
+
+			list, _, err = s.ListByRange(&schema.EntityWithOptions{}, `CreatedAt`,
+				createdAt(1), createdAt(2), 0, ``)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(list)).To(Equal([]string{`aaa`, `bbb`}))
+		})
+	})
+})
@@ -0,0 +1,130 @@
+package mapping
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/hyperledger-labs/cckit/state"
+	"github.com/hyperledger-labs/cckit/state/schema"
+)
+
+const (
+	// PatchEventName name of event, emitted on patch with PatchEvent option
+	PatchEventName = `EntryPatched`
+)
+
+type (
+	// PatchOpts options of mapped entry patch
+	PatchOpts struct {
+		event state.Event
+	}
+
+	PatchOpt func(*PatchOpts)
+)
+
+// PatchEvent emits EntryPatched event with field mask and patched entry value.
+// Value is not included in event for entries stored in private data collection
+func PatchEvent(event state.Event) PatchOpt {
+	return func(opts *PatchOpts) {
+		opts.event = event
+	}
+}
+
+// Patch loads current entry value from state, applies fields from field mask, taken from entry,
+// and puts patched entry to state with indexes update. Entry must have primary key fields filled,
+// field mask paths are proto field names, nested fields are separated with dot
+func (s *Impl) Patch(entry interface{}, mask *fieldmaskpb.FieldMask, opts ...PatchOpt) (interface{}, error) {
+	patchOpts := &PatchOpts{}
+	for _, opt := range opts {
+		opt(patchOpts)
+	}
+
+	msg, ok := entry.(proto.Message)
+	if !ok {
+		return nil, ErrEntryTypeNotSupported
+	}
+
+	mapped, err := s.mappings.Map(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(mask.GetPaths()) == 0 || !mask.IsValid(proto.MessageV2(msg)) {
+		return nil, fmt.Errorf(`%w: %s`, ErrFieldMaskInvalid, strings.Join(mask.GetPaths(), `,`))
+	}
+
+	key, err := mapped.Key()
+	if err != nil {
+		return nil, err
+	}
+
+	cur, err := s.Get(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	patched := proto.Clone(cur.(proto.Message))
+	for _, path := range mask.GetPaths() {
+		patchField(proto.MessageV2(patched).ProtoReflect(), proto.MessageV2(msg).ProtoReflect(), strings.Split(path, `.`))
+	}
+
+	if err = s.Put(patched); err != nil {
+		return nil, err
+	}
+
+	if patchOpts.event != nil {
+		if err = s.patchEvent(patchOpts.event, mapped.Mapper(), key, mask, patched); err != nil {
+			return nil, err
+		}
+	}
+
+	return patched, nil
+}
+
+// patchField copies field value by path from src to dst, intermediate messages are created if not exist
+func patchField(dst, src protoreflect.Message, path []string) {
+	fd := dst.Descriptor().Fields().ByName(protoreflect.Name(path[0]))
+
+	if len(path) == 1 {
+		if src.Has(fd) {
+			dst.Set(fd, src.Get(fd))
+		} else {
+			dst.Clear(fd)
+		}
+		return
+	}
+
+	if !src.Has(fd) {
+		dst.Clear(fd)
+		return
+	}
+	patchField(dst.Mutable(fd).Message(), src.Get(fd).Message(), path[1:])
+}
+
+func (s *Impl) patchEvent(
+	event state.Event, m StateMapper, key state.Key, mask *fieldmaskpb.FieldMask, patched proto.Message) error {
+	patchedEvent := &schema.EntryPatched{
+		Schema: strings.Join(SchemaNamespace(m.Schema()), `-`),
+		PKey:   key,
+		Mask:   mask,
+	}
+
+	// private data is not exposed in event
+	if m.Collection() == nil {
+		// value in event is the same as in state, encrypted fields are not exposed
+		stored, err := s.encryptEntry(patched)
+		if err != nil {
+			return err
+		}
+		if patchedEvent.Value, err = ptypes.MarshalAny(stored.(proto.Message)); err != nil {
+			return err
+		}
+	}
+
+	return event.Set(PatchEventName, patchedEvent)
+}
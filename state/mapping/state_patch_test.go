@@ -0,0 +1,109 @@
+package mapping_test
+
+import (
+	"errors"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/hyperledger-labs/cckit/state"
+	"github.com/hyperledger-labs/cckit/state/mapping"
+	"github.com/hyperledger-labs/cckit/state/mapping/testdata/schema"
+	stateschema "github.com/hyperledger-labs/cckit/state/schema"
+	testcc "github.com/hyperledger-labs/cckit/testing"
+)
+
+var _ = Describe(`State mapping patch`, func() {
+
+	var (
+		mappings = mapping.StateMappings{}.Add(&schema.EntityWithIndexes{},
+			mapping.PKeyId(),
+			mapping.List(&schema.EntityWithIndexesList{}),
+			mapping.UniqKey(`ExternalId`))
+
+		cc, ctx = testcc.NewTxHandler(`patch`)
+	)
+
+	It("Allow to insert entry", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(s.Insert(&schema.EntityWithIndexes{
+				Id:                  `aaa`,
+				ExternalId:          `aaa_ext`,
+				OptionalExternalIds: []string{`aaa_opt`},
+				Value:               1,
+			})).To(Succeed())
+		})
+	})
+
+	It("Allow to patch only masked fields", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			patched, err := s.Patch(&schema.EntityWithIndexes{Id: `aaa`, ExternalId: `not_patched`, Value: 2},
+				&fieldmaskpb.FieldMask{Paths: []string{`value`}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(patched.(*schema.EntityWithIndexes).Value).To(Equal(int32(2)))
+			Expect(patched.(*schema.EntityWithIndexes).ExternalId).To(Equal(`aaa_ext`))
+		})
+
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			e, err := s.Get(&schema.EntityWithIndexes{Id: `aaa`})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(e.(*schema.EntityWithIndexes).Value).To(Equal(int32(2)))
+			Expect(e.(*schema.EntityWithIndexes).ExternalId).To(Equal(`aaa_ext`))
+			Expect(e.(*schema.EntityWithIndexes).OptionalExternalIds).To(Equal([]string{`aaa_opt`}))
+		})
+	})
+
+	It("Allow to patch indexed field with index update and event", func() {
+		mask := &fieldmaskpb.FieldMask{Paths: []string{`external_id`, `optional_external_ids`}}
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			_, err := s.Patch(&schema.EntityWithIndexes{Id: `aaa`, ExternalId: `aaa_ext2`}, mask,
+				mapping.PatchEvent(ctx.Event()))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Expect(cc.MockStub.ChaincodeEvent.EventName).To(Equal(mapping.PatchEventName))
+		event := &stateschema.EntryPatched{}
+		Expect(proto.Unmarshal(cc.MockStub.ChaincodeEvent.Payload, event)).To(Succeed())
+		Expect(event.Schema).To(Equal(`EntityWithIndexes`))
+		Expect(event.PKey).To(Equal([]string{`EntityWithIndexes`, `aaa`}))
+		Expect(event.Mask.Paths).To(Equal(mask.Paths))
+
+		value := &schema.EntityWithIndexes{}
+		Expect(ptypes.UnmarshalAny(event.Value, value)).To(Succeed())
+		Expect(value.ExternalId).To(Equal(`aaa_ext2`))
+		Expect(value.OptionalExternalIds).To(BeEmpty())
+		Expect(value.Value).To(Equal(int32(2)))
+
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			_, err := s.GetByKey(&schema.EntityWithIndexes{}, `ExternalId`, []string{`aaa_ext`})
+			Expect(err).To(HaveOccurred())
+
+			e, err := s.GetByKey(&schema.EntityWithIndexes{}, `ExternalId`, []string{`aaa_ext2`}, &schema.EntityWithIndexes{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(e.(*schema.EntityWithIndexes).Id).To(Equal(`aaa`))
+		})
+	})
+
+	It("Disallow to patch with invalid field mask or not existing entry", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+
+			_, err := s.Patch(&schema.EntityWithIndexes{Id: `aaa`}, &fieldmaskpb.FieldMask{Paths: []string{`unknown`}})
+			Expect(errors.Is(err, mapping.ErrFieldMaskInvalid)).To(BeTrue())
+
+			_, err = s.Patch(&schema.EntityWithIndexes{Id: `aaa`}, &fieldmaskpb.FieldMask{})
+			Expect(errors.Is(err, mapping.ErrFieldMaskInvalid)).To(BeTrue())
+
+			_, err = s.Patch(&schema.EntityWithIndexes{Id: `bbb`}, &fieldmaskpb.FieldMask{Paths: []string{`value`}})
+			Expect(errors.Is(err, state.ErrKeyNotFound)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,128 @@
+package mapping_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/cckit/state"
+	"github.com/hyperledger-labs/cckit/state/mapping"
+	"github.com/hyperledger-labs/cckit/state/mapping/testdata/schema"
+	testcc "github.com/hyperledger-labs/cckit/testing"
+)
+
+var _ = Describe(`State mapping range indexes`, func() {
+
+	var (
+		mappings = mapping.StateMappings{}.Add(&schema.EntityWithIndexes{},
+			mapping.PKeyId(),
+			mapping.List(&schema.EntityWithIndexesList{}),
+			mapping.RangeIndex(`Value`))
+
+		cc, ctx = testcc.NewTxHandler(`ranges`)
+	)
+
+	ids := func(list interface{}) []string {
+		var ids []string
+		for _, item := range list.(*schema.EntityWithIndexesList).Items {
+			ids = append(ids, item.Id)
+		}
+		return ids
+	}
+
+	It("Allow to insert entries with range index", func() {
+		cc.Tx(func() {
+			_, err := mapping.WrapState(ctx.State(), mappings).InsertMany(
+				&schema.EntityWithIndexes{Id: `a`, Value: 100},
+				&schema.EntityWithIndexes{Id: `b`, Value: -5},
+				&schema.EntityWithIndexes{Id: `c`, Value: 20},
+				&schema.EntityWithIndexes{Id: `d`, Value: 3},
+				&schema.EntityWithIndexes{Id: `e`, Value: 3},
+				&schema.EntityWithIndexes{Id: `f`, Value: 9})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	It("Allow to list entries by index value range in natural order", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+
+			list, md, err := s.ListByRange(&schema.EntityWithIndexes{}, `Value`, 0, 20, 0, ``)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(md).To(BeNil())
+			Expect(ids(list)).To(Equal([]string{`d`, `e`, `f`, `c`}))
+
+			// boundary as schema instance, range not bounded from top
+			list, _, err = s.ListByRange(&schema.EntityWithIndexes{}, `Value`, &schema.EntityWithIndexes{Value: 10}, nil, 0, ``)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(list)).To(Equal([]string{`c`, `a`}))
+
+			list, _, err = s.ListByRange(&schema.EntityWithIndexes{}, `Value`, nil, 3, 0, ``)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(list)).To(Equal([]string{`b`, `d`, `e`}))
+		})
+	})
+
+	It("Allow to list entries by index value range with pagination", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+
+			list, md, err := s.ListByRange(&schema.EntityWithIndexes{}, `Value`, -10, 50, 2, ``)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(list)).To(Equal([]string{`b`, `d`}))
+			Expect(md.Bookmark).NotTo(BeEmpty())
+
+			list, md, err = s.ListByRange(&schema.EntityWithIndexes{}, `Value`, -10, 50, 2, md.Bookmark)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(list)).To(Equal([]string{`e`, `f`}))
+
+			list, md, err = s.ListByRange(&schema.EntityWithIndexes{}, `Value`, -10, 50, 2, md.Bookmark)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(list)).To(Equal([]string{`c`}))
+			Expect(md.FetchedRecordsCount).To(BeNumerically("==", 1))
+			Expect(md.Bookmark).To(BeEmpty())
+		})
+	})
+
+	It("Allow to list entries by updated index value", func() {
+		cc.Tx(func() {
+			Expect(mapping.WrapState(ctx.State(), mappings).Put(
+				&schema.EntityWithIndexes{Id: `a`, Value: 4})).To(Succeed())
+		})
+
+		cc.Tx(func() {
+			list, _, err := mapping.WrapState(ctx.State(), mappings).ListByRange(
+				&schema.EntityWithIndexes{}, `Value`, 3, 4, 0, ``)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(list)).To(Equal([]string{`d`, `e`, `a`}))
+		})
+	})
+
+	It("Allow to use ordered key encoding only in range index keys", func() {
+		pkeyMappings := mapping.StateMappings{}.Add(&schema.EntityWithIndexes{},
+			mapping.PKeyAttr(`Id`, `Value`),
+			mapping.RangeIndex(`Value`))
+
+		m, err := pkeyMappings.Get(&schema.EntityWithIndexes{})
+		Expect(err).NotTo(HaveOccurred())
+
+		// primary key field is encoded as is
+		pKey, err := pkeyMappings.PrimaryKey(&schema.EntityWithIndexes{Id: `a`, Value: 5})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pKey).To(Equal(m.Namespace().Append(state.Key{`a`, `5`})))
+
+		// range index key is order preserving
+		keys, err := m.Index(`Value`).Keyer(&schema.EntityWithIndexes{Id: `a`, Value: 5})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys).To(HaveLen(1))
+		Expect(keys[0]).NotTo(Equal(state.Key{`5`}))
+	})
+
+	It("Allow to list mapped entries by primary key range", func() {
+		cc.Tx(func() {
+			list, _, err := mapping.WrapState(ctx.State(), mappings).ListRange(
+				&schema.EntityWithIndexes{}, state.Key{`b`}, state.Key{`d`}, 0, ``)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(list)).To(Equal([]string{`b`, `c`, `d`}))
+		})
+	})
+})
@@ -0,0 +1,406 @@
+package mapping
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/hyperledger-labs/cckit/state"
+	"github.com/hyperledger-labs/cckit/state/schema"
+)
+
+// RefNamespace namespace for back references from referencing to referenced entries
+const RefNamespace = `_ref`
+
+// RefKeyer keyer for back reference, key of referenced entry goes first,
+// so entries referencing one entry can be listed by key prefix
+var RefKeyer = attrsKeyer([]string{`RefKey`, `Schema`, `Idx`, `PKey`})
+
+var RefMapper = &StateMapping{
+	schema:       &schema.KeyRef{},
+	namespace:    state.Key{RefNamespace},
+	primaryKeyer: RefKeyer,
+}
+
+type (
+	// RefOnDelete defines behavior of referencing entries, when referenced entry is deleted
+	RefOnDelete int
+
+	// StateRef reference from mapped entry fields to primary key of another mapped schema entry
+	StateRef struct {
+		Name string
+		// Fields of referencing entry, field values are primary key fields of target entry (without namespace)
+		Fields []string
+		// Target schema of referenced entry
+		Target   interface{}
+		OnDelete RefOnDelete
+	}
+
+	// mappedRef reference with mapper of referencing schema
+	mappedRef struct {
+		mapper StateMapper
+		ref    *StateRef
+	}
+)
+
+const (
+	// RefRestrict referenced entry can't be deleted, while referencing entries exist
+	RefRestrict RefOnDelete = iota
+	// RefCascade referencing entries are deleted with referenced entry
+	RefCascade
+	// RefSetNull reference fields of referencing entries are cleared, when referenced entry is deleted
+	RefSetNull
+)
+
+// Ref defines reference from entry fields to target schema entry, with restrict on delete behavior.
+// Reference with empty fields values is not checked
+func Ref(target interface{}, fields ...string) StateMappingOpt {
+	return WithRef(&StateRef{
+		Target:   target,
+		Fields:   fields,
+		OnDelete: RefRestrict,
+	})
+}
+
+func WithRef(ref *StateRef) StateMappingOpt {
+	return func(sm *StateMapping, smm StateMappings) {
+		if len(ref.Fields) == 0 {
+			return
+		}
+
+		r := *ref
+		if r.Name == `` {
+			r.Name = strings.Join(r.Fields, `-`)
+		}
+		sm.refs = append(sm.refs, &r)
+	}
+}
+
+func (sm *StateMapping) Refs() []*StateRef {
+	return sm.refs
+}
+
+func (sm *StateMapping) Ref(name string) *StateRef {
+	for _, r := range sm.refs {
+		if r.Name == name {
+			return r
+		}
+	}
+	return nil
+}
+
+// refsTo returns references from all mapped schemas to target schema, ordered by schema
+func (smm StateMappings) refsTo(target interface{}) []*mappedRef {
+	var keys []string
+	for k := range smm {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var refs []*mappedRef
+	for _, k := range keys {
+		for _, ref := range smm[k].refs {
+			if mapKey(ref.Target) == mapKey(target) {
+				refs = append(refs, &mappedRef{mapper: smm[k], ref: ref})
+			}
+		}
+	}
+	return refs
+}
+
+// RefPrefix returns prefix of back references to referenced entry from source schema entries
+func RefPrefix(targetKey state.Key, source interface{}, ref ...string) state.Key {
+	return state.Key{RefNamespace}.Append(targetKey).
+		Append(state.Key{strings.Join(SchemaNamespace(source), `-`)}).Append(ref)
+}
+
+// refTargetKey returns key of entry, referenced by instance fields, or nil if reference fields are empty
+func (s *Impl) refTargetKey(ref *StateRef, instance interface{}) (state.Key, error) {
+	target, err := s.mappings.Get(ref.Target)
+	if err != nil {
+		return nil, fmt.Errorf(`ref %s target: %w`, ref.Name, err)
+	}
+
+	var (
+		inst     = reflect.Indirect(reflect.ValueOf(instance))
+		attrs    = target.PrimaryKeyAttrs()
+		key      = target.Namespace()
+		notEmpty bool
+	)
+	for i, field := range ref.Fields {
+		v := inst.FieldByName(field)
+		if !v.IsValid() {
+			return nil, fmt.Errorf(`%w: %s`, ErrFieldNotExists, field)
+		}
+		notEmpty = notEmpty || !v.IsZero()
+
+		// target key field encoders are used
+		attr := field
+		if len(attrs) == len(ref.Fields) {
+			attr = attrs[i]
+		}
+		part, err := target.EncodeKey(attr, v.Interface())
+		if err != nil {
+			return nil, fmt.Errorf(`ref %s field %s: %w`, ref.Name, field, err)
+		}
+		key = key.Append(part)
+	}
+
+	if !notEmpty {
+		return nil, nil
+	}
+	return key, nil
+}
+
+// backRefs returns back references from mapped entry to referenced entries
+func (s *Impl) backRefs(mapped *StateInstance) ([]*schema.KeyRef, error) {
+	if len(mapped.Mapper().Refs()) == 0 {
+		return nil, nil
+	}
+
+	pKey, err := mapped.Key()
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []*schema.KeyRef
+	for _, ref := range mapped.Mapper().Refs() {
+		targetKey, err := s.refTargetKey(ref, mapped.instance)
+		if err != nil {
+			return nil, err
+		}
+		if targetKey == nil {
+			continue
+		}
+		refs = append(refs, NewKeyRef(mapped.Mapper().Schema(), ref.Name, targetKey, pKey))
+	}
+	return refs, nil
+}
+
+// putRefs checks referenced entries exist in state and updates back references
+func (s *Impl) putRefs(mapped, prevMapped *StateInstance) error {
+	refs, err := s.backRefs(mapped)
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range refs {
+		target, err := s.mappings.Get(mapped.Mapper().Ref(ref.Idx).Target)
+		if err != nil {
+			return err
+		}
+		exists, err := s.stateFor(target).Exists(state.Key(ref.RefKey))
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf(`%w: %s=%s`, ErrRefTargetNotFound, ref.Idx, state.Key(ref.RefKey))
+		}
+	}
+
+	var prevRefs []*schema.KeyRef
+	if prevMapped != nil {
+		if prevRefs, err = s.backRefs(prevMapped); err != nil {
+			return errors.Wrap(err, `prev refs`)
+		}
+	}
+
+	deleteRefs, insertRefs, err := KeyRefsDiff(refInstances(prevRefs), refInstances(refs))
+	if err != nil {
+		return errors.Wrap(err, `calculate refs diff`)
+	}
+
+	// back refs are stored with referencing entry, in public state or private data collection
+	st := s.stateFor(mapped.Mapper())
+	for _, r := range deleteRefs {
+		if err = st.Delete(r); err != nil {
+			return errors.Wrap(err, `delete back ref`)
+		}
+	}
+	for _, r := range insertRefs {
+		if err = st.Put(r); err != nil {
+			return errors.Wrap(err, `put back ref`)
+		}
+	}
+	return nil
+}
+
+// deleteRefs deletes back references of mapped entry
+func (s *Impl) deleteRefs(mapped *StateInstance) error {
+	refs, err := s.backRefs(mapped)
+	if err != nil {
+		return err
+	}
+
+	st := s.stateFor(mapped.Mapper())
+	for _, r := range refInstances(refs) {
+		if err = st.Delete(r); err != nil {
+			return errors.Wrap(err, `delete back ref`)
+		}
+	}
+	return nil
+}
+
+// deleteReferencing applies on delete behavior of references to mapped entry, entries in deleting are
+// already being deleted in current operation
+func (s *Impl) deleteReferencing(mapped *StateInstance, deleting map[string]bool) error {
+	refs := s.mappings.refsTo(mapped.Mapper().Schema())
+	if len(refs) == 0 {
+		return nil
+	}
+
+	targetKey, err := mapped.Key()
+	if err != nil {
+		return err
+	}
+
+	// referencing entry can reference target with multiple refs, on delete actions are grouped by entry
+	type referencing struct {
+		mapper     StateMapper
+		pKey       state.Key
+		cascade    bool
+		nullFields []string
+	}
+	var (
+		entries []*referencing
+		byKey   = make(map[string]*referencing)
+	)
+
+	// restrict is checked before any changes
+	for _, mr := range refs {
+		keyRefs, err := s.keyRefs(mr.mapper, RefPrefix(targetKey, mr.mapper.Schema(), mr.ref.Name))
+		if err != nil {
+			return err
+		}
+
+		for _, kr := range keyRefs {
+			pKey := state.Key(kr.PKey)
+			if deleting[pKey.String()] {
+				continue
+			}
+			if mr.ref.OnDelete == RefRestrict {
+				return fmt.Errorf(`%w: %s referenced by %s`, ErrEntryReferenced, targetKey, pKey)
+			}
+
+			r, ok := byKey[pKey.String()]
+			if !ok {
+				r = &referencing{mapper: mr.mapper, pKey: pKey}
+				byKey[pKey.String()] = r
+				entries = append(entries, r)
+			}
+			if mr.ref.OnDelete == RefCascade {
+				r.cascade = true
+			} else {
+				r.nullFields = append(r.nullFields, mr.ref.Fields...)
+			}
+		}
+	}
+
+	for _, r := range entries {
+		// entry can be deleted by cascade from previous referencing entry
+		if deleting[r.pKey.String()] {
+			continue
+		}
+
+		entry, err := s.stateFor(r.mapper).Get(r.pKey, r.mapper.Schema())
+		if err != nil {
+			return errors.Wrap(err, `referencing entry`)
+		}
+
+		if r.cascade {
+			s.Logger().Debug(`state mapped DELETE cascade`, zap.String(`key`, r.pKey.String()))
+			if err = s.delete(entry, deleting); err != nil {
+				return errors.Wrap(err, `cascade delete`)
+			}
+			continue
+		}
+
+		// entry is put with encryption
+		if err = s.decryptEntry(entry); err != nil {
+			return err
+		}
+		inst := reflect.Indirect(reflect.ValueOf(entry))
+		for _, field := range r.nullFields {
+			v := inst.FieldByName(field)
+			v.Set(reflect.Zero(v.Type()))
+		}
+		if err = s.Put(entry); err != nil {
+			return errors.Wrap(err, `set null reference`)
+		}
+	}
+
+	return nil
+}
+
+// ListReferencing returns entries of schema, referencing entry
+func (s *Impl) ListReferencing(entry interface{}, referencing interface{}) (interface{}, error) {
+	mapped, err := s.mappings.Map(entry)
+	if err != nil {
+		return nil, err
+	}
+	targetKey, err := mapped.Key()
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := s.mappings.Get(referencing)
+	if err != nil {
+		return nil, errors.Wrap(err, `mapping`)
+	}
+
+	prefix := RefPrefix(targetKey, referencing)
+	s.Logger().Debug(`state mapped LIST referencing`, zap.String(`prefix`, prefix.String()))
+
+	keyRefs, err := s.keyRefs(m, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	// entry can reference target with multiple refs
+	refs, seen := &schema.List{}, make(map[string]bool)
+	for _, kr := range keyRefs {
+		if seen[state.Key(kr.PKey).String()] {
+			continue
+		}
+		seen[state.Key(kr.PKey).String()] = true
+
+		item, err := ptypes.MarshalAny(kr)
+		if err != nil {
+			return nil, err
+		}
+		refs.Items = append(refs.Items, item)
+	}
+
+	return s.listFromKeyRefs(m, refs)
+}
+
+// keyRefs returns back refs with prefix, stored with referencing entries of mapping
+func (s *Impl) keyRefs(m StateMapper, prefix state.Key) ([]*schema.KeyRef, error) {
+	list, err := s.stateFor(m).List(prefix, &schema.KeyRef{})
+	if err != nil {
+		return nil, errors.Wrap(err, `back refs`)
+	}
+
+	var refs []*schema.KeyRef
+	for _, item := range list.(*schema.List).Items {
+		kr := &schema.KeyRef{}
+		if err = ptypes.UnmarshalAny(item, kr); err != nil {
+			return nil, errors.Wrap(err, `back ref`)
+		}
+		refs = append(refs, kr)
+	}
+	return refs, nil
+}
+
+func refInstances(refs []*schema.KeyRef) []state.KeyValue {
+	instances := make([]state.KeyValue, len(refs))
+	for i, r := range refs {
+		instances[i] = NewStateInstance(r, RefMapper)
+	}
+	return instances
+}
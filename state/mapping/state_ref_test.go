@@ -0,0 +1,163 @@
+package mapping_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/cckit/state/mapping"
+	"github.com/hyperledger-labs/cckit/state/mapping/testdata/schema"
+	testcc "github.com/hyperledger-labs/cckit/testing"
+)
+
+var _ = Describe(`State mapping references`, func() {
+
+	var (
+		mappings = mapping.StateMappings{}.
+				Add(&schema.Holder{}, mapping.PKeyId()).
+				Add(&schema.Policy{},
+				mapping.PKeyId(),
+				mapping.List(&schema.PolicyList{}),
+				mapping.Ref(&schema.Holder{}, `HolderId`)).
+			Add(&schema.Claim{},
+				mapping.PKeyId(),
+				mapping.List(&schema.ClaimList{}),
+				mapping.WithRef(&mapping.StateRef{
+					Target:   &schema.Policy{},
+					Fields:   []string{`PolicyId`},
+					OnDelete: mapping.RefCascade,
+				}),
+				mapping.WithRef(&mapping.StateRef{
+					Name:     `Related`,
+					Target:   &schema.Policy{},
+					Fields:   []string{`RelatedPolicyId`},
+					OnDelete: mapping.RefSetNull,
+				}))
+
+		cc, ctx = testcc.NewTxHandler(`refs`)
+	)
+
+	ids := func(list interface{}) []string {
+		var ids []string
+		switch l := list.(type) {
+		case *schema.PolicyList:
+			for _, item := range l.Items {
+				ids = append(ids, item.Id)
+			}
+		case *schema.ClaimList:
+			for _, item := range l.Items {
+				ids = append(ids, item.Id)
+			}
+		}
+		return ids
+	}
+
+	It("Allow to insert entries with existing references", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(s.Insert(&schema.Holder{Id: `h1`})).To(Succeed())
+		})
+
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(s.Insert(&schema.Policy{Id: `p1`, HolderId: `h1`})).To(Succeed())
+			Expect(s.Insert(&schema.Policy{Id: `p2`, HolderId: `h1`})).To(Succeed())
+			// empty reference is not checked
+			Expect(s.Insert(&schema.Policy{Id: `p3`})).To(Succeed())
+		})
+
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(s.Insert(&schema.Claim{Id: `c1`, PolicyId: `p1`, RelatedPolicyId: `p2`})).To(Succeed())
+			Expect(s.Insert(&schema.Claim{Id: `c2`, PolicyId: `p2`})).To(Succeed())
+			Expect(s.Insert(&schema.Claim{Id: `c3`, PolicyId: `p1`})).To(Succeed())
+		})
+	})
+
+	It("Disallow to insert or put entries with non existent references", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			err := s.Insert(&schema.Policy{Id: `p4`, HolderId: `h2`})
+			Expect(errors.Is(err, mapping.ErrRefTargetNotFound)).To(BeTrue())
+
+			err = s.Put(&schema.Claim{Id: `c1`, PolicyId: `p1`, RelatedPolicyId: `p4`})
+			Expect(errors.Is(err, mapping.ErrRefTargetNotFound)).To(BeTrue())
+		})
+	})
+
+	It("Allow to list referencing entries", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			list, err := s.ListReferencing(&schema.Holder{Id: `h1`}, &schema.Policy{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(list)).To(Equal([]string{`p1`, `p2`}))
+
+			list, err = s.ListReferencing(&schema.Policy{Id: `p1`}, &schema.Claim{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(list)).To(Equal([]string{`c1`, `c3`}))
+
+			// ordered by reference name, then by primary key
+			list, err = s.ListReferencing(&schema.Policy{Id: `p2`}, &schema.Claim{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(list)).To(Equal([]string{`c2`, `c1`}))
+		})
+	})
+
+	It("Disallow to delete entry with restrict references", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			err := s.Delete(&schema.Holder{Id: `h1`})
+			Expect(errors.Is(err, mapping.ErrEntryReferenced)).To(BeTrue())
+		})
+	})
+
+	It("Allow to delete entry with cascade and set null references", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(s.Delete(&schema.Policy{Id: `p2`})).To(Succeed())
+		})
+
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			// cascade
+			exists, err := s.Exists(&schema.Claim{Id: `c2`})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+
+			// set null
+			c1, err := s.Get(&schema.Claim{Id: `c1`})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c1.(*schema.Claim).PolicyId).To(Equal(`p1`))
+			Expect(c1.(*schema.Claim).RelatedPolicyId).To(BeEmpty())
+
+			list, err := s.ListReferencing(&schema.Policy{Id: `p2`}, &schema.Claim{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(list)).To(BeEmpty())
+
+			list, err = s.ListReferencing(&schema.Holder{Id: `h1`}, &schema.Policy{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(list)).To(Equal([]string{`p1`}))
+		})
+	})
+
+	It("Allow to delete entry after references are removed", func() {
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(s.Put(&schema.Policy{Id: `p1`})).To(Succeed())
+		})
+
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			Expect(s.Delete(&schema.Holder{Id: `h1`})).To(Succeed())
+			Expect(s.Delete(&schema.Policy{Id: `p1`})).To(Succeed())
+		})
+
+		cc.Tx(func() {
+			s := mapping.WrapState(ctx.State(), mappings)
+			claims, err := s.List(&schema.Claim{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(claims)).To(BeEmpty())
+		})
+	})
+})
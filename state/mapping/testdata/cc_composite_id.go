@@ -17,7 +17,8 @@ var (
 		mapping.WithNamespace(EntityCompositeIdNamespace),
 		//  schema for Primary Key
 		mapping.PKeySchema(&schema.EntityCompositeId{}),
-		mapping.List(&schema.EntityWithCompositeIdList{}))
+		mapping.List(&schema.EntityWithCompositeIdList{}),
+		mapping.HistoryList(&schema.EntityWithCompositeIdHistory{}))
 )
 
 func NewCompositeIdCC() *router.Chaincode {
@@ -35,6 +36,7 @@ func NewCompositeIdCC() *router.Chaincode {
 	r.
 		Query("list", queryListComposite).
 		Query("get", queryByIdComposite, defparam.Proto(&schema.EntityCompositeId{})).
+		Query("history", queryHistoryComposite, defparam.Proto(&schema.EntityCompositeId{})).
 		Invoke("create", invokeCreateComposite, defparam.Proto(&schema.CreateEntityWithCompositeId{})).
 		Invoke("update", invokeUpdateComposite, defparam.Proto(&schema.UpdateEntityWithCompositeId{})).
 		Invoke("delete", invokeDeleteComposite, defparam.Proto(&schema.EntityCompositeId{}))
@@ -46,6 +48,11 @@ func queryByIdComposite(c router.Context) (interface{}, error) {
 	return c.State().Get(c.Param().(*schema.EntityCompositeId))
 }
 
+func queryHistoryComposite(c router.Context) (interface{}, error) {
+	list, _, err := c.State().(mapping.MappedState).GetHistoryList(c.Param().(*schema.EntityCompositeId), 0, ``)
+	return list, err
+}
+
 func queryListComposite(c router.Context) (interface{}, error) {
 	return c.State().List(&schema.EntityWithCompositeId{})
 }
@@ -35,7 +35,10 @@ func NewIndexesCC() *router.Chaincode {
 		Query("getByOptMultiExternalId", queryByOptMultiExternalId, defparam.String()).
 		Invoke("create", invokeCreateIndexes, defparam.Proto(&schema.CreateEntityWithIndexes{})).
 		Invoke("update", invokeUpdateIndexes, defparam.Proto(&schema.UpdateEntityWithIndexes{})).
-		Invoke("delete", invokeDeleteIndexes, defparam.String())
+		Invoke("delete", invokeDeleteIndexes, defparam.String()).
+		Invoke("insertMany", invokeInsertManyIndexes, defparam.Proto(&schema.EntityWithIndexesList{})).
+		Invoke("putMany", invokePutManyIndexes, defparam.Proto(&schema.EntityWithIndexesList{})).
+		Invoke("deleteMany", invokeDeleteManyIndexes, defparam.Proto(&schema.EntityWithIndexesList{}))
 
 	return router.NewChaincode(r)
 }
@@ -78,6 +81,29 @@ func invokeDeleteIndexes(c router.Context) (interface{}, error) {
 	return nil, c.State().(mapping.MappedState).Delete(&schema.EntityWithIndexes{Id: c.Param().(string)})
 }
 
+func invokeInsertManyIndexes(c router.Context) (interface{}, error) {
+	_, err := c.State().InsertMany(entriesFromList(c.Param().(*schema.EntityWithIndexesList))...)
+	return nil, err
+}
+
+func invokePutManyIndexes(c router.Context) (interface{}, error) {
+	_, err := c.State().PutMany(entriesFromList(c.Param().(*schema.EntityWithIndexesList))...)
+	return nil, err
+}
+
+func invokeDeleteManyIndexes(c router.Context) (interface{}, error) {
+	_, err := c.State().DeleteMany(entriesFromList(c.Param().(*schema.EntityWithIndexesList))...)
+	return nil, err
+}
+
+func entriesFromList(list *schema.EntityWithIndexesList) []interface{} {
+	entries := make([]interface{}, len(list.Items))
+	for i, item := range list.Items {
+		entries[i] = item
+	}
+	return entries
+}
+
 func queryByExternalId(c router.Context) (interface{}, error) {
 	externalId := c.Param().(string)
 	return c.State().(mapping.MappedState).GetByKey(
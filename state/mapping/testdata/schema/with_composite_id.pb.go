@@ -373,6 +373,126 @@ func (x *UpdateEntityWithCompositeId) GetValue() int32 {
 	return 0
 }
 
+// EntityWithCompositeIdHistoryEntry
+type EntityWithCompositeIdHistoryEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TxId      string                 `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	IsDeleted bool                   `protobuf:"varint,3,opt,name=is_deleted,json=isDeleted,proto3" json:"is_deleted,omitempty"`
+	Value     *EntityWithCompositeId `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *EntityWithCompositeIdHistoryEntry) Reset() {
+	*x = EntityWithCompositeIdHistoryEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mapping_testdata_schema_with_composite_id_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EntityWithCompositeIdHistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntityWithCompositeIdHistoryEntry) ProtoMessage() {}
+
+func (x *EntityWithCompositeIdHistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_mapping_testdata_schema_with_composite_id_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityWithCompositeIdHistoryEntry.ProtoReflect.Descriptor instead.
+func (*EntityWithCompositeIdHistoryEntry) Descriptor() ([]byte, []int) {
+	return file_mapping_testdata_schema_with_composite_id_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *EntityWithCompositeIdHistoryEntry) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *EntityWithCompositeIdHistoryEntry) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *EntityWithCompositeIdHistoryEntry) GetIsDeleted() bool {
+	if x != nil {
+		return x.IsDeleted
+	}
+	return false
+}
+
+func (x *EntityWithCompositeIdHistoryEntry) GetValue() *EntityWithCompositeId {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// EntityWithCompositeIdHistory
+type EntityWithCompositeIdHistory struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items []*EntityWithCompositeIdHistoryEntry `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *EntityWithCompositeIdHistory) Reset() {
+	*x = EntityWithCompositeIdHistory{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mapping_testdata_schema_with_composite_id_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EntityWithCompositeIdHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntityWithCompositeIdHistory) ProtoMessage() {}
+
+func (x *EntityWithCompositeIdHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_mapping_testdata_schema_with_composite_id_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityWithCompositeIdHistory.ProtoReflect.Descriptor instead.
+func (*EntityWithCompositeIdHistory) Descriptor() ([]byte, []int) {
+	return file_mapping_testdata_schema_with_composite_id_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *EntityWithCompositeIdHistory) GetItems() []*EntityWithCompositeIdHistoryEntry {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
 var File_mapping_testdata_schema_with_composite_id_proto protoreflect.FileDescriptor
 
 var file_mapping_testdata_schema_with_composite_id_proto_rawDesc = []byte{
@@ -436,11 +556,30 @@ var file_mapping_testdata_schema_with_composite_id_proto_rawDesc = []byte{
 	0x68, 0x69, 0x72, 0x64, 0x50, 0x61, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
 	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05,
 	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x42, 0x41, 0x5a, 0x3f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x68, 0x79, 0x70, 0x65, 0x72, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x2d, 0x6c, 0x61, 0x62,
-	0x73, 0x2f, 0x63, 0x63, 0x6b, 0x69, 0x74, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2f, 0x6d, 0x61,
-	0x70, 0x70, 0x69, 0x6e, 0x67, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x64, 0x61, 0x74, 0x61, 0x2f, 0x73,
-	0x63, 0x68, 0x65, 0x6d, 0x61, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x75, 0x65, 0x22, 0xc6, 0x01, 0x0a, 0x21, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x57, 0x69, 0x74,
+	0x68, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x65, 0x49, 0x64, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x13, 0x0a, 0x05, 0x74, 0x78, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x78, 0x49, 0x64, 0x12, 0x38, 0x0a,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x64, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x73, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x33, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x45,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0x57, 0x69, 0x74, 0x68, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x65, 0x49, 0x64, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x5f, 0x0a, 0x1c, 0x45,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0x57, 0x69, 0x74, 0x68, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x65, 0x49, 0x64, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x3f, 0x0a, 0x05, 0x69,
+	0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x57, 0x69, 0x74, 0x68, 0x43, 0x6f,
+	0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x65, 0x49, 0x64, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x42, 0x41, 0x5a, 0x3f,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x79, 0x70, 0x65, 0x72,
+	0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x2d, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x63, 0x63, 0x6b, 0x69,
+	0x74, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2f, 0x6d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x2f,
+	0x74, 0x65, 0x73, 0x74, 0x64, 0x61, 0x74, 0x61, 0x2f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -455,26 +594,31 @@ func file_mapping_testdata_schema_with_composite_id_proto_rawDescGZIP() []byte {
 	return file_mapping_testdata_schema_with_composite_id_proto_rawDescData
 }
 
-var file_mapping_testdata_schema_with_composite_id_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_mapping_testdata_schema_with_composite_id_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_mapping_testdata_schema_with_composite_id_proto_goTypes = []interface{}{
-	(*EntityWithCompositeId)(nil),       // 0: schema.EntityWithCompositeId
-	(*EntityCompositeId)(nil),           // 1: schema.EntityCompositeId
-	(*EntityWithCompositeIdList)(nil),   // 2: schema.EntityWithCompositeIdList
-	(*CreateEntityWithCompositeId)(nil), // 3: schema.CreateEntityWithCompositeId
-	(*UpdateEntityWithCompositeId)(nil), // 4: schema.UpdateEntityWithCompositeId
-	(*timestamppb.Timestamp)(nil),       // 5: google.protobuf.Timestamp
+	(*EntityWithCompositeId)(nil),             // 0: schema.EntityWithCompositeId
+	(*EntityCompositeId)(nil),                 // 1: schema.EntityCompositeId
+	(*EntityWithCompositeIdList)(nil),         // 2: schema.EntityWithCompositeIdList
+	(*CreateEntityWithCompositeId)(nil),       // 3: schema.CreateEntityWithCompositeId
+	(*UpdateEntityWithCompositeId)(nil),       // 4: schema.UpdateEntityWithCompositeId
+	(*EntityWithCompositeIdHistoryEntry)(nil), // 5: schema.EntityWithCompositeIdHistoryEntry
+	(*EntityWithCompositeIdHistory)(nil),      // 6: schema.EntityWithCompositeIdHistory
+	(*timestamppb.Timestamp)(nil),             // 7: google.protobuf.Timestamp
 }
 var file_mapping_testdata_schema_with_composite_id_proto_depIdxs = []int32{
-	5, // 0: schema.EntityWithCompositeId.id_third_part:type_name -> google.protobuf.Timestamp
-	5, // 1: schema.EntityCompositeId.id_third_part:type_name -> google.protobuf.Timestamp
+	7, // 0: schema.EntityWithCompositeId.id_third_part:type_name -> google.protobuf.Timestamp
+	7, // 1: schema.EntityCompositeId.id_third_part:type_name -> google.protobuf.Timestamp
 	0, // 2: schema.EntityWithCompositeIdList.items:type_name -> schema.EntityWithCompositeId
-	5, // 3: schema.CreateEntityWithCompositeId.id_third_part:type_name -> google.protobuf.Timestamp
-	5, // 4: schema.UpdateEntityWithCompositeId.id_third_part:type_name -> google.protobuf.Timestamp
-	5, // [5:5] is the sub-list for method output_type
-	5, // [5:5] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	7, // 3: schema.CreateEntityWithCompositeId.id_third_part:type_name -> google.protobuf.Timestamp
+	7, // 4: schema.UpdateEntityWithCompositeId.id_third_part:type_name -> google.protobuf.Timestamp
+	7, // 5: schema.EntityWithCompositeIdHistoryEntry.timestamp:type_name -> google.protobuf.Timestamp
+	0, // 6: schema.EntityWithCompositeIdHistoryEntry.value:type_name -> schema.EntityWithCompositeId
+	5, // 7: schema.EntityWithCompositeIdHistory.items:type_name -> schema.EntityWithCompositeIdHistoryEntry
+	8, // [8:8] is the sub-list for method output_type
+	8, // [8:8] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_mapping_testdata_schema_with_composite_id_proto_init() }
@@ -543,6 +687,30 @@ func file_mapping_testdata_schema_with_composite_id_proto_init() {
 				return nil
 			}
 		}
+		file_mapping_testdata_schema_with_composite_id_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EntityWithCompositeIdHistoryEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mapping_testdata_schema_with_composite_id_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EntityWithCompositeIdHistory); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -550,7 +718,7 @@ func file_mapping_testdata_schema_with_composite_id_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_mapping_testdata_schema_with_composite_id_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -80,6 +80,29 @@ var _ = Describe(`PrivateState`, func() {
 			Expect(book3FromCC).To(Equal(book2Updated))
 		})
 
+		It("Allow to get entry hash", func() {
+			bookJson, _ := json.Marshal(testdata.PrivateBooks[1])
+			hash := booksCC.Invoke(`privateBookHash`, testdata.PrivateBooks[1].Id).Payload
+			Expect(hash).To(Equal(state.PrivateDataHash(bookJson)))
+		})
+
+		It("Allow to verify entry with presented value", func() {
+			verified := expectcc.PayloadIs(
+				booksCC.Invoke(`privateBookVerify`, &testdata.PrivateBooks[1]), true).(bool)
+			Expect(verified).To(BeTrue())
+
+			bookChanged := testdata.PrivateBooks[1]
+			bookChanged.Title = `changed title`
+			verified = expectcc.PayloadIs(
+				booksCC.Invoke(`privateBookVerify`, &bookChanged), true).(bool)
+			Expect(verified).To(BeFalse())
+		})
+
+		It("Disallow to verify non existent entry", func() {
+			expectcc.ResponseError(booksCC.Invoke(`privateBookVerify`, &schema.PrivateBook{Id: `unknown`}),
+				state.ErrKeyNotFound)
+		})
+
 		It("Allow to delete entry", func() {
 			expectcc.ResponseOk(booksCC.From(Owner).Invoke(`privateBookDelete`, testdata.PrivateBooks[0].Id))
 			books := expectcc.PayloadIs(booksCC.Invoke(`privateBookList`), &[]schema.PrivateBook{}).([]schema.PrivateBook)
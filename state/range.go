@@ -0,0 +1,110 @@
+package state
+
+import (
+	"unicode/utf8"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// ListRange returns slice of target type with keys in range [from, to] inside namespace.
+// Fabric doesn't support range queries with composite keys, so range is built on partial composite key query.
+// With pagination page is queried with paginated partial composite key query, starting from bookmark
+// or `from` boundary, so ListRange with pagination, as ListPaginated, can be used only in queries.
+// Bookmark is key of first entry of next page.
+// If pageSize is 0, all entries in range are returned: entries before `from` boundary are skipped,
+// iteration stops after `to` boundary
+func (s *Impl) ListRange(
+	namespace interface{}, from, to Key, pageSize int32, bookmark string, target ...interface{}) (
+	interface{}, *pb.QueryResponseMetadata, error) {
+	stateList, err := NewStateList(target...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	n, t, err := s.normalizeAndTransformKey(namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(t) == 0 {
+		return nil, nil, ErrKeyPartsLength
+	}
+
+	var startKey, endKey string
+	if len(from) > 0 {
+		if startKey, err = KeyToComposite(s.stub, t.Append(from)); err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(to) > 0 {
+		if endKey, err = KeyToComposite(s.stub, t.Append(to)); err != nil {
+			return nil, nil, err
+		}
+		// all keys with `to` prefix are in range
+		endKey += string(utf8.MaxRune)
+	}
+
+	s.logger.Debug(`state LIST RANGE`,
+		zap.String(`namespace`, n.String()), zap.String(`from`, from.String()), zap.String(`to`, to.String()),
+		zap.Int32("pageSize", pageSize), zap.String("bookmark", bookmark))
+
+	// next page starts from bookmark
+	if bookmark > startKey {
+		startKey = bookmark
+	}
+
+	var (
+		objectType, attrs = t.Parts()
+		iter              shim.StateQueryIteratorInterface
+		md                *pb.QueryResponseMetadata
+	)
+	if pageSize > 0 {
+		// paginated query bookmark is key, query starts from
+		iter, md, err = s.GetStateByPartialCompositeKeyWithPagination(objectType, attrs, pageSize, startKey)
+	} else {
+		iter, err = s.GetStateByPartialCompositeKey(objectType, attrs)
+	}
+	if err != nil {
+		return nil, nil, errors.Wrap(err, `state iterator`)
+	}
+	defer func() { _ = iter.Close() }()
+
+	var fetched int32
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if kv.Key < startKey {
+			continue
+		}
+		// range is finished, next page is empty
+		if endKey != `` && kv.Key > endKey {
+			if md != nil {
+				md.Bookmark = ``
+			}
+			break
+		}
+
+		item, err := s.serializer.FromBytesTo(kv.Value, stateList.itemTarget)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, `transform list entry`)
+		}
+		stateList.AddElementToList(item)
+		fetched++
+	}
+
+	if md != nil {
+		md.FetchedRecordsCount = fetched
+		// last entry of page can be last entry in range
+		if endKey != `` && md.Bookmark > endKey {
+			md.Bookmark = ``
+		}
+	}
+
+	list, err := stateList.Get()
+	return list, md, err
+}
@@ -0,0 +1,49 @@
+package state_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/cckit/state"
+	testcc "github.com/hyperledger-labs/cckit/testing"
+)
+
+var _ = Describe(`State keys range`, func() {
+
+	var (
+		cc, ctx   = testcc.NewTxHandler(`keys_range`)
+		namespace = state.Key{`range`}
+	)
+
+	It("Allow to read keys in batches, starting from bookmark", func() {
+		cc.Tx(func() {
+			for _, id := range []string{`a`, `b`, `c`, `d`, `e`} {
+				Expect(ctx.State().Put(namespace.Append(state.Key{id}), id)).To(Succeed())
+			}
+		})
+
+		cc.Tx(func() {
+			var (
+				batches  [][]string
+				bookmark string
+			)
+			for {
+				keys, next, err := ctx.State().KeysFrom(namespace, bookmark, 2)
+				Expect(err).NotTo(HaveOccurred())
+				batches = append(batches, keys)
+				if next == `` {
+					break
+				}
+				bookmark = next
+			}
+
+			Expect(batches).To(HaveLen(3))
+			Expect(batches[0]).To(HaveLen(2))
+			Expect(batches[2]).To(HaveLen(1))
+
+			all, err := ctx.State().Keys(namespace)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(append(append(batches[0], batches[1]...), batches[2]...)).To(Equal(all))
+		})
+	})
+})
@@ -10,6 +10,8 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	anypb "google.golang.org/protobuf/types/known/anypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -21,6 +23,107 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type EntryChanged_Operation int32
+
+const (
+	EntryChanged_CREATE EntryChanged_Operation = 0
+	EntryChanged_UPDATE EntryChanged_Operation = 1
+	EntryChanged_DELETE EntryChanged_Operation = 2
+)
+
+// Enum value maps for EntryChanged_Operation.
+var (
+	EntryChanged_Operation_name = map[int32]string{
+		0: "CREATE",
+		1: "UPDATE",
+		2: "DELETE",
+	}
+	EntryChanged_Operation_value = map[string]int32{
+		"CREATE": 0,
+		"UPDATE": 1,
+		"DELETE": 2,
+	}
+)
+
+func (x EntryChanged_Operation) Enum() *EntryChanged_Operation {
+	p := new(EntryChanged_Operation)
+	*p = x
+	return p
+}
+
+func (x EntryChanged_Operation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EntryChanged_Operation) Descriptor() protoreflect.EnumDescriptor {
+	return file_schema_schema_proto_enumTypes[0].Descriptor()
+}
+
+func (EntryChanged_Operation) Type() protoreflect.EnumType {
+	return &file_schema_schema_proto_enumTypes[0]
+}
+
+func (x EntryChanged_Operation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EntryChanged_Operation.Descriptor instead.
+func (EntryChanged_Operation) EnumDescriptor() ([]byte, []int) {
+	return file_schema_schema_proto_rawDescGZIP(), []int{6, 0}
+}
+
+type IndexIssue_Kind int32
+
+const (
+	// key ref of entry index value not exists
+	IndexIssue_MISSING IndexIssue_Kind = 0
+	// key ref is not produced by index of any existing entry
+	IndexIssue_ORPHANED IndexIssue_Kind = 1
+	// key ref of uniq index refers to another entry
+	IndexIssue_MISMATCHED IndexIssue_Kind = 2
+)
+
+// Enum value maps for IndexIssue_Kind.
+var (
+	IndexIssue_Kind_name = map[int32]string{
+		0: "MISSING",
+		1: "ORPHANED",
+		2: "MISMATCHED",
+	}
+	IndexIssue_Kind_value = map[string]int32{
+		"MISSING":    0,
+		"ORPHANED":   1,
+		"MISMATCHED": 2,
+	}
+)
+
+func (x IndexIssue_Kind) Enum() *IndexIssue_Kind {
+	p := new(IndexIssue_Kind)
+	*p = x
+	return p
+}
+
+func (x IndexIssue_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (IndexIssue_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_schema_schema_proto_enumTypes[1].Descriptor()
+}
+
+func (IndexIssue_Kind) Type() protoreflect.EnumType {
+	return &file_schema_schema_proto_enumTypes[1]
+}
+
+func (x IndexIssue_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use IndexIssue_Kind.Descriptor instead.
+func (IndexIssue_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_schema_schema_proto_rawDescGZIP(), []int{9, 0}
+}
+
 // KeyRefId  id part of key reference
 type KeyRefId struct {
 	state         protoimpl.MessageState
@@ -210,93 +313,958 @@ func (x *List) GetItems() []*anypb.Any {
 	return nil
 }
 
-var File_schema_schema_proto protoreflect.FileDescriptor
+// HistoryEntry state entry history record
+type HistoryEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_schema_schema_proto_rawDesc = []byte{
-	0x0a, 0x13, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x63, 0x68,
-	0x65, 0x6d, 0x61, 0x1a, 0x19, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x4d,
-	0x0a, 0x08, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x66, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63,
-	0x68, 0x65, 0x6d, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65,
-	0x6d, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x69, 0x64, 0x78, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x65, 0x66, 0x5f, 0x6b, 0x65, 0x79, 0x18,
-	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x66, 0x4b, 0x65, 0x79, 0x22, 0x60, 0x0a,
-	0x06, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x66, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d,
-	0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12,
-	0x10, 0x0a, 0x03, 0x69, 0x64, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x69, 0x64,
-	0x78, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x65, 0x66, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x66, 0x4b, 0x65, 0x79, 0x12, 0x13, 0x0a, 0x05, 0x70, 0x5f,
-	0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x70, 0x4b, 0x65, 0x79, 0x22,
-	0x32, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x05, 0x69, 0x74,
-	0x65, 0x6d, 0x73, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x68, 0x79, 0x70, 0x65, 0x72, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x2d, 0x6c, 0x61,
-	0x62, 0x73, 0x2f, 0x63, 0x63, 0x6b, 0x69, 0x74, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2f, 0x73,
-	0x63, 0x68, 0x65, 0x6d, 0x61, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	// transaction id, changed entry
+	TxId string `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	// transaction timestamp
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// entry is deleted in transaction
+	IsDeleted bool `protobuf:"varint,3,opt,name=is_deleted,json=isDeleted,proto3" json:"is_deleted,omitempty"`
+	// entry value
+	Value *anypb.Any `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
 }
 
-var (
-	file_schema_schema_proto_rawDescOnce sync.Once
-	file_schema_schema_proto_rawDescData = file_schema_schema_proto_rawDesc
-)
+func (x *HistoryEntry) Reset() {
+	*x = HistoryEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_schema_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_schema_schema_proto_rawDescGZIP() []byte {
-	file_schema_schema_proto_rawDescOnce.Do(func() {
-		file_schema_schema_proto_rawDescData = protoimpl.X.CompressGZIP(file_schema_schema_proto_rawDescData)
-	})
-	return file_schema_schema_proto_rawDescData
+func (x *HistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_schema_schema_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
-var file_schema_schema_proto_goTypes = []interface{}{
-	(*KeyRefId)(nil),  // 0: state.schema.KeyRefId
-	(*KeyRef)(nil),    // 1: state.schema.KeyRef
-	(*List)(nil),      // 2: state.schema.List
-	(*anypb.Any)(nil), // 3: google.protobuf.Any
+func (*HistoryEntry) ProtoMessage() {}
+
+func (x *HistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_schema_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_schema_schema_proto_depIdxs = []int32{
-	3, // 0: state.schema.List.items:type_name -> google.protobuf.Any
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+
+// Deprecated: Use HistoryEntry.ProtoReflect.Descriptor instead.
+func (*HistoryEntry) Descriptor() ([]byte, []int) {
+	return file_schema_schema_proto_rawDescGZIP(), []int{3}
 }
 
-func init() { file_schema_schema_proto_init() }
-func file_schema_schema_proto_init() {
-	if File_schema_schema_proto != nil {
-		return
+func (x *HistoryEntry) GetTxId() string {
+	if x != nil {
+		return x.TxId
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_schema_schema_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KeyRefId); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return ""
+}
+
+func (x *HistoryEntry) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *HistoryEntry) GetIsDeleted() bool {
+	if x != nil {
+		return x.IsDeleted
+	}
+	return false
+}
+
+func (x *HistoryEntry) GetValue() *anypb.Any {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// HistoryEntryList list of state entry history records
+type HistoryEntryList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items []*HistoryEntry `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *HistoryEntryList) Reset() {
+	*x = HistoryEntryList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_schema_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HistoryEntryList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryEntryList) ProtoMessage() {}
+
+func (x *HistoryEntryList) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_schema_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_schema_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KeyRef); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryEntryList.ProtoReflect.Descriptor instead.
+func (*HistoryEntryList) Descriptor() ([]byte, []int) {
+	return file_schema_schema_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *HistoryEntryList) GetItems() []*HistoryEntry {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// EntryPatched event, emitted when fields of state entry are patched
+type EntryPatched struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// entity type
+	Schema string `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	// primary key of patched entry
+	PKey []string `protobuf:"bytes,2,rep,name=p_key,json=pKey,proto3" json:"p_key,omitempty"`
+	// patched fields
+	Mask *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=mask,proto3" json:"mask,omitempty"`
+	// entry value after patch
+	Value *anypb.Any `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *EntryPatched) Reset() {
+	*x = EntryPatched{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_schema_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EntryPatched) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryPatched) ProtoMessage() {}
+
+func (x *EntryPatched) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_schema_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_schema_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*List); i {
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryPatched.ProtoReflect.Descriptor instead.
+func (*EntryPatched) Descriptor() ([]byte, []int) {
+	return file_schema_schema_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *EntryPatched) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+func (x *EntryPatched) GetPKey() []string {
+	if x != nil {
+		return x.PKey
+	}
+	return nil
+}
+
+func (x *EntryPatched) GetMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.Mask
+	}
+	return nil
+}
+
+func (x *EntryPatched) GetValue() *anypb.Any {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// EntryChanged event, emitted on create, update or delete of mapped entry with change events option
+type EntryChanged struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Operation EntryChanged_Operation `protobuf:"varint,1,opt,name=operation,proto3,enum=state.schema.EntryChanged_Operation" json:"operation,omitempty"`
+	// entity type
+	Schema string `protobuf:"bytes,2,opt,name=schema,proto3" json:"schema,omitempty"`
+	// namespace of entity primary key
+	Namespace []string `protobuf:"bytes,3,rep,name=namespace,proto3" json:"namespace,omitempty"`
+	// primary key of changed entry
+	PKey []string `protobuf:"bytes,4,rep,name=p_key,json=pKey,proto3" json:"p_key,omitempty"`
+	// entry value after change, empty on delete
+	Value *anypb.Any `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	// sha256 hash of entry value before change, empty on create
+	PrevValueHash []byte `protobuf:"bytes,6,opt,name=prev_value_hash,json=prevValueHash,proto3" json:"prev_value_hash,omitempty"`
+}
+
+func (x *EntryChanged) Reset() {
+	*x = EntryChanged{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_schema_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EntryChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryChanged) ProtoMessage() {}
+
+func (x *EntryChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_schema_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryChanged.ProtoReflect.Descriptor instead.
+func (*EntryChanged) Descriptor() ([]byte, []int) {
+	return file_schema_schema_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *EntryChanged) GetOperation() EntryChanged_Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return EntryChanged_CREATE
+}
+
+func (x *EntryChanged) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+func (x *EntryChanged) GetNamespace() []string {
+	if x != nil {
+		return x.Namespace
+	}
+	return nil
+}
+
+func (x *EntryChanged) GetPKey() []string {
+	if x != nil {
+		return x.PKey
+	}
+	return nil
+}
+
+func (x *EntryChanged) GetValue() *anypb.Any {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *EntryChanged) GetPrevValueHash() []byte {
+	if x != nil {
+		return x.PrevValueHash
+	}
+	return nil
+}
+
+// BatchedEvent event, set in transaction and emitted as part of events batch
+type BatchedEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// event name
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// event payload
+	Payload []byte `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (x *BatchedEvent) Reset() {
+	*x = BatchedEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_schema_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchedEvent) ProtoMessage() {}
+
+func (x *BatchedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_schema_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchedEvent.ProtoReflect.Descriptor instead.
+func (*BatchedEvent) Descriptor() ([]byte, []int) {
+	return file_schema_schema_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BatchedEvent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BatchedEvent) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+// EventBatch events, set in transaction. Fabric keeps only last event of transaction,
+// so multiple events are emitted as single batch event
+type EventBatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Events []*BatchedEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+}
+
+func (x *EventBatch) Reset() {
+	*x = EventBatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_schema_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EventBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventBatch) ProtoMessage() {}
+
+func (x *EventBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_schema_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventBatch.ProtoReflect.Descriptor instead.
+func (*EventBatch) Descriptor() ([]byte, []int) {
+	return file_schema_schema_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EventBatch) GetEvents() []*BatchedEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// IndexIssue inconsistency of mapped entry index key ref
+type IndexIssue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind IndexIssue_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=state.schema.IndexIssue_Kind" json:"kind,omitempty"`
+	// idx name from entity type
+	Idx string `protobuf:"bytes,2,opt,name=idx,proto3" json:"idx,omitempty"`
+	// key of key ref
+	Key []string `protobuf:"bytes,3,rep,name=key,proto3" json:"key,omitempty"`
+	// primary key of entry, key ref should refer to
+	PKey []string `protobuf:"bytes,4,rep,name=p_key,json=pKey,proto3" json:"p_key,omitempty"`
+	// primary key of entry, key ref actually refers to
+	RefPKey []string `protobuf:"bytes,5,rep,name=ref_p_key,json=refPKey,proto3" json:"ref_p_key,omitempty"`
+	// issue is fixed with index rebuild
+	Fixed bool `protobuf:"varint,6,opt,name=fixed,proto3" json:"fixed,omitempty"`
+}
+
+func (x *IndexIssue) Reset() {
+	*x = IndexIssue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_schema_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IndexIssue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexIssue) ProtoMessage() {}
+
+func (x *IndexIssue) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_schema_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexIssue.ProtoReflect.Descriptor instead.
+func (*IndexIssue) Descriptor() ([]byte, []int) {
+	return file_schema_schema_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *IndexIssue) GetKind() IndexIssue_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return IndexIssue_MISSING
+}
+
+func (x *IndexIssue) GetIdx() string {
+	if x != nil {
+		return x.Idx
+	}
+	return ""
+}
+
+func (x *IndexIssue) GetKey() []string {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *IndexIssue) GetPKey() []string {
+	if x != nil {
+		return x.PKey
+	}
+	return nil
+}
+
+func (x *IndexIssue) GetRefPKey() []string {
+	if x != nil {
+		return x.RefPKey
+	}
+	return nil
+}
+
+func (x *IndexIssue) GetFixed() bool {
+	if x != nil {
+		return x.Fixed
+	}
+	return false
+}
+
+// IndexCheckRequest request for index check or rebuild of mapped entity type
+type IndexCheckRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// entity type
+	Schema string `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	// number of entries or key refs processed in one batch
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// bookmark from previous batch result
+	Bookmark string `protobuf:"bytes,3,opt,name=bookmark,proto3" json:"bookmark,omitempty"`
+}
+
+func (x *IndexCheckRequest) Reset() {
+	*x = IndexCheckRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_schema_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IndexCheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexCheckRequest) ProtoMessage() {}
+
+func (x *IndexCheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_schema_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexCheckRequest.ProtoReflect.Descriptor instead.
+func (*IndexCheckRequest) Descriptor() ([]byte, []int) {
+	return file_schema_schema_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *IndexCheckRequest) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+func (x *IndexCheckRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *IndexCheckRequest) GetBookmark() string {
+	if x != nil {
+		return x.Bookmark
+	}
+	return ""
+}
+
+// IndexCheckResult result of index check or rebuild batch
+type IndexCheckResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// entity type
+	Schema string        `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	Issues []*IndexIssue `protobuf:"bytes,2,rep,name=issues,proto3" json:"issues,omitempty"`
+	// number of entries checked in batch
+	CheckedEntries uint32 `protobuf:"varint,3,opt,name=checked_entries,json=checkedEntries,proto3" json:"checked_entries,omitempty"`
+	// number of key refs checked in batch
+	CheckedKeyRefs uint32 `protobuf:"varint,4,opt,name=checked_key_refs,json=checkedKeyRefs,proto3" json:"checked_key_refs,omitempty"`
+	// bookmark for next batch, empty if check is finished
+	Bookmark string `protobuf:"bytes,5,opt,name=bookmark,proto3" json:"bookmark,omitempty"`
+}
+
+func (x *IndexCheckResult) Reset() {
+	*x = IndexCheckResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_schema_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IndexCheckResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexCheckResult) ProtoMessage() {}
+
+func (x *IndexCheckResult) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_schema_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexCheckResult.ProtoReflect.Descriptor instead.
+func (*IndexCheckResult) Descriptor() ([]byte, []int) {
+	return file_schema_schema_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *IndexCheckResult) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+func (x *IndexCheckResult) GetIssues() []*IndexIssue {
+	if x != nil {
+		return x.Issues
+	}
+	return nil
+}
+
+func (x *IndexCheckResult) GetCheckedEntries() uint32 {
+	if x != nil {
+		return x.CheckedEntries
+	}
+	return 0
+}
+
+func (x *IndexCheckResult) GetCheckedKeyRefs() uint32 {
+	if x != nil {
+		return x.CheckedKeyRefs
+	}
+	return 0
+}
+
+func (x *IndexCheckResult) GetBookmark() string {
+	if x != nil {
+		return x.Bookmark
+	}
+	return ""
+}
+
+var File_schema_schema_proto protoreflect.FileDescriptor
+
+var file_schema_schema_proto_rawDesc = []byte{
+	0x0a, 0x13, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x1a, 0x19, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x20,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x4d, 0x0a, 0x08, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x66, 0x49, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x78, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x69, 0x64, 0x78, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x65, 0x66, 0x5f, 0x6b,
+	0x65, 0x79, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x66, 0x4b, 0x65, 0x79,
+	0x22, 0x60, 0x0a, 0x06, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x66, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x69, 0x64, 0x78, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x65, 0x66, 0x5f, 0x6b, 0x65, 0x79, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x66, 0x4b, 0x65, 0x79, 0x12, 0x13, 0x0a,
+	0x05, 0x70, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x70, 0x4b,
+	0x65, 0x79, 0x22, 0x32, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x05, 0x69, 0x74,
+	0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52,
+	0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0xa8, 0x01, 0x0a, 0x0c, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x13, 0x0a, 0x05, 0x74, 0x78, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x78, 0x49, 0x64, 0x12, 0x38, 0x0a, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x64, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x73, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x22, 0x44, 0x0a, 0x10, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x30, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x97, 0x01, 0x0a, 0x0c, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x50, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x12, 0x13, 0x0a, 0x05, 0x70, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x04, 0x70, 0x4b, 0x65, 0x79, 0x12, 0x2e, 0x0a, 0x04, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52,
+	0x04, 0x6d, 0x61, 0x73, 0x6b, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x22, 0xa2, 0x02, 0x0a, 0x0c, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x64, 0x12, 0x42, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x24, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x64, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x1c,
+	0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x13, 0x0a, 0x05,
+	0x70, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x70, 0x4b, 0x65,
+	0x79, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x26, 0x0a,
+	0x0f, 0x70, 0x72, 0x65, 0x76, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x70, 0x72, 0x65, 0x76, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x48, 0x61, 0x73, 0x68, 0x22, 0x2f, 0x0a, 0x09, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x10, 0x00, 0x12, 0x0a,
+	0x0a, 0x06, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45,
+	0x4c, 0x45, 0x54, 0x45, 0x10, 0x02, 0x22, 0x3c, 0x0a, 0x0c, 0x42, 0x61, 0x74, 0x63, 0x68, 0x65,
+	0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x61, 0x79,
+	0x6c, 0x6f, 0x61, 0x64, 0x22, 0x40, 0x0a, 0x0a, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x12, 0x32, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x22, 0xdd, 0x01, 0x0a, 0x0a, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x49, 0x73, 0x73, 0x75, 0x65, 0x12, 0x31, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x49, 0x73, 0x73, 0x75, 0x65, 0x2e, 0x4b, 0x69,
+	0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x78, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x69, 0x64, 0x78, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x13, 0x0a, 0x05,
+	0x70, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x70, 0x4b, 0x65,
+	0x79, 0x12, 0x1a, 0x0a, 0x09, 0x72, 0x65, 0x66, 0x5f, 0x70, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x66, 0x50, 0x4b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x66, 0x69, 0x78, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x69,
+	0x78, 0x65, 0x64, 0x22, 0x31, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x0b, 0x0a, 0x07, 0x4d,
+	0x49, 0x53, 0x53, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x4f, 0x52, 0x50, 0x48,
+	0x41, 0x4e, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x49, 0x53, 0x4d, 0x41, 0x54,
+	0x43, 0x48, 0x45, 0x44, 0x10, 0x02, 0x22, 0x64, 0x0a, 0x11, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x62, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x62, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x22, 0xcb, 0x01, 0x0a,
+	0x10, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x30, 0x0a, 0x06, 0x69, 0x73, 0x73,
+	0x75, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x49, 0x73,
+	0x73, 0x75, 0x65, 0x52, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x45, 0x6e, 0x74,
+	0x72, 0x69, 0x65, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x5f,
+	0x6b, 0x65, 0x79, 0x5f, 0x72, 0x65, 0x66, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x66, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x62, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x62, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x79, 0x70, 0x65, 0x72, 0x6c, 0x65,
+	0x64, 0x67, 0x65, 0x72, 0x2d, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x63, 0x63, 0x6b, 0x69, 0x74, 0x2f,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x2f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_schema_schema_proto_rawDescOnce sync.Once
+	file_schema_schema_proto_rawDescData = file_schema_schema_proto_rawDesc
+)
+
+func file_schema_schema_proto_rawDescGZIP() []byte {
+	file_schema_schema_proto_rawDescOnce.Do(func() {
+		file_schema_schema_proto_rawDescData = protoimpl.X.CompressGZIP(file_schema_schema_proto_rawDescData)
+	})
+	return file_schema_schema_proto_rawDescData
+}
+
+var file_schema_schema_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_schema_schema_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_schema_schema_proto_goTypes = []interface{}{
+	(EntryChanged_Operation)(0),   // 0: state.schema.EntryChanged.Operation
+	(IndexIssue_Kind)(0),          // 1: state.schema.IndexIssue.Kind
+	(*KeyRefId)(nil),              // 2: state.schema.KeyRefId
+	(*KeyRef)(nil),                // 3: state.schema.KeyRef
+	(*List)(nil),                  // 4: state.schema.List
+	(*HistoryEntry)(nil),          // 5: state.schema.HistoryEntry
+	(*HistoryEntryList)(nil),      // 6: state.schema.HistoryEntryList
+	(*EntryPatched)(nil),          // 7: state.schema.EntryPatched
+	(*EntryChanged)(nil),          // 8: state.schema.EntryChanged
+	(*BatchedEvent)(nil),          // 9: state.schema.BatchedEvent
+	(*EventBatch)(nil),            // 10: state.schema.EventBatch
+	(*IndexIssue)(nil),            // 11: state.schema.IndexIssue
+	(*IndexCheckRequest)(nil),     // 12: state.schema.IndexCheckRequest
+	(*IndexCheckResult)(nil),      // 13: state.schema.IndexCheckResult
+	(*anypb.Any)(nil),             // 14: google.protobuf.Any
+	(*timestamppb.Timestamp)(nil), // 15: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil), // 16: google.protobuf.FieldMask
+}
+var file_schema_schema_proto_depIdxs = []int32{
+	14, // 0: state.schema.List.items:type_name -> google.protobuf.Any
+	15, // 1: state.schema.HistoryEntry.timestamp:type_name -> google.protobuf.Timestamp
+	14, // 2: state.schema.HistoryEntry.value:type_name -> google.protobuf.Any
+	5,  // 3: state.schema.HistoryEntryList.items:type_name -> state.schema.HistoryEntry
+	16, // 4: state.schema.EntryPatched.mask:type_name -> google.protobuf.FieldMask
+	14, // 5: state.schema.EntryPatched.value:type_name -> google.protobuf.Any
+	0,  // 6: state.schema.EntryChanged.operation:type_name -> state.schema.EntryChanged.Operation
+	14, // 7: state.schema.EntryChanged.value:type_name -> google.protobuf.Any
+	9,  // 8: state.schema.EventBatch.events:type_name -> state.schema.BatchedEvent
+	1,  // 9: state.schema.IndexIssue.kind:type_name -> state.schema.IndexIssue.Kind
+	11, // 10: state.schema.IndexCheckResult.issues:type_name -> state.schema.IndexIssue
+	11, // [11:11] is the sub-list for method output_type
+	11, // [11:11] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_schema_schema_proto_init() }
+func file_schema_schema_proto_init() {
+	if File_schema_schema_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_schema_schema_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyRefId); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_schema_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyRef); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_schema_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*List); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_schema_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HistoryEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_schema_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HistoryEntryList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_schema_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EntryPatched); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_schema_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EntryChanged); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_schema_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchedEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_schema_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EventBatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_schema_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IndexIssue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_schema_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IndexCheckRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_schema_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IndexCheckResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -313,13 +1281,14 @@ func file_schema_schema_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_schema_schema_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   3,
+			NumEnums:      2,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_schema_schema_proto_goTypes,
 		DependencyIndexes: file_schema_schema_proto_depIdxs,
+		EnumInfos:         file_schema_schema_proto_enumTypes,
 		MessageInfos:      file_schema_schema_proto_msgTypes,
 	}.Build()
 	File_schema_schema_proto = out.File
@@ -8,6 +8,8 @@ import (
 	proto "github.com/golang/protobuf/proto"
 	github_com_mwitkow_go_proto_validators "github.com/mwitkow/go-proto-validators"
 	_ "google.golang.org/protobuf/types/known/anypb"
+	_ "google.golang.org/protobuf/types/known/fieldmaskpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
 	math "math"
 )
 
@@ -32,3 +34,76 @@ func (this *List) Validate() error {
 	}
 	return nil
 }
+func (this *HistoryEntry) Validate() error {
+	if this.Timestamp != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Timestamp); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Timestamp", err)
+		}
+	}
+	if this.Value != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Value); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Value", err)
+		}
+	}
+	return nil
+}
+func (this *HistoryEntryList) Validate() error {
+	for _, item := range this.Items {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Items", err)
+			}
+		}
+	}
+	return nil
+}
+func (this *EntryPatched) Validate() error {
+	if this.Mask != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Mask); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Mask", err)
+		}
+	}
+	if this.Value != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Value); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Value", err)
+		}
+	}
+	return nil
+}
+func (this *EntryChanged) Validate() error {
+	if this.Value != nil {
+		if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(this.Value); err != nil {
+			return github_com_mwitkow_go_proto_validators.FieldError("Value", err)
+		}
+	}
+	return nil
+}
+func (this *BatchedEvent) Validate() error {
+	return nil
+}
+func (this *EventBatch) Validate() error {
+	for _, item := range this.Events {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Events", err)
+			}
+		}
+	}
+	return nil
+}
+func (this *IndexIssue) Validate() error {
+	return nil
+}
+func (this *IndexCheckRequest) Validate() error {
+	return nil
+}
+func (this *IndexCheckResult) Validate() error {
+	for _, item := range this.Issues {
+		if item != nil {
+			if err := github_com_mwitkow_go_proto_validators.CallValidatorIfExists(item); err != nil {
+				return github_com_mwitkow_go_proto_validators.FieldError("Issues", err)
+			}
+		}
+	}
+	return nil
+}
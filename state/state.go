@@ -1,8 +1,12 @@
 package state
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"fmt"
+	"sort"
 
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric-chaincode-go/shim"
 	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
 	"github.com/pkg/errors"
@@ -13,17 +17,6 @@ import (
 	"github.com/hyperledger-labs/cckit/serialize"
 )
 
-// HistoryEntry struct containing history information of a single entry
-type HistoryEntry struct {
-	TxId      string      `json:"txId"`
-	Timestamp int64       `json:"timestamp"`
-	IsDeleted bool        `json:"isDeleted"`
-	Value     interface{} `json:"value"`
-}
-
-// HistoryEntryList list of history entries
-type HistoryEntryList []HistoryEntry
-
 type Impl struct {
 	stub   shim.ChaincodeStubInterface
 	logger *zap.Logger
@@ -39,6 +32,10 @@ type Impl struct {
 	StateKeyReverseTransformer KeyTransformer
 
 	serializer serialize.Serializer
+	// values, upgraded by serializer on Get, to rewrite on FlushRewrites
+	rewrites *txRewrites
+	// values, cached in current tx
+	cache *txCache
 	//StateGetTransformer        serialize.FromBytesConverter
 	//StatePutTransformer        serialize.ToBytesConverter
 }
@@ -51,6 +48,8 @@ func NewState(stub shim.ChaincodeStubInterface, logger *zap.Logger) *Impl {
 		StateKeyTransformer:        KeyAsIs,
 		StateKeyReverseTransformer: KeyAsIs,
 		serializer:                 serialize.DefaultSerializer,
+		rewrites:                   &txRewrites{},
+		cache:                      &txCache{},
 	}
 
 	// Get data by key from state, direct from stub
@@ -97,6 +96,8 @@ func (s *Impl) Clone() State {
 		StateKeyTransformer:                         s.StateKeyTransformer,
 		StateKeyReverseTransformer:                  s.StateKeyReverseTransformer,
 		serializer:                                  s.serializer,
+		rewrites:                                    s.rewrites,
+		cache:                                       s.cache,
 		//StateGetTransformer:                         s.StateGetTransformer,
 		//StatePutTransformer:                         s.StatePutTransformer,
 	}
@@ -106,6 +107,28 @@ func (s *Impl) Logger() *zap.Logger {
 	return s.logger
 }
 
+func (s *Impl) TxID() string {
+	return s.stub.GetTxID()
+}
+
+func (s *Impl) Transient() (map[string][]byte, error) {
+	return s.stub.GetTransient()
+}
+
+func (s *Impl) TxTimestamp() (*timestamp.Timestamp, error) {
+	return s.stub.GetTxTimestamp()
+}
+
+// TxCached returns value, cached in current tx with transformed key, cache is shared by state clones
+func (s *Impl) TxCached(key interface{}, init func() interface{}) (interface{}, error) {
+	trKey, err := s.Key(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.cache.get(s.stub.GetTxID(), trKey.String, init), nil
+}
+
 func (s *Impl) Key(key interface{}) (*TransformedKey, error) {
 	var (
 		trKey = &TransformedKey{}
@@ -157,45 +180,114 @@ func (s *Impl) Get(entry interface{}, config ...interface{}) (interface{}, error
 		target = config[0]
 	}
 
-	return s.serializer.FromBytesTo(bb, target)
+	value, err := s.serializer.FromBytesTo(bb, target)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.scheduleRewrite(key.String, bb, target); err != nil {
+		return nil, err
+	}
+	return value, nil
 }
 
-// GetHistory by key from state, trying to convert to target interface
-func (s *Impl) GetHistory(entry interface{}, target interface{}) (HistoryEntryList, error) {
+// GetHash returns sha256 hash of entry value as stored in state, without deserialization
+func (s *Impl) GetHash(entry interface{}) ([]byte, error) {
 	key, err := s.Key(entry)
 	if err != nil {
 		return nil, err
 	}
 
-	iter, err := s.stub.GetHistoryForKey(key.String)
+	s.logger.Debug(`state GET HASH`, zap.String(`key`, key.String))
+	bb, err := s.GetState(key.String)
 	if err != nil {
 		return nil, err
 	}
+	if len(bb) == 0 {
+		return nil, fmt.Errorf(`get state hash with key=%s: %w`, key.Origin, ErrKeyNotFound)
+	}
 
-	defer func() { _ = iter.Close() }()
+	return PrivateDataHash(bb), nil
+}
 
-	results := HistoryEntryList{}
+// scheduleRewrite schedules rewrite of value, upgraded by serializer to current format, on FlushRewrites
+func (s *Impl) scheduleRewrite(key string, bb []byte, target interface{}) error {
+	rewriter, ok := s.serializer.(serialize.Rewriter)
+	if !ok || target == nil || s.rewrites == nil {
+		return nil
+	}
 
-	for iter.HasNext() {
-		state, err := iter.Next()
-		if err != nil {
-			return nil, err
-		}
-		value, err := s.serializer.FromBytesTo(state.Value, target)
-		if err != nil {
-			return nil, err
-		}
+	rewritten, upgraded, err := rewriter.Rewrite(bb, target)
+	if err != nil || !upgraded {
+		return err
+	}
+	s.rewrites.forTx(s.stub.GetTxID())[key] = rewritten
+	return nil
+}
+
+// FlushRewrites puts values, upgraded on Get in current tx and not changed after it, in current format
+func (s *Impl) FlushRewrites() error {
+	if s.rewrites == nil {
+		return nil
+	}
+
+	values := s.rewrites.forTx(s.stub.GetTxID())
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
 
-		entry := HistoryEntry{
-			TxId:      state.GetTxId(),
-			Timestamp: state.GetTimestamp().GetSeconds(),
-			IsDeleted: state.GetIsDelete(),
-			Value:     value,
+	for _, key := range keys {
+		s.logger.Debug(`state PUT upgraded`, zap.String(`key`, key))
+		if err := s.PutState(key, values[key]); err != nil {
+			return err
 		}
-		results = append(results, entry)
+		delete(values, key)
 	}
+	return nil
+}
+
+// txRewrites values to rewrite, state can be used in several txs (for example, in tests),
+// so values of previous tx are dropped
+type txRewrites struct {
+	txID   string
+	values map[string][]byte
+}
+
+func (r *txRewrites) forTx(txID string) map[string][]byte {
+	if r.values == nil || r.txID != txID {
+		r.txID = txID
+		r.values = make(map[string][]byte)
+	}
+	return r.values
+}
 
-	return results, nil
+func (r *txRewrites) drop(txID, key string) {
+	if r != nil {
+		delete(r.forTx(txID), key)
+	}
+}
+
+// txCache values, cached in current tx, state can be used in several txs (for example, in tests),
+// so values of previous tx are dropped
+type txCache struct {
+	txID   string
+	values map[string]interface{}
+}
+
+func (c *txCache) get(txID, key string, init func() interface{}) interface{} {
+	if c.values == nil || c.txID != txID {
+		c.txID = txID
+		c.values = make(map[string]interface{})
+	}
+
+	value, ok := c.values[key]
+	if !ok {
+		value = init()
+		c.values[key] = value
+	}
+	return value
 }
 
 // Exists check entry with key exists in chaincode state
@@ -308,34 +400,108 @@ func (s *Impl) Keys(namespace interface{}) ([]string, error) {
 		return nil, errors.Wrap(err, `state iterator`)
 	}
 
+	return s.keys(iter)
+}
+
+// KeysPrivate returns keys of private data collection entries with namespace
+func (s *Impl) KeysPrivate(collection string, namespace interface{}) ([]string, error) {
+	iter, err := s.createPrivateQueryIterator(collection, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.keys(iter)
+}
+
+// KeysFrom returns at most limit keys with namespace, starting from state key startKey, and state key of first entry
+// after returned keys, empty if there are no more entries. Fabric doesn't allow range queries with composite keys
+// and writes in transaction with paginated queries, so keys are read with partial composite key query
+// without pagination: keys before startKey are skipped without reverse transformation,
+// iteration stops after limit keys
+func (s *Impl) KeysFrom(namespace interface{}, startKey string, limit int32) ([]string, string, error) {
+	iter, err := s.createStateQueryIterator(namespace)
+	if err != nil {
+		return nil, ``, errors.Wrap(err, `state iterator`)
+	}
+
+	return s.keysFrom(iter, startKey, limit)
+}
+
+// KeysFromPrivate returns at most limit keys of private data collection entries with namespace,
+// starting from state key startKey, as KeysFrom
+func (s *Impl) KeysFromPrivate(collection string, namespace interface{}, startKey string, limit int32) (
+	[]string, string, error) {
+	iter, err := s.createPrivateQueryIterator(collection, namespace)
+	if err != nil {
+		return nil, ``, err
+	}
+
+	return s.keysFrom(iter, startKey, limit)
+}
+
+func (s *Impl) createPrivateQueryIterator(collection string, namespace interface{}) (
+	shim.StateQueryIteratorInterface, error) {
+	_, t, err := s.normalizeAndTransformKey(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var iter shim.StateQueryIteratorInterface
+	if objectType, attrs := t.Parts(); objectType == `` {
+		iter, err = s.stub.GetPrivateDataByRange(collection, ``, ``)
+	} else {
+		iter, err = s.stub.GetPrivateDataByPartialCompositeKey(collection, objectType, attrs)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, `private data iterator`)
+	}
+	return iter, nil
+}
+
+// keys returns reverse transformed keys of iterator entries
+func (s *Impl) keys(iter shim.StateQueryIteratorInterface) ([]string, error) {
+	keys, _, err := s.keysFrom(iter, ``, 0)
+	return keys, err
+}
+
+// keysFrom returns at most limit (all, if limit is 0) reverse transformed keys of iterator entries,
+// starting from state key startKey, and state key of first entry after returned keys
+func (s *Impl) keysFrom(iter shim.StateQueryIteratorInterface, startKey string, limit int32) ([]string, string, error) {
 	defer func() { _ = iter.Close() }()
 
 	var keys []string
 	for iter.HasNext() {
 		v, err := iter.Next()
 		if err != nil {
-			return nil, err
+			return nil, ``, err
+		}
+
+		if v.Key < startKey {
+			continue
+		}
+		if limit > 0 && int32(len(keys)) == limit {
+			return keys, v.Key, nil
 		}
 
 		key, err := KeyFromComposite(s.stub, v.Key)
 		if err != nil {
-			return nil, err
+			return nil, ``, err
 		}
 
 		reverseTransformedKey, err := s.StateKeyReverseTransformer(key)
 		if err != nil {
-			return nil, fmt.Errorf(`reverse transform key: %w`, err)
+			return nil, ``, fmt.Errorf(`reverse transform key: %w`, err)
 		}
 
 		keyStr, err := KeyToString(s.stub, reverseTransformedKey)
 		if err != nil {
-			return nil, err
+			return nil, ``, err
 		}
 
 		keys = append(keys, keyStr)
 	}
 
-	return keys, nil
+	return keys, ``, nil
 }
 
 func (s *Impl) argKeyValue(arg interface{}, values []interface{}) (key Key, value interface{}, err error) {
@@ -373,6 +539,7 @@ func (s *Impl) Put(entry interface{}, values ...interface{}) error {
 	}
 
 	s.logger.Debug(`state PUT`, zap.String(`key`, key.String))
+	s.rewrites.drop(s.stub.GetTxID(), key.String)
 	return s.PutState(key.String, bb)
 }
 
@@ -400,6 +567,7 @@ func (s *Impl) Delete(entry interface{}) error {
 	}
 
 	s.logger.Debug(`state DELETE`, zap.String(`key`, key.String))
+	s.rewrites.drop(s.stub.GetTxID(), key.String)
 	return s.DelState(key.String)
 }
 
@@ -411,6 +579,14 @@ func (s *Impl) UseKeyReverseTransformer(kt KeyTransformer) {
 	s.StateKeyReverseTransformer = kt
 }
 
+func (s *Impl) KeyTransformer() KeyTransformer {
+	return s.StateKeyTransformer
+}
+
+func (s *Impl) KeyReverseTransformer() KeyTransformer {
+	return s.StateKeyReverseTransformer
+}
+
 func (s *Impl) UseSerializer(serializer serialize.Serializer) {
 	s.serializer = serializer
 }
@@ -437,7 +613,7 @@ func (s *Impl) GetPrivate(collection string, entry interface{}, config ...interf
 		if len(config) >= 2 {
 			return config[1], nil
 		}
-		return nil, errors.Errorf(`%s: %s`, ErrKeyNotFound, key.Origin.String())
+		return nil, fmt.Errorf(`%w: %s`, ErrKeyNotFound, key.Origin.String())
 	}
 
 	// return bytes as is
@@ -512,7 +688,13 @@ func (s *Impl) ListPrivate(collection string, usePrivateDataIterator bool, names
 			return nil, err
 		}
 
-		object, err := s.GetPrivate(collection, append([]string{objKey}, keyParts...), target...)
+		// key from iterator is already transformed, GetPrivate will transform it once more
+		originKey, err := s.StateKeyReverseTransformer(append(Key{objKey}, keyParts...))
+		if err != nil {
+			return nil, fmt.Errorf(`reverse transform key: %w`, err)
+		}
+
+		object, err := s.GetPrivate(collection, originKey, target...)
 		if err != nil {
 			return nil, err
 		}
@@ -567,3 +749,51 @@ func (s *Impl) DeletePrivate(collection string, entry interface{}) error {
 	s.logger.Debug(`private state DELETE`, zap.String(`key`, key.String))
 	return s.stub.DelPrivateData(collection, key.String)
 }
+
+// GetPrivateHash returns hash of private state entry value. Hash available for all channel members,
+// including parties without access to collection
+func (s *Impl) GetPrivateHash(collection string, entry interface{}) ([]byte, error) {
+	key, err := s.Key(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Debug(`private state GET HASH`, zap.String(`key`, key.String))
+	hash, err := s.stub.GetPrivateDataHash(collection, key.String)
+	if err != nil {
+		return nil, err
+	}
+	if len(hash) == 0 {
+		return nil, fmt.Errorf(`get private state hash with key=%s: %w`, key.Origin, ErrKeyNotFound)
+	}
+
+	return hash, nil
+}
+
+// VerifyPrivate checks presented value against private state entry hash.
+// Value is converted to bytes with state serializer, same as in PutPrivate,
+// so serializer must produce deterministic output
+func (s *Impl) VerifyPrivate(collection string, entry interface{}, values ...interface{}) (bool, error) {
+	entryKey, value, err := s.argKeyValue(entry, values)
+	if err != nil {
+		return false, err
+	}
+
+	bb, err := s.serializer.ToBytesFrom(value)
+	if err != nil {
+		return false, err
+	}
+
+	hash, err := s.GetPrivateHash(collection, entryKey)
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(hash, PrivateDataHash(bb)), nil
+}
+
+// PrivateDataHash returns hash of private data value, computed as in Fabric peer (SHA-256)
+func PrivateDataHash(value []byte) []byte {
+	hash := sha256.Sum256(value)
+	return hash[:]
+}
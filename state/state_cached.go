@@ -1,11 +1,13 @@
 package state
 
 import (
+	"fmt"
 	"sort"
 	"strings"
 
 	"github.com/hyperledger/fabric-chaincode-go/shim"
 	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/pkg/errors"
 )
 
@@ -19,6 +21,23 @@ type (
 		TxDeleteSet TxDeleteSet
 	}
 
+	// CachedStub wraps chaincode stub with tx level cache of public and private state changes,
+	// so all state read methods return values, written in current tx
+	CachedStub struct {
+		shim.ChaincodeStubInterface
+		TxWriteSet  TxWriteSet
+		TxDeleteSet TxDeleteSet
+
+		// private state changes by collection
+		PrivateTxWriteSet  map[string]TxWriteSet
+		PrivateTxDeleteSet map[string]TxDeleteSet
+	}
+
+	// Unwrapper is implemented by state wrappers, for example mapped state, returns wrapped state
+	Unwrapper interface {
+		Unwrap() State
+	}
+
 	CachedQueryIterator struct {
 		current int
 		closed  bool
@@ -26,58 +45,230 @@ type (
 	}
 )
 
-// WithCached returns state with tx level state cache
-func WithCache(ss State) *Cached {
-	s := ss.(*Impl)
-	cached := &Cached{
-		State:       s,
-		TxWriteSet:  make(map[string][]byte),
-		TxDeleteSet: make(map[string]interface{}),
+// WithCache returns clone of state with tx level state cache. State can be wrapped state (implementing Unwrapper),
+// cache is applied to underlying state of clone, so wrapper clone also reads values written in current tx.
+// Source state is not changed
+func WithCache(ss State) (*Cached, error) {
+	clone := ss.Clone()
+	s, ok := unwrapImpl(clone)
+	if !ok {
+		return nil, fmt.Errorf(`%w: %T`, ErrStateCacheNotSupported, ss)
 	}
 
-	// PutState wrapper
-	s.PutState = func(key string, bb []byte) error {
-		cached.TxWriteSet[key] = bb
-		return s.stub.PutState(key, bb)
+	cachedStub, ok := s.stub.(*CachedStub)
+	if !ok {
+		cachedStub = NewCachedStub(s.stub)
+		s.useStub(cachedStub)
 	}
 
-	// GetState wrapper
-	s.GetState = func(key string) ([]byte, error) {
-		if bb, ok := cached.TxWriteSet[key]; ok {
-			return bb, nil
-		}
+	return &Cached{
+		State:       clone,
+		TxWriteSet:  cachedStub.TxWriteSet,
+		TxDeleteSet: cachedStub.TxDeleteSet,
+	}, nil
+}
 
-		if _, ok := cached.TxDeleteSet[key]; ok {
-			return []byte{}, nil
+// unwrapImpl returns underlying state implementation of state wrapper
+func unwrapImpl(ss State) (*Impl, bool) {
+	for {
+		switch s := ss.(type) {
+		case *Impl:
+			return s, true
+		case *Cached:
+			ss = s.State
+		case Unwrapper:
+			ss = s.Unwrap()
+		default:
+			return nil, false
 		}
-		return s.stub.GetState(key)
 	}
+}
 
-	s.DelState = func(key string) error {
-		delete(cached.TxWriteSet, key)
-		cached.TxDeleteSet[key] = nil
+// useStub replaces stub in state and state access methods, must be called only on state clone
+func (s *Impl) useStub(stub shim.ChaincodeStubInterface) {
+	s.stub = stub
+	s.GetState = stub.GetState
+	s.PutState = stub.PutState
+	s.DelState = stub.DelState
+	s.GetStateByPartialCompositeKey = stub.GetStateByPartialCompositeKey
+	s.GetStateByPartialCompositeKeyWithPagination = stub.GetStateByPartialCompositeKeyWithPagination
+}
 
-		return s.stub.DelState(key)
+// NewCachedStub creates chaincode stub wrapper with tx level cache
+func NewCachedStub(stub shim.ChaincodeStubInterface) *CachedStub {
+	return &CachedStub{
+		ChaincodeStubInterface: stub,
+		TxWriteSet:             make(TxWriteSet),
+		TxDeleteSet:            make(TxDeleteSet),
+		PrivateTxWriteSet:      make(map[string]TxWriteSet),
+		PrivateTxDeleteSet:     make(map[string]TxDeleteSet),
 	}
+}
 
-	s.GetStateByPartialCompositeKey = func(objectType string, keys []string) (shim.StateQueryIteratorInterface, error) {
-		iterator, err := s.stub.GetStateByPartialCompositeKey(objectType, keys)
-		if err != nil {
-			return nil, err
-		}
+func (c *CachedStub) PutState(key string, bb []byte) error {
+	c.TxWriteSet[key] = bb
+	delete(c.TxDeleteSet, key)
+	return c.ChaincodeStubInterface.PutState(key, bb)
+}
 
-		prefix, err := s.stub.CreateCompositeKey(objectType, keys)
-		if err != nil {
-			return nil, err
-		}
+func (c *CachedStub) GetState(key string) ([]byte, error) {
+	if bb, ok := c.TxWriteSet[key]; ok {
+		return bb, nil
+	}
+
+	if _, ok := c.TxDeleteSet[key]; ok {
+		return []byte{}, nil
+	}
+	return c.ChaincodeStubInterface.GetState(key)
+}
+
+func (c *CachedStub) DelState(key string) error {
+	delete(c.TxWriteSet, key)
+	c.TxDeleteSet[key] = nil
+
+	return c.ChaincodeStubInterface.DelState(key)
+}
+
+func (c *CachedStub) GetStateByRange(startKey, endKey string) (shim.StateQueryIteratorInterface, error) {
+	iterator, err := c.ChaincodeStubInterface.GetStateByRange(startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	return newCachedQueryIterator(iterator, rangeMatcher(startKey, endKey), c.TxWriteSet, c.TxDeleteSet)
+}
+
+// GetStateByRangeWithPagination returns page of range query, merged with state changes of current tx.
+// Range is queried without pagination, bookmark is key of first entry of next page
+func (c *CachedStub) GetStateByRangeWithPagination(startKey, endKey string, pageSize int32, bookmark string) (
+	shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	iterator, err := c.GetStateByRange(startKey, endKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return iterator.(*CachedQueryIterator).page(pageSize, bookmark)
+}
 
-		return NewCachedQueryIterator(iterator, prefix, cached.TxWriteSet, cached.TxDeleteSet)
+func (c *CachedStub) GetStateByPartialCompositeKey(objectType string, keys []string) (
+	shim.StateQueryIteratorInterface, error) {
+	iterator, err := c.ChaincodeStubInterface.GetStateByPartialCompositeKey(objectType, keys)
+	if err != nil {
+		return nil, err
 	}
 
-	return cached
+	prefix, err := c.CreateCompositeKey(objectType, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCachedQueryIterator(iterator, prefix, c.TxWriteSet, c.TxDeleteSet)
+}
+
+// GetStateByPartialCompositeKeyWithPagination returns page of partial composite key query,
+// merged with state changes of current tx. Query is executed without pagination,
+// bookmark is key of first entry of next page
+func (c *CachedStub) GetStateByPartialCompositeKeyWithPagination(
+	objectType string, keys []string, pageSize int32, bookmark string) (
+	shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	iterator, err := c.GetStateByPartialCompositeKey(objectType, keys)
+	if err != nil {
+		return nil, nil, err
+	}
+	return iterator.(*CachedQueryIterator).page(pageSize, bookmark)
+}
+
+func (c *CachedStub) privateSets(collection string) (TxWriteSet, TxDeleteSet) {
+	if _, ok := c.PrivateTxWriteSet[collection]; !ok {
+		c.PrivateTxWriteSet[collection] = make(TxWriteSet)
+		c.PrivateTxDeleteSet[collection] = make(TxDeleteSet)
+	}
+	return c.PrivateTxWriteSet[collection], c.PrivateTxDeleteSet[collection]
+}
+
+func (c *CachedStub) PutPrivateData(collection string, key string, bb []byte) error {
+	writeSet, deleteSet := c.privateSets(collection)
+	writeSet[key] = bb
+	delete(deleteSet, key)
+	return c.ChaincodeStubInterface.PutPrivateData(collection, key, bb)
+}
+
+func (c *CachedStub) GetPrivateData(collection string, key string) ([]byte, error) {
+	writeSet, deleteSet := c.privateSets(collection)
+	if bb, ok := writeSet[key]; ok {
+		return bb, nil
+	}
+
+	if _, ok := deleteSet[key]; ok {
+		return []byte{}, nil
+	}
+	return c.ChaincodeStubInterface.GetPrivateData(collection, key)
+}
+
+func (c *CachedStub) DelPrivateData(collection string, key string) error {
+	writeSet, deleteSet := c.privateSets(collection)
+	delete(writeSet, key)
+	deleteSet[key] = nil
+
+	return c.ChaincodeStubInterface.DelPrivateData(collection, key)
+}
+
+// GetPrivateDataHash returns hash of private data, written in current tx, or hash from ledger
+func (c *CachedStub) GetPrivateDataHash(collection string, key string) ([]byte, error) {
+	writeSet, deleteSet := c.privateSets(collection)
+	if bb, ok := writeSet[key]; ok {
+		return PrivateDataHash(bb), nil
+	}
+
+	if _, ok := deleteSet[key]; ok {
+		return nil, nil
+	}
+	return c.ChaincodeStubInterface.GetPrivateDataHash(collection, key)
+}
+
+func (c *CachedStub) GetPrivateDataByRange(collection, startKey, endKey string) (
+	shim.StateQueryIteratorInterface, error) {
+	iterator, err := c.ChaincodeStubInterface.GetPrivateDataByRange(collection, startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+
+	writeSet, deleteSet := c.privateSets(collection)
+	return newCachedQueryIterator(iterator, rangeMatcher(startKey, endKey), writeSet, deleteSet)
+}
+
+func (c *CachedStub) GetPrivateDataByPartialCompositeKey(collection, objectType string, keys []string) (
+	shim.StateQueryIteratorInterface, error) {
+	iterator, err := c.ChaincodeStubInterface.GetPrivateDataByPartialCompositeKey(collection, objectType, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, err := c.CreateCompositeKey(objectType, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	writeSet, deleteSet := c.privateSets(collection)
+	return NewCachedQueryIterator(iterator, prefix, writeSet, deleteSet)
+}
+
+// rangeMatcher checks key is in range [startKey, endKey), empty key means unbounded range
+func rangeMatcher(startKey, endKey string) func(string) bool {
+	return func(key string) bool {
+		return (startKey == `` || key >= startKey) && (endKey == `` || key < endKey)
+	}
 }
 
 func NewCachedQueryIterator(iterator shim.StateQueryIteratorInterface, prefix string, writeSet TxWriteSet, deleteSet TxDeleteSet) (*CachedQueryIterator, error) {
+	return newCachedQueryIterator(iterator, func(key string) bool {
+		return strings.HasPrefix(key, prefix)
+	}, writeSet, deleteSet)
+}
+
+// newCachedQueryIterator returns iterator over ledger entries, merged with entries written and deleted in current tx
+func newCachedQueryIterator(iterator shim.StateQueryIteratorInterface, match func(key string) bool,
+	writeSet TxWriteSet, deleteSet TxDeleteSet) (*CachedQueryIterator, error) {
+	defer func() { _ = iterator.Close() }()
+
 	queryIterator := &CachedQueryIterator{
 		current: -1,
 	}
@@ -91,11 +282,16 @@ func NewCachedQueryIterator(iterator shim.StateQueryIteratorInterface, prefix st
 			continue
 		}
 
+		// value written in current tx is added below
+		if _, ok := writeSet[kv.Key]; ok {
+			continue
+		}
+
 		queryIterator.KVs = append(queryIterator.KVs, kv)
 	}
 
 	for wroteKey, wroteValue := range writeSet {
-		if strings.HasPrefix(wroteKey, prefix) {
+		if match(wroteKey) {
 			queryIterator.KVs = append(queryIterator.KVs, &queryresult.KV{
 				Namespace: "",
 				Key:       wroteKey,
@@ -111,6 +307,32 @@ func NewCachedQueryIterator(iterator shim.StateQueryIteratorInterface, prefix st
 	return queryIterator, nil
 }
 
+// page returns iterator over page of pageSize entries, starting from bookmark key
+func (i *CachedQueryIterator) page(pageSize int32, bookmark string) (
+	*CachedQueryIterator, *pb.QueryResponseMetadata, error) {
+	start := 0
+	if bookmark != `` {
+		start = sort.Search(len(i.KVs), func(n int) bool {
+			return i.KVs[n].Key >= bookmark
+		})
+	}
+
+	end := len(i.KVs)
+	if pageSize > 0 && start+int(pageSize) < end {
+		end = start + int(pageSize)
+	}
+
+	md := &pb.QueryResponseMetadata{FetchedRecordsCount: int32(end - start)}
+	if end < len(i.KVs) {
+		md.Bookmark = i.KVs[end].Key
+	}
+
+	return &CachedQueryIterator{
+		current: -1,
+		KVs:     i.KVs[start:end],
+	}, md, nil
+}
+
 func (i *CachedQueryIterator) Next() (*queryresult.KV, error) {
 	if !i.HasNext() {
 		return nil, errors.New(`no next items`)
@@ -45,4 +45,32 @@ var _ = Describe(`State caching`, func() {
 		Expect(resp).To(Equal([]testdata.Value{
 			testdata.KeyValue(testdata.Keys[1]), testdata.KeyValue(testdata.Keys[2])}))
 	})
+
+	It("List paginated after write returns all pages", func() {
+		resp := expectcc.PayloadIs(
+			stateCachedCC.Invoke(testdata.TxStateCachedListPaginatedAfterWrite), &[]testdata.Value{}).([]testdata.Value)
+
+		Expect(resp).To(Equal([]testdata.Value{
+			testdata.KeyValue(testdata.Keys[0]), testdata.KeyValue(testdata.Keys[1]), testdata.KeyValue(testdata.Keys[2])}))
+	})
+
+	It("Keys after delete returns keys without deleted key", func() {
+		resp := expectcc.PayloadIs(
+			stateCachedCC.Invoke(testdata.TxStateCachedKeysAfterDelete), &[]string{}).([]string)
+		Expect(resp).To(HaveLen(2))
+	})
+
+	It("Private list after write returns list", func() {
+		resp := expectcc.PayloadIs(
+			stateCachedCC.Invoke(testdata.TxStateCachedPrivateListAfterWrite), &[]testdata.Value{}).([]testdata.Value)
+
+		Expect(resp).To(Equal([]testdata.Value{
+			testdata.KeyValue(testdata.Keys[0]), testdata.KeyValue(testdata.Keys[1]), testdata.KeyValue(testdata.Keys[2])}))
+	})
+
+	It("Private read after delete returns not existing entry", func() {
+		resp := expectcc.PayloadIs(
+			stateCachedCC.Invoke(testdata.TxStateCachedPrivateReadAfterDelete), new(bool)).(bool)
+		Expect(resp).To(BeFalse())
+	})
 })
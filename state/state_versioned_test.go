@@ -0,0 +1,96 @@
+package state_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger-labs/cckit/router"
+	"github.com/hyperledger-labs/cckit/serialize"
+	"github.com/hyperledger-labs/cckit/serialize/testdata"
+	testcc "github.com/hyperledger-labs/cckit/testing"
+)
+
+var _ = Describe(`State with versioned serializer`, func() {
+
+	var (
+		cc  *testcc.TxHandler
+		ctx router.Context
+
+		// version 0 of Payment stored amount in `sum` field
+		serializer = serialize.NewVersionedSerializer(serialize.PreferJSONSerializer,
+			serialize.WithVersion(&testdata.Payment{}, 1),
+			serialize.WithUpgrade(&testdata.Payment{}, 0, serialize.UpgradeJSON(
+				func(fields map[string]interface{}) error {
+					fields[`amount`] = fields[`sum`]
+					delete(fields, `sum`)
+					return nil
+				})),
+			serialize.WithRewriteUpgraded())
+
+		storedVersion = func(key string) uint32 {
+			bb, err := cc.MockStub.GetState(key)
+			Expect(err).NotTo(HaveOccurred())
+			_, version, err := serialize.SplitVersionHeader(bb)
+			Expect(err).NotTo(HaveOccurred())
+			return version
+		}
+	)
+
+	It("Allow to get upgraded legacy value", func() {
+		cc, ctx = testcc.NewTxHandler(`versioned`)
+		cc.Tx(func() {
+			Expect(ctx.State().Put(`payment1`, []byte(`{"id":"payment1","sum":100}`))).To(Succeed())
+			Expect(ctx.State().Put(`payment2`, []byte(`{"id":"payment2","sum":200}`))).To(Succeed())
+		})
+
+		cc.Tx(func() {
+			ctx.State().UseSerializer(serializer)
+			payment, err := ctx.State().Get(`payment1`, &testdata.Payment{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(payment.(*testdata.Payment).Amount).To(Equal(int32(100)))
+		})
+
+		// value is not rewritten in tx without Put
+		cc.Tx(func() {
+			Expect(storedVersion(`payment1`)).To(Equal(uint32(0)))
+		})
+	})
+
+	It("Allow to rewrite upgraded values on flush", func() {
+		cc.Tx(func() {
+			ctx.State().UseSerializer(serializer)
+			_, err := ctx.State().Get(`payment1`, &testdata.Payment{})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = ctx.State().Get(`payment2`, &testdata.Payment{})
+			Expect(err).NotTo(HaveOccurred())
+
+			// payment2 is written by Put, other upgraded values are not rewritten on Put
+			Expect(ctx.State().Put(`payment2`, &testdata.Payment{Id: `payment2`, Amount: 300})).To(Succeed())
+		})
+
+		cc.Tx(func() {
+			Expect(storedVersion(`payment1`)).To(Equal(uint32(0)))
+			Expect(storedVersion(`payment2`)).To(Equal(uint32(1)))
+
+			ctx.State().UseSerializer(serializer)
+			_, err := ctx.State().Get(`payment1`, &testdata.Payment{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ctx.State().FlushRewrites()).To(Succeed())
+		})
+
+		cc.Tx(func() {
+			Expect(storedVersion(`payment1`)).To(Equal(uint32(1)))
+			Expect(storedVersion(`payment2`)).To(Equal(uint32(1)))
+
+			ctx.State().UseSerializer(serialize.NewVersionedSerializer(serialize.PreferJSONSerializer,
+				serialize.WithVersion(&testdata.Payment{}, 1)))
+			payment1, err := ctx.State().Get(`payment1`, &testdata.Payment{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(payment1.(*testdata.Payment).Amount).To(Equal(int32(100)))
+
+			payment2, err := ctx.State().Get(`payment2`, &testdata.Payment{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(payment2.(*testdata.Payment).Amount).To(Equal(int32(300)))
+		})
+	})
+})
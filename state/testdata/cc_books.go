@@ -27,11 +27,17 @@ func NewBooksCC() *router.Chaincode {
 		Invoke(`bookUpsert`, bookUpsert, p.Struct(`book`, &schema.Book{})).
 		Invoke(`bookUpsertWithCache`, bookUpsertWithCache, p.Struct(`book`, &schema.Book{})).
 		Invoke(`bookDelete`, bookDelete, p.String(`id`)).
+		Invoke(`bookInsertMany`, bookInsertMany, p.Struct(`in`, &schema.BookList{})).
+		Invoke(`bookDeleteMany`, bookDeleteMany, p.Struct(`in`, &schema.BookList{})).
+		Invoke(`bookHistory`, bookHistory, p.Struct(`in`, &schema.BookHistoryRequest{})).
+		Invoke(`bookGetAt`, bookGetAt, p.Struct(`in`, &schema.BookAtRequest{})).
 		Invoke(`privateBookList`, privateBookList).
 		Invoke(`privateBookGet`, privateBookGet, p.String(`id`)).
 		Invoke(`privateBookInsert`, privateBookInsert, p.Struct(`book`, &schema.PrivateBook{})).
 		Invoke(`privateBookUpsert`, privateBookUpsert, p.Struct(`book`, &schema.PrivateBook{})).
-		Invoke(`privateBookDelete`, privateBookDelete, p.String(`id`))
+		Invoke(`privateBookDelete`, privateBookDelete, p.String(`id`)).
+		Invoke(`privateBookHash`, privateBookHash, p.String(`id`)).
+		Invoke(`privateBookVerify`, privateBookVerify, p.Struct(`book`, &schema.PrivateBook{}))
 
 	return router.NewChaincode(r)
 }
@@ -97,7 +103,10 @@ func bookUpsert(c router.Context) (interface{}, error) {
 func bookUpsertWithCache(c router.Context) (interface{}, error) {
 	book := c.Param(`book`).(schema.Book)
 
-	stateCached := state.WithCache(c.State())
+	stateCached, err := state.WithCache(c.State())
+	if err != nil {
+		return nil, err
+	}
 
 	// udate data in state
 	if err := stateCached.Put(book); err != nil {
@@ -126,6 +135,64 @@ func bookDelete(c router.Context) (interface{}, error) {
 	return nil, c.State().Delete(schema.Book{Id: c.ParamString(`id`)})
 }
 
+func bookInsertMany(c router.Context) (interface{}, error) {
+	in := c.Param(`in`).(schema.BookList)
+	results, err := c.State().InsertMany(booksAsEntries(in.Items)...)
+	if err != nil {
+		return nil, err
+	}
+	return results.Keys(), nil
+}
+
+func bookDeleteMany(c router.Context) (interface{}, error) {
+	in := c.Param(`in`).(schema.BookList)
+	results, err := c.State().DeleteMany(booksAsEntries(in.Items)...)
+	if err != nil {
+		return nil, err
+	}
+	return results.Keys(), nil
+}
+
+func booksAsEntries(books []*schema.Book) []interface{} {
+	entries := make([]interface{}, len(books))
+	for i, b := range books {
+		entries[i] = *b
+	}
+	return entries
+}
+
+func bookHistory(c router.Context) (interface{}, error) {
+	in := c.Param(`in`).(schema.BookHistoryRequest)
+
+	opts := []state.HistoryOpt{state.HistoryFrom(in.From), state.HistoryTo(in.To)}
+	if in.Reverse {
+		opts = append(opts, state.HistoryReverse())
+	}
+
+	entries, md, err := c.State().GetHistoryPaginated(
+		schema.Book{Id: in.Id}, &schema.Book{}, in.PageSize, in.Bookmark, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	history := schema.BookHistory{Next: md.Bookmark}
+	for _, e := range entries {
+		entry := &schema.BookHistoryEntry{TxId: e.TxId, Time: e.Time, IsDeleted: e.IsDeleted}
+		if e.Value != nil {
+			b := e.Value.(schema.Book)
+			entry.Value = &b
+		}
+		history.Items = append(history.Items, entry)
+	}
+
+	return history, nil
+}
+
+func bookGetAt(c router.Context) (interface{}, error) {
+	in := c.Param(`in`).(schema.BookAtRequest)
+	return c.State().GetAt(schema.Book{Id: in.Id}, in.At, &schema.Book{})
+}
+
 func privateBookList(c router.Context) (interface{}, error) {
 	return c.State().ListPrivate(collection, false, schema.PrivateBookEntity, &schema.PrivateBook{})
 }
@@ -156,3 +223,11 @@ func privateBookDelete(c router.Context) (interface{}, error) {
 	c.State().Delete(schema.PrivateBook{Id: c.ParamString(`id`)})
 	return nil, c.State().DeletePrivate(collection, schema.PrivateBook{Id: c.ParamString(`id`)})
 }
+
+func privateBookHash(c router.Context) (interface{}, error) {
+	return c.State().GetPrivateHash(collection, schema.PrivateBook{Id: c.ParamString(`id`)})
+}
+
+func privateBookVerify(c router.Context) (interface{}, error) {
+	return c.State().VerifyPrivate(collection, c.Param(`book`))
+}
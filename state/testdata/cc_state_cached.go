@@ -11,6 +11,13 @@ const (
 	TxStateCachedListAfterWrite  = `ListAfterWrite`
 	TxStateCachedListAfterDelete = `ListAfterDelete`
 
+	TxStateCachedListPaginatedAfterWrite = `ListPaginatedAfterWrite`
+	TxStateCachedKeysAfterDelete         = `KeysAfterDelete`
+	TxStateCachedPrivateListAfterWrite   = `PrivateListAfterWrite`
+	TxStateCachedPrivateReadAfterDelete  = `PrivateReadAfterDelete`
+
+	CachedCollection = `cached_collection`
+
 	BasePrefix = `prefix`
 )
 
@@ -35,13 +42,20 @@ func NewStateCachedCC() *router.Chaincode {
 	r.Query(TxStateCachedReadAfterWrite, ReadAfterWrite).
 		Query(TxStateCachedReadAfterDelete, ReadAfterDelete).
 		Query(TxStateCachedListAfterWrite, ListAfterWrite).
-		Query(TxStateCachedListAfterDelete, ListAfterDelete)
+		Query(TxStateCachedListAfterDelete, ListAfterDelete).
+		Query(TxStateCachedListPaginatedAfterWrite, ListPaginatedAfterWrite).
+		Query(TxStateCachedKeysAfterDelete, KeysAfterDelete).
+		Query(TxStateCachedPrivateListAfterWrite, PrivateListAfterWrite).
+		Query(TxStateCachedPrivateReadAfterDelete, PrivateReadAfterDelete)
 
 	return router.NewChaincode(r)
 }
 
 func ReadAfterWrite(ctx router.Context) (interface{}, error) {
-	stateWithCache := state.WithCache(ctx.State())
+	stateWithCache, err := state.WithCache(ctx.State())
+	if err != nil {
+		return nil, err
+	}
 	for _, k := range Keys {
 		if err := stateWithCache.Put(Key(k), KeyValue(k)); err != nil {
 			return nil, err
@@ -53,7 +67,10 @@ func ReadAfterWrite(ctx router.Context) (interface{}, error) {
 }
 
 func ReadAfterDelete(ctx router.Context) (interface{}, error) {
-	ctxWithStateCache := router.ContextWithStateCache(ctx)
+	ctxWithStateCache, err := router.ContextWithStateCache(ctx)
+	if err != nil {
+		return nil, err
+	}
 	// delete all keys
 	for _, k := range Keys {
 		if err := ctxWithStateCache.State().Delete(Key(k)); err != nil {
@@ -68,7 +85,10 @@ func ReadAfterDelete(ctx router.Context) (interface{}, error) {
 }
 
 func ListAfterWrite(ctx router.Context) (interface{}, error) {
-	stateWithCache := state.WithCache(ctx.State())
+	stateWithCache, err := state.WithCache(ctx.State())
+	if err != nil {
+		return nil, err
+	}
 	for _, k := range Keys {
 		if err := stateWithCache.Put(Key(k), KeyValue(k)); err != nil {
 			return nil, err
@@ -80,7 +100,10 @@ func ListAfterWrite(ctx router.Context) (interface{}, error) {
 }
 
 func ListAfterDelete(ctx router.Context) (interface{}, error) {
-	ctxWithStateCache := router.ContextWithStateCache(ctx)
+	ctxWithStateCache, err := router.ContextWithStateCache(ctx)
+	if err != nil {
+		return nil, err
+	}
 	// delete only one key, two keys remained
 	if err := ctxWithStateCache.State().Delete(Key(Keys[0])); err != nil {
 		return nil, err
@@ -89,3 +112,79 @@ func ListAfterDelete(ctx router.Context) (interface{}, error) {
 	// return list with 2 items, cause first item is deleted and state is cached
 	return ctxWithStateCache.State().List(BasePrefix, &Value{})
 }
+
+func ListPaginatedAfterWrite(ctx router.Context) (interface{}, error) {
+	stateWithCache, err := state.WithCache(ctx.State())
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range Keys {
+		if err := stateWithCache.Put(Key(k), KeyValue(k)); err != nil {
+			return nil, err
+		}
+	}
+
+	// return list of two pages, cause state changes cached
+	var (
+		list     []interface{}
+		bookmark string
+	)
+	for {
+		page, md, err := stateWithCache.ListPaginated(BasePrefix, 2, bookmark, &Value{})
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, page.([]interface{})...)
+		if bookmark = md.Bookmark; bookmark == `` {
+			return list, nil
+		}
+	}
+}
+
+func KeysAfterDelete(ctx router.Context) (interface{}, error) {
+	stateWithCache, err := state.WithCache(ctx.State())
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range Keys {
+		if err := stateWithCache.Put(Key(k), KeyValue(k)); err != nil {
+			return nil, err
+		}
+	}
+	if err := stateWithCache.Delete(Key(Keys[0])); err != nil {
+		return nil, err
+	}
+
+	// return keys without deleted, cause state changes cached
+	return stateWithCache.Keys(BasePrefix)
+}
+
+func PrivateListAfterWrite(ctx router.Context) (interface{}, error) {
+	stateWithCache, err := state.WithCache(ctx.State())
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range Keys {
+		if err := stateWithCache.PutPrivate(CachedCollection, Key(k), KeyValue(k)); err != nil {
+			return nil, err
+		}
+	}
+
+	return stateWithCache.ListPrivate(CachedCollection, true, BasePrefix, &Value{})
+}
+
+func PrivateReadAfterDelete(ctx router.Context) (interface{}, error) {
+	stateWithCache, err := state.WithCache(ctx.State())
+	if err != nil {
+		return nil, err
+	}
+	if err := stateWithCache.PutPrivate(CachedCollection, Key(Keys[0]), KeyValue(Keys[0])); err != nil {
+		return nil, err
+	}
+	if err := stateWithCache.DeletePrivate(CachedCollection, Key(Keys[0])); err != nil {
+		return nil, err
+	}
+
+	// return false, cause state changes cached
+	return stateWithCache.ExistsPrivate(CachedCollection, Key(Keys[0]))
+}
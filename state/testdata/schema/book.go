@@ -1,5 +1,7 @@
 package schema
 
+import "time"
+
 const BookEntity = `BOOK`
 
 type Book struct {
@@ -38,3 +40,29 @@ type BookList struct {
 	Items []*Book
 	Next  string
 }
+
+type BookHistoryRequest struct {
+	Id       string
+	PageSize int32
+	Bookmark string
+	From     time.Time
+	To       time.Time
+	Reverse  bool
+}
+
+type BookHistoryEntry struct {
+	TxId      string
+	Time      time.Time
+	IsDeleted bool
+	Value     *Book
+}
+
+type BookHistory struct {
+	Items []*BookHistoryEntry
+	Next  string
+}
+
+type BookAtRequest struct {
+	Id string
+	At time.Time
+}
@@ -1,5 +1,9 @@
 package state
 
+import (
+	"fmt"
+)
+
 type (
 
 	// KeyTransformer is used before putState operation for convert key
@@ -17,3 +21,45 @@ func KeyAsIs(key Key) (Key, error) {
 func NameAsIs(name string) (string, error) {
 	return name, nil
 }
+
+// KeyWithPrefix returns key transformer, adding prefix to every key.
+// Empty key (namespace for all state entries) is transformed to prefix itself
+func KeyWithPrefix(prefix Key) KeyTransformer {
+	return func(key Key) (Key, error) {
+		if len(key) == 0 || (len(key) == 1 && key[0] == ``) {
+			return append(Key{}, prefix...), nil
+		}
+		return append(append(Key{}, prefix...), key...), nil
+	}
+}
+
+// KeyWithoutPrefix returns key reverse transformer, removing prefix from key.
+// Key without prefix cannot be transformed
+func KeyWithoutPrefix(prefix Key) KeyTransformer {
+	return func(key Key) (Key, error) {
+		if len(key) < len(prefix) {
+			return nil, fmt.Errorf(`key=%s, prefix=%s: %w`, key, prefix, ErrKeyPrefixMismatch)
+		}
+
+		for i := range prefix {
+			if key[i] != prefix[i] {
+				return nil, fmt.Errorf(`key=%s, prefix=%s: %w`, key, prefix, ErrKeyPrefixMismatch)
+			}
+		}
+
+		return append(Key{}, key[len(prefix):]...), nil
+	}
+}
+
+// ComposeKeyTransformers returns key transformer, applying transformers in order
+func ComposeKeyTransformers(transformers ...KeyTransformer) KeyTransformer {
+	return func(key Key) (Key, error) {
+		var err error
+		for _, kt := range transformers {
+			if key, err = kt(key); err != nil {
+				return nil, err
+			}
+		}
+		return key, nil
+	}
+}
@@ -3,11 +3,13 @@ package testing
 import (
 	"container/list"
 	"crypto/rand"
+	"crypto/sha256"
 	"fmt"
 	"strings"
 	"sync"
 	"unicode/utf8"
 
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric-chaincode-go/shim"
 	"github.com/hyperledger/fabric-chaincode-go/shimtest"
 	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
@@ -59,6 +61,9 @@ type MockStub struct {
 	chaincodeEventSubscriptions []chan *peer.ChaincodeEvent // multiple event subscriptions
 
 	PrivateKeys map[string]*list.List
+	// History of key modifications, ordered from oldest to newest
+	History     map[string][]*queryresult.KeyModification
+	txTimestamp *timestamp.Timestamp
 	// flag for cc2cc invocation via InvokeChaincode to dump state on outer tx finish
 	// https://github.com/hyperledger-labs/cckit/issues/97
 	cc2ccInvocation bool
@@ -77,6 +82,7 @@ func NewMockStub(name string, cc shim.Chaincode) *MockStub {
 		ClearCreatorAfterInvoke: true,
 		Invokables:              make(map[string]*MockStub),
 		PrivateKeys:             make(map[string]*list.List),
+		History:                 make(map[string][]*queryresult.KeyModification),
 	}
 }
 
@@ -303,6 +309,7 @@ func (stub *MockStub) DumpStateBuffer() {
 			} else {
 				_ = stub.MockStub.PutState(s.Key, s.Value)
 			}
+			stub.addHistory(s)
 		}
 	} else {
 		stub.ChaincodeEvent = nil
@@ -310,6 +317,23 @@ func (stub *MockStub) DumpStateBuffer() {
 	stub.StateBuffer = nil
 }
 
+// addHistory adds key modification to history, only last modification of key in tx is stored
+func (stub *MockStub) addHistory(s *StateItem) {
+	mod := &queryresult.KeyModification{
+		TxId:      stub.TxID,
+		Value:     s.Value,
+		Timestamp: stub.TxTimestamp,
+		IsDelete:  s.Delete,
+	}
+
+	history := stub.History[s.Key]
+	if len(history) > 0 && history[len(history)-1].TxId == stub.TxID {
+		history[len(history)-1] = mod
+		return
+	}
+	stub.History[s.Key] = append(history, mod)
+}
+
 func (stub *MockStub) dumpEvents() {
 	if stub.ChaincodeEvent != nil {
 		// send only last event
@@ -336,6 +360,9 @@ func (stub *MockStub) MockTransactionStart(uuid string) {
 	stub.TxResult = peer.Response{}
 
 	stub.MockStub.MockTransactionStart(uuid)
+	if stub.txTimestamp != nil {
+		stub.TxTimestamp = stub.txTimestamp
+	}
 }
 
 func (stub *MockStub) MockTransactionEnd(uuid string) {
@@ -355,6 +382,7 @@ func (stub *MockStub) MockTransactionEnd(uuid string) {
 	if stub.ClearCreatorAfterInvoke {
 		stub.mockCreator = nil
 		stub.transient = nil
+		stub.txTimestamp = nil
 	}
 }
 
@@ -454,10 +482,51 @@ func (stub *MockStub) AddTransient(transient map[string][]byte) *MockStub {
 }
 
 // At mock tx timestamp
-//func (stub *MockStub) At(txTimestamp *timestamp.Timestamp) *MockStub {
-//	stub.TxTimestamp = txTimestamp
-//	return stub
-//}
+func (stub *MockStub) At(txTimestamp *timestamp.Timestamp) *MockStub {
+	stub.txTimestamp = txTimestamp
+	return stub
+}
+
+// GetHistoryForKey mocked, returns key modifications from newest to oldest as Fabric peer does
+func (stub *MockStub) GetHistoryForKey(key string) (shim.HistoryQueryIteratorInterface, error) {
+	history := stub.History[key]
+	items := make([]*queryresult.KeyModification, len(history))
+	for i := range history {
+		items[len(history)-1-i] = history[i]
+	}
+	return &MockHistoryQueryIterator{items: items}, nil
+}
+
+// MockHistoryQueryIterator iterates over mocked key modifications
+type MockHistoryQueryIterator struct {
+	items   []*queryresult.KeyModification
+	current int
+	closed  bool
+}
+
+// HasNext returns true if the history query iterator contains additional key modifications
+func (iter *MockHistoryQueryIterator) HasNext() bool {
+	return !iter.closed && iter.current < len(iter.items)
+}
+
+// Next returns the next key modification in the history query iterator
+func (iter *MockHistoryQueryIterator) Next() (*queryresult.KeyModification, error) {
+	if !iter.HasNext() {
+		return nil, errors.New("MockHistoryQueryIterator.Next() called when it does not HaveNext()")
+	}
+	item := iter.items[iter.current]
+	iter.current++
+	return item, nil
+}
+
+// Close closes the history query iterator
+func (iter *MockHistoryQueryIterator) Close() error {
+	if iter.closed {
+		return errors.New("MockHistoryQueryIterator.Close() called after Close()")
+	}
+	iter.closed = true
+	return nil
+}
 
 // DelPrivateData mocked
 func (stub *MockStub) DelPrivateData(collection string, key string) error {
@@ -479,6 +548,21 @@ func (stub *MockStub) DelPrivateData(collection string, key string) error {
 	return nil
 }
 
+// GetPrivateDataHash mocked, returns SHA-256 hash of private data value as Fabric peer does
+func (stub *MockStub) GetPrivateDataHash(collection string, key string) ([]byte, error) {
+	value, err := stub.GetPrivateData(collection, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(value) == 0 {
+		return nil, nil
+	}
+
+	hash := sha256.Sum256(value)
+	return hash[:], nil
+}
+
 type PrivateMockStateRangeQueryIterator struct {
 	Closed     bool
 	Stub       *MockStub
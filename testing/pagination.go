@@ -63,9 +63,9 @@ func NewMockStatesRangeQueryPagedIterator(stub *MockStub, startKey string, endKe
 	iter.Keys = new(list.List)
 
 	var elem = stub.Keys.Front()
-	// rewind until bookmark if is set
+	// rewind until bookmark if is set, as in peer, bookmark of range query is key, query starts from
 	for bookmark != "" && elem != nil {
-		if elem.Value.(string) == bookmark {
+		if elem.Value.(string) >= bookmark {
 			break
 		}
 		elem = elem.Next()